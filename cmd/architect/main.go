@@ -3,8 +3,10 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/faisalahmedsifat/architect/internal/commands"
+	"github.com/faisalahmedsifat/architect/internal/plugins"
 	"github.com/spf13/cobra"
 )
 
@@ -30,6 +32,19 @@ exact API contracts and business logic.`,
 	rootCmd.AddCommand(commands.ShowCmd())
 	rootCmd.AddCommand(commands.EditCmd())
 	rootCmd.AddCommand(commands.ExportCmd())
+	rootCmd.AddCommand(commands.TestCmd())
+	rootCmd.AddCommand(commands.MockCmd())
+	rootCmd.AddCommand(commands.ProxyCmd())
+	rootCmd.AddCommand(commands.PluginsCmd())
+
+	// Unknown commands run architect-<name> from PATH, like git
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		if cmd, _, err := rootCmd.Find(os.Args[1:]); err != nil || cmd == rootCmd {
+			if path, ok := plugins.Lookup(os.Args[1]); ok {
+				os.Exit(plugins.RunCommand(path, os.Args[2:]))
+			}
+		}
+	}
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
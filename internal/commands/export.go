@@ -1,13 +1,15 @@
 package commands
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
-	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/exporters"
 	"github.com/faisalahmedsifat/architect/internal/parser"
+	"github.com/faisalahmedsifat/architect/internal/plugins"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -16,321 +18,200 @@ func ExportCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export specifications",
-		Long:  "Export specifications in different formats",
-		RunE:  runExport,
+		Long: `Export specifications in different formats.
+
+Several formats can be exported in one run by repeating --format or giving a
+comma-separated list; each is written to its default file name, in
+--output-dir when given. Formats accept options given as --option name=value,
+or as format.name=value to set an option of one format only.
+
+Plugins enabled in .architect/config.yaml can add more formats. Run
+with --list to see the available formats and their options.`,
+		Example: `  architect export --format openapi
+  architect export --format openapi,markdown,postman --output-dir docs
+  architect export --format openapi --option title="Billing API" --option version=2.1.0
+  architect export --format markdown --output -
+  architect export --list`,
+		RunE: runExport,
 	}
 
-	cmd.Flags().String("format", "openapi", "Export format (openapi, markdown, postman)")
-	cmd.Flags().String("output", "", "Output file (default: stdout)")
+	cmd.Flags().StringSlice("format", []string{"openapi"}, "Export formats, comma-separated or repeated (see --list)")
+	cmd.Flags().String("output", "", "Output file, or - for stdout (default: the default file name of the format)")
+	cmd.Flags().String("output-dir", "", "Directory to write the exported files to")
+	cmd.Flags().StringArray("option", nil, "Format option as name=value or format.name=value (repeatable)")
+	cmd.Flags().Bool("list", false, "List the available formats and their options")
 
 	return cmd
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
-	format, _ := cmd.Flags().GetString("format")
+	formats, _ := cmd.Flags().GetStringSlice("format")
 	output, _ := cmd.Flags().GetString("output")
-
-	api, err := parser.ParseAPIYAML(".architect/api.yaml")
-	if err != nil {
-		return fmt.Errorf("failed to parse api.yaml: %w", err)
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	optionArgs, _ := cmd.Flags().GetStringArray("option")
+	list, _ := cmd.Flags().GetBool("list")
+
+	registry := exporters.NewRegistry()
+	if list {
+		// Plugins add formats but never replace built-in ones
+		for _, exporter := range plugins.Exporters() {
+			if _, err := registry.Get(exporter.Name()); err != nil {
+				registry.Register(exporter)
+			}
+		}
+		printExportFormats(registry)
+		return nil
 	}
 
-	var content string
-	var filename string
-
-	switch format {
-	case "openapi":
-		content = exportOpenAPI(api)
-		if output == "" {
-			filename = "openapi.json"
+	var selected []exporters.Exporter
+	seen := make(map[string]bool)
+	for _, format := range formats {
+		format = strings.TrimSpace(format)
+		if format == "" || seen[format] {
+			continue
 		}
-	case "markdown":
-		content = exportMarkdown(api)
-		if output == "" {
-			filename = "API_DOCUMENTATION.md"
-		}
-	case "postman":
-		content = exportPostman(api)
-		if output == "" {
-			filename = "postman_collection.json"
+		seen[format] = true
+		exporter, err := registry.Get(format)
+		if err != nil {
+			// Enabled plugins are only asked for formats that are not built in
+			pluginExporter, ok := plugins.FindExporter(format)
+			if !ok {
+				return err
+			}
+			exporter = pluginExporter
 		}
-	default:
-		return fmt.Errorf("unsupported format: %s", format)
+		selected = append(selected, exporter)
 	}
-
-	if output != "" {
-		filename = output
+	if len(selected) == 0 {
+		return fmt.Errorf("no export format given")
 	}
-
-	if filename != "" {
-		if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
-			return fmt.Errorf("failed to write file: %w", err)
-		}
-		color.Green("✅ Exported to %s", filename)
-	} else {
-		fmt.Print(content)
+	if len(selected) > 1 && output != "" {
+		return fmt.Errorf("--output can only be used with a single format; use --output-dir")
 	}
 
-	return nil
-}
-
-func exportOpenAPI(api *models.API) string {
-	// Simplified OpenAPI 3.0 export
-	openapi := map[string]interface{}{
-		"openapi": "3.0.0",
-		"info": map[string]string{
-			"title":   "API Documentation",
-			"version": "1.0.0",
-		},
-		"servers": []map[string]string{
-			{"url": api.BaseURL},
-		},
-		"paths": make(map[string]interface{}),
+	options, err := exportOptions(optionArgs, selected)
+	if err != nil {
+		return err
 	}
 
-	paths := openapi["paths"].(map[string]interface{})
-
-	for _, endpoint := range api.Endpoints {
-		path := endpoint.Path
-		if _, exists := paths[path]; !exists {
-			paths[path] = make(map[string]interface{})
-		}
+	api, err := parser.ParseAPIYAML(".architect/api.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to parse api.yaml: %w", err)
+	}
 
-		method := strings.ToLower(endpoint.Method)
-		paths[path].(map[string]interface{})[method] = map[string]interface{}{
-			"summary":   endpoint.Description,
-			"security":  []map[string][]string{},
-			"responses": buildResponses(endpoint),
+	// Export every format before writing anything, so that a failing format
+	// leaves no partial export behind
+	results := make([]*exporters.Output, len(selected))
+	for idx, exporter := range selected {
+		resolved, err := exporters.ResolveOptions(exporter, options[exporter.Name()])
+		if err != nil {
+			return err
 		}
-
-		if endpoint.Auth {
-			paths[path].(map[string]interface{})[method].(map[string]interface{})["security"] = []map[string][]string{
-				{"bearerAuth": []string{}},
+		results[idx], err = exporter.Export(api, resolved)
+		if err != nil {
+			if len(selected) > 1 {
+				return fmt.Errorf("%s: %w", exporter.Name(), err)
 			}
-		}
-
-		if endpoint.Request != nil && endpoint.Request.Body != nil {
-			paths[path].(map[string]interface{})[method].(map[string]interface{})["requestBody"] = buildRequestBody(endpoint.Request)
+			return err
 		}
 	}
 
-	if api.AuthType == "bearer" {
-		openapi["components"] = map[string]interface{}{
-			"securitySchemes": map[string]interface{}{
-				"bearerAuth": map[string]string{
-					"type":         "http",
-					"scheme":       "bearer",
-					"bearerFormat": "JWT",
-				},
-			},
+	if outputDir != "" {
+		if err := os.MkdirAll(outputDir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", outputDir, err)
 		}
 	}
 
-	data, _ := json.MarshalIndent(openapi, "", "  ")
-	return string(data)
-}
-
-func buildResponses(endpoint models.Endpoint) map[string]interface{} {
-	responses := make(map[string]interface{})
-
-	if endpoint.Response != nil {
-		status := fmt.Sprintf("%d", endpoint.Response.Status)
-		responses[status] = map[string]interface{}{
-			"description": "Success",
+	for idx, exporter := range selected {
+		result := results[idx]
+		filename := output
+		if filename == "" {
+			filename = filepath.Join(outputDir, exporter.DefaultFilename())
 		}
 
-		if endpoint.Response.Body != nil {
-			responses[status].(map[string]interface{})["content"] = map[string]interface{}{
-				"application/json": map[string]interface{}{
-					"schema": buildSchema(endpoint.Response.Body),
-				},
+		dir := filepath.Dir(filename)
+		if filename == "-" {
+			fmt.Print(result.Content)
+			dir = outputDir
+		} else {
+			if err := os.WriteFile(filename, []byte(result.Content), 0644); err != nil {
+				return fmt.Errorf("failed to write file: %w", err)
 			}
+			color.Green("✅ Exported to %s", filename)
 		}
-	}
 
-	for _, err := range endpoint.Errors {
-		status := fmt.Sprintf("%d", err.Status)
-		responses[status] = map[string]interface{}{
-			"description": err.Message,
+		// Companions are written next to the main export
+		names := make([]string, 0, len(result.Companions))
+		for name := range result.Companions {
+			names = append(names, name)
 		}
-	}
-
-	return responses
-}
-
-func buildRequestBody(request *models.EndpointRequest) map[string]interface{} {
-	return map[string]interface{}{
-		"required": true,
-		"content": map[string]interface{}{
-			"application/json": map[string]interface{}{
-				"schema": buildSchema(request.Body),
-			},
-		},
-	}
-}
-
-func buildSchema(fields map[string]string) map[string]interface{} {
-	schema := map[string]interface{}{
-		"type":       "object",
-		"properties": make(map[string]interface{}),
-		"required":   []string{},
-	}
-
-	props := schema["properties"].(map[string]interface{})
-	required := []string{}
-
-	for field, def := range fields {
-		parts := strings.Split(def, ",")
-		fieldType := strings.TrimSpace(parts[0])
-
-		props[field] = map[string]string{
-			"type": mapType(fieldType),
-		}
-
-		for _, part := range parts[1:] {
-			part = strings.TrimSpace(part)
-			if part == "required" {
-				required = append(required, field)
+		sort.Strings(names)
+		for _, name := range names {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, []byte(result.Companions[name]), 0644); err != nil {
+				return fmt.Errorf("failed to write file: %w", err)
 			}
+			color.Green("✅ Exported to %s", path)
 		}
 	}
 
-	if len(required) > 0 {
-		schema["required"] = required
-	}
-
-	return schema
+	return nil
 }
 
-func mapType(t string) string {
-	switch t {
-	case "uuid", "datetime":
-		return "string"
-	case "integer", "number":
-		return "number"
-	case "boolean":
-		return "boolean"
-	case "array":
-		return "array"
-	case "object":
-		return "object"
-	default:
-		return "string"
+// exportOptions assigns --option values to the selected formats. Unqualified
+// options apply to every selected format declaring them.
+func exportOptions(args []string, selected []exporters.Exporter) (map[string]map[string]string, error) {
+	options := make(map[string]map[string]string)
+	for _, exporter := range selected {
+		options[exporter.Name()] = make(map[string]string)
 	}
-}
-
-func exportMarkdown(api *models.API) string {
-	var sb strings.Builder
-
-	sb.WriteString("# API Documentation\n\n")
-	sb.WriteString("Base URL: `" + api.BaseURL + "`\n\n")
-
-	if api.AuthType != "none" {
-		sb.WriteString("## Authentication\n")
-		sb.WriteString("This API uses " + api.AuthType + " authentication.\n\n")
-	}
-
-	sb.WriteString("## Endpoints\n\n")
-
-	for _, endpoint := range api.Endpoints {
-		sb.WriteString("### " + endpoint.Method + " " + endpoint.Path + "\n")
-		sb.WriteString(endpoint.Description + "\n\n")
 
-		if endpoint.Auth {
-			sb.WriteString("**Authentication Required**\n\n")
+	for _, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid option %q: expected name=value", arg)
 		}
 
-		if endpoint.Request != nil && endpoint.Request.Body != nil {
-			sb.WriteString("**Request Body:**\n```json\n{\n")
-			for field, def := range endpoint.Request.Body {
-				sb.WriteString(fmt.Sprintf("  \"%s\": \"%s\",\n", field, def))
+		if format, option, qualified := strings.Cut(name, "."); qualified {
+			if options[format] == nil {
+				return nil, fmt.Errorf("option %s is for format %s, which is not being exported", name, format)
 			}
-			sb.WriteString("}\n```\n\n")
+			options[format][option] = value
+			continue
 		}
 
-		if endpoint.Response != nil && endpoint.Response.Body != nil {
-			sb.WriteString(fmt.Sprintf("**Response (%d):**\n```json\n{\n", endpoint.Response.Status))
-			for field, def := range endpoint.Response.Body {
-				sb.WriteString(fmt.Sprintf("  \"%s\": \"%s\",\n", field, def))
+		applied := false
+		for _, exporter := range selected {
+			for _, declared := range exporter.Options() {
+				if declared.Name == name {
+					options[exporter.Name()][name] = value
+					applied = true
+				}
 			}
-			sb.WriteString("}\n```\n\n")
 		}
-
-		if len(endpoint.Errors) > 0 {
-			sb.WriteString("**Errors:**\n")
-			for _, err := range endpoint.Errors {
-				sb.WriteString(fmt.Sprintf("- %d %s: %s\n", err.Status, err.Code, err.Message))
-			}
-			sb.WriteString("\n")
+		if !applied {
+			return nil, fmt.Errorf("no selected format has an option %q (see --list)", name)
 		}
-
-		sb.WriteString("---\n\n")
 	}
 
-	return sb.String()
+	return options, nil
 }
 
-func exportPostman(api *models.API) string {
-	// Simplified Postman collection export
-	collection := map[string]interface{}{
-		"info": map[string]interface{}{
-			"name":   "API Collection",
-			"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
-		},
-		"item": []interface{}{},
-	}
-
-	items := []interface{}{}
-
-	for _, endpoint := range api.Endpoints {
-		// Construct proper Postman URL object
-		fullURL := api.BaseURL + endpoint.Path
-		urlObject := map[string]interface{}{
-			"raw": fullURL,
-		}
-
-		item := map[string]interface{}{
-			"name": endpoint.Description,
-			"request": map[string]interface{}{
-				"method": endpoint.Method,
-				"url":    urlObject,
-				"header": []map[string]string{},
-			},
-		}
-
-		if endpoint.Auth {
-			item["request"].(map[string]interface{})["header"] = append(
-				item["request"].(map[string]interface{})["header"].([]map[string]string),
-				map[string]string{
-					"key":   "Authorization",
-					"value": "Bearer {{token}}",
-				},
-			)
-		}
-
-		if endpoint.Request != nil && endpoint.Request.Body != nil {
-			body := make(map[string]interface{})
-			for field := range endpoint.Request.Body {
-				body[field] = ""
-			}
-
-			bodyJSON, _ := json.Marshal(body)
-			item["request"].(map[string]interface{})["body"] = map[string]interface{}{
-				"mode": "raw",
-				"raw":  string(bodyJSON),
-				"options": map[string]interface{}{
-					"raw": map[string]string{
-						"language": "json",
-					},
-				},
+// printExportFormats lists the registered formats with their options
+func printExportFormats(registry *exporters.Registry) {
+	color.Cyan("📦 Available export formats:")
+	fmt.Println()
+
+	for _, exporter := range registry.List() {
+		fmt.Printf("  %-10s %s\n", exporter.Name(), exporter.Description())
+		fmt.Printf("  %-10s default file: %s (%s)\n", "", exporter.DefaultFilename(), strings.Join(exporter.GetSupportedExtensions(), ", "))
+		for _, option := range exporter.Options() {
+			line := fmt.Sprintf("  %-10s --option %-16s %s", "", option.Name+"=...", option.Description)
+			if option.Default != "" {
+				line += fmt.Sprintf(" (default %q)", option.Default)
 			}
+			fmt.Println(line)
 		}
-
-		items = append(items, item)
+		fmt.Println()
 	}
-
-	collection["item"] = items
-
-	data, _ := json.MarshalIndent(collection, "", "  ")
-	return string(data)
 }
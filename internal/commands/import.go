@@ -1,12 +1,20 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/AlecAivazis/survey/v2"
 	"github.com/faisalahmedsifat/architect/internal/importers"
 	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/specdiff"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -14,9 +22,12 @@ import (
 
 func ImportCmd() *cobra.Command {
 	var (
-		format    string
-		merge     bool
-		overwrite bool
+		format       string
+		merge        bool
+		overwrite    bool
+		dryRun       bool
+		strategy     string
+		environments []string
 	)
 
 	cmd := &cobra.Command{
@@ -24,26 +35,90 @@ func ImportCmd() *cobra.Command {
 		Short: "Import API specification from external formats",
 		Long: `Import API specifications from various formats including:
 - OpenAPI 3.0 (JSON/YAML)
-- Postman Collections (JSON) [Coming Soon]
+- Postman Collections (JSON), with variables resolved from Postman
+  environment files given with --environment; each environment becomes a
+  server
 - Existing Architect specifications (YAML)
-
-The import will convert the external format to Architect's specification format.`,
+- Insomnia v4 exports (JSON/YAML)
+- HAR files recorded by browser devtools or proxies (endpoints, path
+  parameters, field types, auth and status codes are inferred from traffic)
+- curl commands, from a file or from stdin with "-" (merged into the
+  existing specification unless --overwrite is given)
+- GraphQL schemas in SDL (.graphql, .graphqls, .gql); queries, mutations
+  and types are stored in the graphql section of api.yaml, next to the
+  existing REST endpoints unless --overwrite is given
+- Protocol Buffers files (.proto); rpcs become endpoints on their
+  google.api.http bindings
+- AsyncAPI 2.x and 3.x documents (JSON/YAML); the messages of each channel
+  are stored in the channels section of api.yaml with the role of the
+  service (producer or consumer), next to the existing endpoints unless
+  --overwrite is given
+- RAML 1.0 definitions (.raml); resource types, traits and !include files
+  are resolved
+- API Blueprint documents (.apib); MSON attributes and data structures,
+  JSON schemas and JSON bodies become fields
+
+RAML and API Blueprint documents are also recognized by their "#%RAML 1.0"
+and "FORMAT: 1A" header lines.
+
+Plugins enabled in .architect/config.yaml can add more formats; see
+'architect plugins list'.
+
+The import will convert the external format to Architect's specification format.
+With --merge, endpoints are merged field by field with the previous import
+of the same file, recorded in .architect/imports/: descriptions, errors,
+field rules and endpoints added or changed locally are kept, and changes
+made upstream are applied. Fields changed on both sides and endpoints
+removed upstream are conflicts, decided by --strategy: ours keeps the local
+value, theirs takes the imported one and interactive asks for each
+conflict. Conflicts are listed after the merge. Without a previous import,
+such as from stdin, no endpoint is removed and every local value differing
+from the imported one is a conflict.
+
+With --dry-run, nothing is written: the endpoints that would be added, changed
+or removed are listed with their field changes, in replace and --merge modes.
+Replacing an existing api.yaml still requires --overwrite, and conflicts are
+listed without asking: --strategy interactive previews them as ours.`,
+		Example: `  architect import openapi.yaml
+  architect import collection.json --environment staging.json --environment production.json
+  architect import recording.har
+  pbpaste | architect import -
+  architect import schema.graphql
+  architect import openapi.yaml --merge --dry-run
+  architect import openapi.yaml --merge --strategy interactive`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runImport(args[0], format, merge, overwrite)
+			return runImport(args[0], format, merge, overwrite, dryRun, strategy, environments)
 		},
 	}
 
-	cmd.Flags().StringVarP(&format, "format", "f", "", "Force specific format (openapi, postman, architect)")
+	cmd.Flags().StringVarP(&format, "format", "f", "", "Force specific format (openapi, postman, insomnia, architect, har, curl, graphql, proto, asyncapi, raml, blueprint, or a plugin format)")
 	cmd.Flags().BoolVarP(&merge, "merge", "m", false, "Merge with existing specification instead of replacing")
 	cmd.Flags().BoolVarP(&overwrite, "overwrite", "o", false, "Overwrite existing files without confirmation")
+	cmd.Flags().StringVar(&strategy, "strategy", specdiff.Theirs, "Resolution of --merge conflicts: ours, theirs or interactive")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes to api.yaml without writing anything")
+	cmd.Flags().StringArrayVarP(&environments, "environment", "e", nil, "Postman environment file to resolve variables from (repeatable)")
 
 	return cmd
 }
 
-func runImport(filename, format string, merge, overwrite bool) error {
-	// Check if file exists
-	if _, err := os.Stat(filename); os.IsNotExist(err) {
+func runImport(filename, format string, merge, overwrite, dryRun bool, strategy string, environments []string) error {
+	var resolve specdiff.Resolver
+	switch strategy {
+	case specdiff.Ours, specdiff.Theirs:
+		resolve = specdiff.Strategy(strategy)
+	case "interactive":
+		resolve = resolveConflictInteractively
+		// A dry run reports conflicts without asking, keeping the local side
+		if dryRun {
+			resolve = specdiff.Strategy(specdiff.Ours)
+		}
+	default:
+		return fmt.Errorf("invalid strategy: %s (expected ours, theirs or interactive)", strategy)
+	}
+
+	// Check if file exists ("-" reads from stdin)
+	if _, err := os.Stat(filename); filename != "-" && os.IsNotExist(err) {
 		return fmt.Errorf("file not found: %s", filename)
 	}
 
@@ -65,12 +140,26 @@ func runImport(filename, format string, merge, overwrite bool) error {
 		color.Blue("🔍 Detected format: %s", format)
 	}
 
+	// curl snippets describe a few endpoints, not a whole API, and GraphQL
+	// schemas and event channels sit next to the REST endpoints
+	if (format == "curl" || format == "graphql" || format == "asyncapi") && !overwrite {
+		merge = true
+	}
+
 	// Create appropriate importer
 	importer, err := factory.CreateImporter(format)
 	if err != nil {
 		return fmt.Errorf("failed to create importer: %w", err)
 	}
 
+	if len(environments) > 0 {
+		postman, ok := importer.(*importers.PostmanImporter)
+		if !ok {
+			return fmt.Errorf("--environment is only supported for Postman collections")
+		}
+		postman.EnvironmentFiles = environments
+	}
+
 	// Import the API specification
 	color.Blue("📥 Importing from %s...", filename)
 	importedAPI, err := importer.Import(filename)
@@ -87,10 +176,12 @@ func runImport(filename, format string, merge, overwrite bool) error {
 	var finalAPI *models.API
 	if merge {
 		color.Blue("🔄 Merging with existing specification...")
-		finalAPI, err = mergeWithExisting(importedAPI)
+		var conflicts []specdiff.Conflict
+		finalAPI, conflicts, err = mergeWithExisting(importedAPI, filename, resolve)
 		if err != nil {
 			return fmt.Errorf("failed to merge: %w", err)
 		}
+		printMergeConflicts(conflicts)
 	} else {
 		finalAPI = importedAPI
 	}
@@ -105,11 +196,26 @@ func runImport(filename, format string, merge, overwrite bool) error {
 		}
 	}
 
+	// A dry run shows what would be written and leaves the files alone
+	if dryRun {
+		existingAPI, err := readExistingAPI()
+		if err != nil {
+			return err
+		}
+		printImportDiff(specdiff.Compare(existingAPI, finalAPI))
+		return nil
+	}
+
 	// Write the API specification
-	if err := writeAPISpec(finalAPI); err != nil {
+	if err := writeAPISpec(os.Stdout, finalAPI); err != nil {
 		return fmt.Errorf("failed to write API specification: %w", err)
 	}
 
+	// Record the import as the base of the next merge of the same file
+	if err := writeImportBase(filename, importedAPI); err != nil {
+		color.Yellow("⚠️  Failed to record import base: %v", err)
+	}
+
 	// Generate basic project.md if it doesn't exist
 	if _, err := os.Stat(".architect/project.md"); os.IsNotExist(err) {
 		if err := writeBasicProjectMd(importedAPI); err != nil {
@@ -120,10 +226,33 @@ func runImport(filename, format string, merge, overwrite bool) error {
 	}
 
 	// Show import summary
-	color.Green("✅ Successfully imported %d endpoints from %s", len(finalAPI.Endpoints), filename)
+	if importedAPI.GraphQL != nil {
+		color.Green("✅ Successfully imported %d GraphQL operations and %d types from %s",
+			len(importedAPI.GraphQL.Operations), len(importedAPI.GraphQL.Types), filename)
+	} else if len(importedAPI.Channels) > 0 {
+		color.Green("✅ Successfully imported %d channel messages from %s", len(importedAPI.Channels), filename)
+	} else {
+		color.Green("✅ Successfully imported %d endpoints from %s", len(finalAPI.Endpoints), filename)
+	}
 
-	// List imported endpoints
-	if len(finalAPI.Endpoints) > 0 {
+	// List imported operations or endpoints
+	if importedAPI.GraphQL != nil {
+		color.Blue("\n📋 Imported operations:")
+		for _, operation := range importedAPI.GraphQL.Operations {
+			fmt.Printf("  %s %s: %s\n",
+				color.CyanString(operation.Type),
+				operation.Name,
+				operation.Output)
+		}
+	} else if len(importedAPI.Channels) > 0 {
+		color.Blue("\n📋 Imported channels:")
+		for _, channel := range importedAPI.Channels {
+			fmt.Printf("  %s %s (%s)\n",
+				color.CyanString(channel.Role),
+				channel.Name,
+				channel.Message)
+		}
+	} else if len(finalAPI.Endpoints) > 0 {
 		color.Blue("\n📋 Imported endpoints:")
 		for _, endpoint := range finalAPI.Endpoints {
 			authIndicator := ""
@@ -142,71 +271,178 @@ func runImport(filename, format string, merge, overwrite bool) error {
 	return runSync(nil, []string{})
 }
 
-func mergeWithExisting(importedAPI *models.API) (*models.API, error) {
-	// Try to load existing API
-	existingAPI := &models.API{
-		BaseURL:   "/api/v1",
-		AuthType:  "none",
-		Endpoints: []models.Endpoint{},
+// mergeWithExisting merges an imported API into the existing api.yaml,
+// field by field against the previous import of the same file. Imports from
+// stdin have no previous import and never remove anything.
+func mergeWithExisting(importedAPI *models.API, filename string, resolve specdiff.Resolver) (*models.API, []specdiff.Conflict, error) {
+	existingAPI, err := readExistingAPI()
+	if err != nil {
+		return nil, nil, err
 	}
-
-	if _, err := os.Stat(".architect/api.yaml"); err == nil {
-		// File exists, try to parse it
-		content, err := os.ReadFile(".architect/api.yaml")
-		if err != nil {
-			return nil, fmt.Errorf("failed to read existing api.yaml: %w", err)
+	if existingAPI == nil {
+		existingAPI = &models.API{
+			BaseURL:   "/api/v1",
+			AuthType:  "none",
+			Endpoints: []models.Endpoint{},
 		}
+	}
+
+	baseAPI, err := readImportBase(filename)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		if err := yaml.Unmarshal(content, existingAPI); err != nil {
-			return nil, fmt.Errorf("failed to parse existing api.yaml: %w", err)
+	// Imports without a base URL, such as curl commands, have absolute paths
+	// that are made relative to the existing base path
+	basePath := existingAPI.BaseURL
+	if parsed, err := url.Parse(basePath); err == nil && parsed.Host != "" {
+		basePath = parsed.Path
+	}
+	basePath = strings.TrimRight(basePath, "/")
+	if importedAPI.BaseURL == "" && basePath != "" {
+		for idx, endpoint := range importedAPI.Endpoints {
+			if strings.HasPrefix(endpoint.Path, basePath+"/") {
+				importedAPI.Endpoints[idx].Path = strings.TrimPrefix(endpoint.Path, basePath)
+			}
 		}
 	}
 
-	// Merge strategy:
-	// 1. Keep existing base URL if imported one is default
-	// 2. Upgrade auth type if imported is more secure
-	// 3. Merge endpoints (avoid duplicates by path+method)
+	// Settings missing from the import, such as the base URL of curl
+	// commands, are not a change
+	theirs := *importedAPI
+	if theirs.BaseURL == "" {
+		theirs.BaseURL = existingAPI.BaseURL
+	}
+	if theirs.AuthType == "" {
+		theirs.AuthType = existingAPI.AuthType
+	}
+
+	return specdiff.Merge(baseAPI, existingAPI, &theirs, resolve)
+}
+
+var importBaseUnsafe = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
 
-	mergedAPI := &models.API{
-		BaseURL:   existingAPI.BaseURL,
-		AuthType:  existingAPI.AuthType,
-		Endpoints: []models.Endpoint{},
+// importBase is the last import of a source file, the base of the next merge
+// of the same file
+type importBase struct {
+	// Source is the absolute path of the imported file
+	Source string      `yaml:"source"`
+	API    *models.API `yaml:"api"`
+}
+
+// importSource returns the absolute path identifying an imported file, or ""
+// for stdin, which has no identity to merge against
+func importSource(filename string) string {
+	if filename == "-" {
+		return ""
+	}
+	source, err := filepath.Abs(filename)
+	if err != nil {
+		return ""
 	}
+	return source
+}
 
-	// Update base URL if imported has non-default value
-	if importedAPI.BaseURL != "" && importedAPI.BaseURL != "/api/v1" {
-		mergedAPI.BaseURL = importedAPI.BaseURL
+// importBasePath returns where the last import of a source file is recorded.
+// Files with the same name in different directories have different bases.
+func importBasePath(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	name := importBaseUnsafe.ReplaceAllString(filepath.Base(source), "_")
+	return filepath.Join(".architect", "imports", name+"-"+hex.EncodeToString(sum[:6])+".yaml")
+}
+
+// readImportBase reads the last import of a file, or returns nil when the
+// file was never imported or is read from stdin
+func readImportBase(filename string) (*models.API, error) {
+	source := importSource(filename)
+	if source == "" {
+		return nil, nil
+	}
+	path := importBasePath(source)
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
 	}
 
-	// Update auth type if imported is not "none"
-	if importedAPI.AuthType != "" && importedAPI.AuthType != "none" {
-		mergedAPI.AuthType = importedAPI.AuthType
+	var base importBase
+	if err := yaml.Unmarshal(content, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	// A base of another source would turn its endpoints into deletions
+	if base.Source != source {
+		return nil, nil
 	}
+	return base.API, nil
+}
 
-	// Create endpoint map for deduplication
-	endpointMap := make(map[string]models.Endpoint)
+// writeImportBase records an import as the base of the next merge of the
+// same file
+func writeImportBase(filename string, api *models.API) error {
+	source := importSource(filename)
+	if source == "" {
+		return nil
+	}
+	path := importBasePath(source)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(importBase{Source: source, API: api})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// resolveConflictInteractively asks which side of a conflict to keep
+func resolveConflictInteractively(conflict *specdiff.Conflict) error {
+	location := conflict.Endpoint
+	if conflict.Field != "" {
+		location = strings.TrimSpace(location + " " + conflict.Field)
+	}
+
+	ours := "Keep ours: " + conflictValue(conflict.Ours)
+	theirs := "Take theirs: " + conflictValue(conflict.Theirs)
+	var choice string
+	prompt := &survey.Select{
+		Message: fmt.Sprintf("Conflict in %s", location),
+		Options: []string{ours, theirs},
+	}
+	if err := survey.AskOne(prompt, &choice); err != nil {
+		return fmt.Errorf("conflict in %s: %w", location, err)
+	}
 
-	// Add existing endpoints
-	for _, endpoint := range existingAPI.Endpoints {
-		key := fmt.Sprintf("%s:%s", endpoint.Method, endpoint.Path)
-		endpointMap[key] = endpoint
+	conflict.Resolution = specdiff.Ours
+	if choice == theirs {
+		conflict.Resolution = specdiff.Theirs
 	}
+	return nil
+}
 
-	// Add imported endpoints (will overwrite duplicates)
-	for _, endpoint := range importedAPI.Endpoints {
-		key := fmt.Sprintf("%s:%s", endpoint.Method, endpoint.Path)
-		endpointMap[key] = endpoint
+func conflictValue(value string) string {
+	if value == "" {
+		return "(none)"
 	}
+	return value
+}
 
-	// Convert map back to slice
-	for _, endpoint := range endpointMap {
-		mergedAPI.Endpoints = append(mergedAPI.Endpoints, endpoint)
+// printMergeConflicts reports the conflicts of a merge and how they were
+// resolved
+func printMergeConflicts(conflicts []specdiff.Conflict) {
+	if len(conflicts) == 0 {
+		return
 	}
 
-	return mergedAPI, nil
+	color.Yellow("\n⚠️  %d merge conflicts:", len(conflicts))
+	for _, conflict := range conflicts {
+		fmt.Printf("  %s → kept %s\n", conflict, conflict.Resolution)
+	}
+	fmt.Println()
 }
 
-func writeAPISpec(api *models.API) error {
+func writeAPISpec(out io.Writer, api *models.API) error {
 	// Marshal to YAML
 	apiData, err := yaml.Marshal(api)
 	if err != nil {
@@ -218,7 +454,7 @@ func writeAPISpec(api *models.API) error {
 		return fmt.Errorf("failed to write api.yaml: %w", err)
 	}
 
-	color.Green("💾 Updated .architect/api.yaml")
+	color.New(color.FgGreen).Fprintln(out, "💾 Updated .architect/api.yaml")
 	return nil
 }
 
@@ -295,3 +531,86 @@ func generateAuthDescription(authType string) string {
 		return fmt.Sprintf("%s authentication required.", strings.Title(authType))
 	}
 }
+
+// readExistingAPI reads the current api.yaml, or returns nil when there is
+// none yet
+func readExistingAPI() (*models.API, error) {
+	content, err := os.ReadFile(".architect/api.yaml")
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read existing api.yaml: %w", err)
+	}
+
+	api := &models.API{}
+	if err := yaml.Unmarshal(content, api); err != nil {
+		return nil, fmt.Errorf("failed to parse existing api.yaml: %w", err)
+	}
+	return api, nil
+}
+
+// printImportDiff shows the changes an import would make to api.yaml
+func printImportDiff(diff *specdiff.Diff) {
+	color.Cyan("\n🔍 Dry run: changes to .architect/api.yaml")
+
+	if diff.Empty() {
+		fmt.Println("\n  No changes")
+		color.Yellow("\n⚠️  Dry run: nothing was written")
+		return
+	}
+
+	if len(diff.Settings) > 0 {
+		color.Blue("\n⚙️  Settings:")
+		for _, change := range diff.Settings {
+			printFieldChange("  ", change)
+		}
+	}
+
+	if len(diff.Added)+len(diff.Changed)+len(diff.Removed) > 0 {
+		color.Blue("\n📋 Endpoints:")
+		for _, endpoint := range diff.Added {
+			color.Green("  + %s %s", endpoint.Method, endpoint.Path)
+		}
+		for _, endpoint := range diff.Changed {
+			color.Yellow("  ~ %s %s", endpoint.Method, endpoint.Path)
+			for _, change := range endpoint.Changes {
+				printFieldChange("      ", change)
+			}
+		}
+		for _, endpoint := range diff.Removed {
+			color.Red("  - %s %s", endpoint.Method, endpoint.Path)
+		}
+	}
+
+	if len(diff.Items) > 0 {
+		color.Blue("\n🧩 Other sections:")
+		for _, item := range diff.Items {
+			switch item.Kind {
+			case specdiff.Added:
+				color.Green("  + %s: %s", item.Section, item.Name)
+			case specdiff.Removed:
+				color.Red("  - %s: %s", item.Section, item.Name)
+			default:
+				color.Yellow("  ~ %s: %s", item.Section, item.Name)
+				for _, change := range item.Changes {
+					printFieldChange("      ", change)
+				}
+			}
+		}
+	}
+
+	fmt.Printf("\n📊 Endpoints: %d added, %d changed, %d removed\n", len(diff.Added), len(diff.Changed), len(diff.Removed))
+	color.Yellow("⚠️  Dry run: nothing was written. Run again without --dry-run to apply")
+}
+
+func printFieldChange(indent string, change specdiff.Change) {
+	switch change.Kind() {
+	case specdiff.Added:
+		color.Green("%s+ %s", indent, change)
+	case specdiff.Removed:
+		color.Red("%s- %s", indent, change)
+	default:
+		color.Yellow("%s~ %s", indent, change)
+	}
+}
@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/specdiff"
+	"gopkg.in/yaml.v3"
+)
+
+func TestImportBasePath(t *testing.T) {
+	first := importBasePath(filepath.Join("/work", "users", "openapi.yaml"))
+	second := importBasePath(filepath.Join("/work", "orders", "openapi.yaml"))
+	if first == second {
+		t.Errorf("files with the same name share the base %s", first)
+	}
+	if filepath.Dir(first) != filepath.Join(".architect", "imports") {
+		t.Errorf("base %s is outside .architect/imports", first)
+	}
+}
+
+func TestImportBaseIsKeyedBySource(t *testing.T) {
+	files := map[string]string{
+		"users/openapi.yaml":  "openapi: 3.0.0\n",
+		"orders/openapi.yaml": "openapi: 3.0.0\n",
+	}
+	inProject(t, files, func() {
+		api := &models.API{Endpoints: []models.Endpoint{{Method: "GET", Path: "/users"}}}
+		if err := writeImportBase("users/openapi.yaml", api); err != nil {
+			t.Fatal(err)
+		}
+
+		base, err := readImportBase("users/openapi.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if base == nil || len(base.Endpoints) != 1 {
+			t.Errorf("base of users/openapi.yaml = %+v, want the recorded import", base)
+		}
+
+		base, err = readImportBase("orders/openapi.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if base != nil {
+			t.Errorf("orders/openapi.yaml has the base of users/openapi.yaml: %+v", base)
+		}
+	})
+}
+
+func TestImportBaseSkipsStdin(t *testing.T) {
+	inProject(t, nil, func() {
+		api := &models.API{Endpoints: []models.Endpoint{{Method: "GET", Path: "/users"}}}
+		if err := writeImportBase("-", api); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(".architect", "imports")); !os.IsNotExist(err) {
+			t.Errorf("stdin import recorded a base: %v", err)
+		}
+		base, err := readImportBase("-")
+		if err != nil || base != nil {
+			t.Errorf("readImportBase(-) = %+v, %v, want no base", base, err)
+		}
+	})
+}
+
+func TestMergeWithExistingKeepsEndpointsOfOtherSources(t *testing.T) {
+	existing := &models.API{
+		BaseURL:  "/api",
+		AuthType: "bearer",
+		Endpoints: []models.Endpoint{
+			{Method: "GET", Path: "/users"},
+			{Method: "GET", Path: "/orders"},
+		},
+	}
+	content, err := yaml.Marshal(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		".architect/api.yaml": string(content),
+		"users/openapi.yaml":  "openapi: 3.0.0\n",
+		"orders/openapi.yaml": "openapi: 3.0.0\n",
+	}
+
+	inProject(t, files, func() {
+		// users/openapi.yaml was imported before, orders/openapi.yaml was not
+		users := &models.API{BaseURL: "/api", Endpoints: []models.Endpoint{{Method: "GET", Path: "/users"}}}
+		if err := writeImportBase("users/openapi.yaml", users); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, filename := range []string{"orders/openapi.yaml", "-"} {
+			imported := &models.API{BaseURL: "/api", Endpoints: []models.Endpoint{{Method: "POST", Path: "/orders"}}}
+			merged, conflicts, err := mergeWithExisting(imported, filename, specdiff.Strategy(specdiff.Theirs))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(merged.Endpoints) != 3 || len(conflicts) != 0 {
+				t.Errorf("import of %s: endpoints = %+v, conflicts = %v, want all three kept", filename, merged.Endpoints, conflicts)
+			}
+		}
+
+		// Re-importing users/openapi.yaml without /users is an upstream
+		// removal, reported as a conflict
+		imported := &models.API{BaseURL: "/api"}
+		merged, conflicts, err := mergeWithExisting(imported, "users/openapi.yaml", specdiff.Strategy(specdiff.Ours))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(merged.Endpoints) != 2 || len(conflicts) != 1 {
+			t.Errorf("re-import: endpoints = %+v, conflicts = %v, want /users kept with a conflict", merged.Endpoints, conflicts)
+		}
+	})
+}
+
+// dryRunProject is a project with a local description, and an incoming
+// specification describing the same endpoint differently
+func dryRunProject(t *testing.T) map[string]string {
+	t.Helper()
+	local := &models.API{BaseURL: "/api", AuthType: "none", Endpoints: []models.Endpoint{
+		{Method: "GET", Path: "/users", Description: "Lists active users only"},
+	}}
+	incoming := &models.API{BaseURL: "/api", AuthType: "none", Endpoints: []models.Endpoint{
+		{Method: "GET", Path: "/users", Description: "List users"},
+		{Method: "POST", Path: "/users", Description: "Create a user"},
+	}}
+	files := make(map[string]string)
+	for name, api := range map[string]*models.API{".architect/api.yaml": local, "upstream.yaml": incoming} {
+		content, err := yaml.Marshal(api)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[name] = string(content)
+	}
+	return files
+}
+
+func TestImportDryRunRequiresOverwrite(t *testing.T) {
+	files := dryRunProject(t)
+	inProject(t, files, func() {
+		if err := runImport("upstream.yaml", "architect", false, false, true, specdiff.Theirs, nil); err == nil {
+			t.Error("dry run replacing an existing api.yaml without --overwrite succeeded")
+		}
+
+		var err error
+		output := captureStdout(t, func() {
+			err = runImport("upstream.yaml", "architect", false, true, true, specdiff.Theirs, nil)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(output, "1 added, 1 changed, 0 removed") {
+			t.Errorf("dry run output = %q, want one added and one changed endpoint", output)
+		}
+
+		content, err := os.ReadFile(".architect/api.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != files[".architect/api.yaml"] {
+			t.Errorf("dry run wrote api.yaml:\n%s", content)
+		}
+	})
+}
+
+func TestImportDryRunReportsConflictsWithoutPrompting(t *testing.T) {
+	files := dryRunProject(t)
+	inProject(t, files, func() {
+		var err error
+		output := captureStdout(t, func() {
+			err = runImport("upstream.yaml", "architect", true, false, true, "interactive", nil)
+		})
+		if err != nil {
+			t.Fatalf("interactive dry run: %v", err)
+		}
+		if !strings.Contains(output, "GET /users description") || !strings.Contains(output, "kept ours") {
+			t.Errorf("dry run output = %q, want the description conflict kept as ours", output)
+		}
+		if _, err := os.Stat(importBasePath(importSource("upstream.yaml"))); !os.IsNotExist(err) {
+			t.Errorf("dry run recorded an import base: %v", err)
+		}
+	})
+}
+
+func TestMergeWithExistingWithoutBaseReportsLocalChanges(t *testing.T) {
+	existing := &models.API{
+		BaseURL:  "/api",
+		AuthType: "bearer",
+		Servers:  []models.Server{{Name: "staging", URL: "https://staging.example.com"}},
+		Endpoints: []models.Endpoint{
+			{Method: "GET", Path: "/users", Description: "Lists active users only"},
+		},
+	}
+	content, err := yaml.Marshal(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		".architect/api.yaml": string(content),
+		"openapi.yaml":        "openapi: 3.0.0\n",
+	}
+
+	inProject(t, files, func() {
+		imported := func() *models.API {
+			return &models.API{
+				BaseURL:   "/api",
+				Servers:   []models.Server{{Name: "production", URL: "https://api.example.com"}},
+				Endpoints: []models.Endpoint{{Method: "GET", Path: "/users", Description: "List users"}},
+			}
+		}
+
+		// The first merge of a file has no base: local values are conflicts
+		merged, conflicts, err := mergeWithExisting(imported(), "openapi.yaml", specdiff.Strategy(specdiff.Ours))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(conflicts) != 2 {
+			t.Errorf("conflicts = %v, want description and servers", conflicts)
+		}
+		if merged.Endpoints[0].Description != "Lists active users only" || merged.Servers[0].Name != "staging" {
+			t.Errorf("merged = %+v, want the local description and servers kept", merged)
+		}
+
+		merged, conflicts, err = mergeWithExisting(imported(), "-", specdiff.Strategy(specdiff.Theirs))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(conflicts) != 2 || merged.Endpoints[0].Description != "List users" {
+			t.Errorf("stdin import: merged = %+v, conflicts = %v, want the imported values with 2 conflicts", merged, conflicts)
+		}
+	})
+}
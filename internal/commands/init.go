@@ -51,7 +51,7 @@ func runInit(cmd *cobra.Command, args []string) error {
 
 	if !flagQuiet {
 		color.Cyan("📋 Architect - Project Specification Setup")
-		fmt.Println("─────────────────────────────────────────\n")
+		fmt.Print("─────────────────────────────────────────\n\n")
 	}
 
 	// Check if .architect already exists
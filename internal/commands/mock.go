@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/fakedata"
+	"github.com/faisalahmedsifat/architect/internal/mock"
+	"github.com/faisalahmedsifat/architect/internal/parser"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func MockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mock",
+		Short: "Serve a mock API from the specification",
+		Long: `Serves every endpoint in .architect/api.yaml with generated responses.
+
+Requests are matched against the declared paths, including path parameters,
+and validated against the declared params, query and body. Invalid requests
+receive a 400 VALIDATION_ERROR response. Valid requests receive the declared
+status with a body generated from the field types (uuid, datetime, email...).
+
+Declared errors can be triggered with the X-Architect-Error header, using
+either the status or the error code:
+
+  curl -H 'X-Architect-Error: 404' localhost:4010/users/1
+  curl -H 'X-Architect-Error: USER_NOT_FOUND' localhost:4010/users/1
+
+The mock reloads api.yaml whenever it changes.
+
+Webhooks declared in api.yaml can be delivered to a local URL with
+'architect mock webhook'.`,
+		Example: `  architect mock
+  architect mock --port 8080 --seed 42`,
+		RunE: runMock,
+	}
+
+	cmd.AddCommand(mockWebhookCmd())
+
+	cmd.Flags().IntP("port", "p", 4010, "Port to listen on")
+	cmd.Flags().String("host", "localhost", "Host to listen on")
+	cmd.Flags().Int64("seed", 0, "Seed for generated response data (default: random)")
+	cmd.Flags().Bool("no-watch", false, "Do not reload api.yaml when it changes")
+
+	return cmd
+}
+
+func runMock(cmd *cobra.Command, args []string) error {
+	port, _ := cmd.Flags().GetInt("port")
+	host, _ := cmd.Flags().GetString("host")
+	seed, _ := cmd.Flags().GetInt64("seed")
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	noWatch, _ := cmd.Flags().GetBool("no-watch")
+
+	api, err := parser.ParseAPIYAML(apiSpecFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse api.yaml: %w", err)
+	}
+
+	server := mock.NewServer(api, seed, logMockRequest)
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	color.Green("🎭 Mock server for %d endpoints listening on http://%s", len(api.Endpoints), listener.Addr())
+	fmt.Printf("Trigger declared errors with the %s header\n", mock.ErrorHeader)
+	fmt.Print("Press Ctrl+C to stop\n\n")
+
+	if !noWatch {
+		go func() {
+			err := watchSpecs(func() {
+				updated, err := parser.ParseAPIYAML(apiSpecFile)
+				if err != nil {
+					color.Red("Error reloading api.yaml, still serving the previous version: %v", err)
+					return
+				}
+				server.SetAPI(updated)
+				timestamp := time.Now().Format("15:04:05")
+				color.Blue("[%s] Reloaded %d endpoints", timestamp, len(updated.Endpoints))
+			})
+			if err != nil {
+				color.Red("Hot reload disabled: %v", err)
+			}
+		}()
+	}
+
+	return http.Serve(listener, server)
+}
+
+func mockWebhookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhook [event...]",
+		Short: "Send sample webhook deliveries to a URL",
+		Long: `Sends a sample delivery of each webhook declared in .architect/api.yaml,
+or only of the given events, to a local URL.
+
+Payloads and headers are generated from the declared fields. With --secret,
+deliveries are signed as declared in the webhook's signature section. With
+--retry, deliveries that are not acknowledged with a 2xx status are retried
+following the webhook's retry section.`,
+		Example: `  architect mock webhook --url http://localhost:3000/webhooks
+  architect mock webhook order.paid --url http://localhost:3000/webhooks --secret whsec_test --retry`,
+		RunE: runMockWebhook,
+	}
+
+	cmd.Flags().String("url", "", "URL to deliver the webhooks to (required)")
+	cmd.Flags().String("secret", "", "Secret used to sign deliveries")
+	cmd.Flags().Int64("seed", 0, "Seed for generated payloads (default: random)")
+	cmd.Flags().Bool("retry", false, "Retry unacknowledged deliveries as declared in api.yaml")
+	cmd.Flags().Duration("timeout", 10*time.Second, "Timeout of each delivery attempt")
+	cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+func runMockWebhook(cmd *cobra.Command, args []string) error {
+	target, _ := cmd.Flags().GetString("url")
+	secret, _ := cmd.Flags().GetString("secret")
+	seed, _ := cmd.Flags().GetInt64("seed")
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	retry, _ := cmd.Flags().GetBool("retry")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	api, err := parser.ParseAPIYAML(apiSpecFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse api.yaml: %w", err)
+	}
+
+	webhooks := api.Webhooks
+	if len(args) > 0 {
+		webhooks = nil
+		for _, name := range args {
+			found := false
+			for _, webhook := range api.Webhooks {
+				if webhook.Name == name {
+					webhooks = append(webhooks, webhook)
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("webhook %s is not declared in api.yaml", name)
+			}
+		}
+	}
+	if len(webhooks) == 0 {
+		return fmt.Errorf("no webhooks declared in api.yaml")
+	}
+
+	fake := fakedata.New(seed)
+	client := &http.Client{Timeout: timeout}
+	failed := 0
+	for _, webhook := range webhooks {
+		if webhook.Signature != nil && secret == "" {
+			color.Yellow("⚠️  %s is signed with %s; pass --secret to sign the delivery", webhook.Name, webhook.Signature.Header)
+		}
+
+		sample, err := mock.NewWebhookSample(webhook, fake, secret)
+		if err != nil {
+			return fmt.Errorf("webhook %s: %w", webhook.Name, err)
+		}
+
+		err = mock.DeliverWebhook(client, target, webhook, sample, retry, func(attempt mock.WebhookAttempt) {
+			line := fmt.Sprintf("📨 %s → ", webhook.Name)
+			if attempt.Err != nil {
+				line += attempt.Err.Error()
+			} else {
+				line += fmt.Sprintf("%d (%s)", attempt.Status, attempt.Duration.Round(time.Millisecond))
+			}
+			if attempt.Attempt > 1 {
+				line += fmt.Sprintf(" [attempt %d]", attempt.Attempt)
+			}
+
+			switch {
+			case attempt.Err == nil && attempt.Status >= 200 && attempt.Status < 300:
+				color.New(color.FgGreen).Println(line)
+			case attempt.Retry > 0:
+				color.New(color.FgYellow).Printf("%s, retrying in %s\n", line, attempt.Retry)
+			default:
+				color.New(color.FgRed).Println(line)
+			}
+		})
+		if err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d webhook deliveries were not acknowledged", failed, len(webhooks))
+	}
+	return nil
+}
+
+// logMockRequest prints one line for every request served by the mock
+func logMockRequest(method, path string, status int, message string) {
+	timestamp := time.Now().Format("15:04:05")
+	line := fmt.Sprintf("[%s] %s %s → %d", timestamp, method, path, status)
+	if message != "" {
+		line += " (" + message + ")"
+	}
+
+	switch {
+	case status >= 500:
+		color.New(color.FgRed).Println(line)
+	case status >= 400:
+		color.New(color.FgYellow).Println(line)
+	default:
+		fmt.Println(line)
+	}
+}
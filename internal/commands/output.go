@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"io"
+	"os"
+
+	"github.com/faisalahmedsifat/architect/internal/report"
+)
+
+// humanOutput returns where the progress output of a command writing a
+// machine-readable report goes. A report written to stdout must not be mixed
+// with it, so it goes to stderr instead.
+func humanOutput(outputFormat, reportFile string) io.Writer {
+	if outputFormat != report.FormatText && reportFile == "" {
+		return os.Stderr
+	}
+	return os.Stdout
+}
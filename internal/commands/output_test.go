@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/report"
+)
+
+func TestHumanOutput(t *testing.T) {
+	tests := []struct {
+		format     string
+		reportFile string
+		want       *os.File
+	}{
+		{report.FormatText, "", os.Stdout},
+		{report.FormatJSON, "report.json", os.Stdout},
+		{report.FormatJSON, "", os.Stderr},
+		{report.FormatSARIF, "", os.Stderr},
+	}
+	for _, tt := range tests {
+		if got := humanOutput(tt.format, tt.reportFile); got != tt.want {
+			t.Errorf("humanOutput(%q, %q) = %v, want %v", tt.format, tt.reportFile, got, tt.want.Name())
+		}
+	}
+}
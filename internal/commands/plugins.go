@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/plugins"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func PluginsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugins",
+		Short: "Manage plugins",
+		Long: `Plugins are architect-<name> executables on PATH.
+
+Any plugin can be run as "architect <name> [args]". Plugins speaking the JSON
+plugin protocol can also add import formats, export formats and rule targets
+written by "architect sync", once enabled in .architect/config.yaml:
+
+  plugins:
+    - soap
+    - windsurf
+
+Plugins that are not enabled are never run on their own.`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List the plugins found on PATH",
+		Args:  cobra.NoArgs,
+		RunE:  runPluginsList,
+	})
+
+	return cmd
+}
+
+func runPluginsList(cmd *cobra.Command, args []string) error {
+	found, err := plugins.Discover()
+	if err != nil {
+		return err
+	}
+	if len(found) == 0 {
+		color.Yellow("No plugins found. Plugins are architect-<name> executables on PATH.")
+		return nil
+	}
+
+	color.Cyan("🔌 Plugins:")
+	fmt.Println()
+
+	for _, plugin := range found {
+		fmt.Printf("  %-12s %s\n", plugin.Name, plugin.Path)
+		if !plugin.Enabled {
+			color.Yellow("  %-12s not enabled: add %s to plugins in %s to use its formats", "", plugin.Name, plugins.ConfigFile)
+			fmt.Println()
+			continue
+		}
+
+		plugins.Describe(plugin)
+		if plugin.Description != "" {
+			fmt.Printf("  %-12s %s\n", "", plugin.Description)
+		}
+		if plugin.Err != nil {
+			color.Yellow("  %-12s ⚠️  command only: %v", "", plugin.Err)
+			fmt.Println()
+			continue
+		}
+
+		for _, importer := range plugin.Importers {
+			line := fmt.Sprintf("  %-12s import %s", "", importer.Format)
+			if len(importer.Extensions) > 0 {
+				line += fmt.Sprintf(" (%s)", strings.Join(importer.Extensions, ", "))
+			}
+			fmt.Println(line)
+		}
+		for _, exporter := range plugin.Exporters {
+			line := fmt.Sprintf("  %-12s export %s", "", exporter.Format)
+			if exporter.Description != "" {
+				line += " - " + exporter.Description
+			}
+			fmt.Println(line)
+		}
+		for _, target := range plugin.RuleTargets {
+			line := fmt.Sprintf("  %-12s rules  %s", "", target.Name)
+			if target.Description != "" {
+				line += " - " + target.Description
+			}
+			fmt.Println(line)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
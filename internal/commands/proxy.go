@@ -0,0 +1,314 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/conformance"
+	"github.com/faisalahmedsifat/architect/internal/importers"
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/parser"
+	"github.com/faisalahmedsifat/architect/internal/proxy"
+	"github.com/faisalahmedsifat/architect/internal/report"
+	"github.com/faisalahmedsifat/architect/internal/utils"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func ProxyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proxy",
+		Short: "Check live traffic against the specification",
+		Long: `Runs a reverse proxy in front of a service and checks every request and
+response passing through it against .architect/api.yaml.
+
+Violations are logged as they happen: requests to routes that are not
+declared, invalid requests accepted by the service, response bodies with
+missing required fields or wrong types, undeclared status codes and
+mismatching error codes.
+
+Stop the proxy with Ctrl+C. With --output-format json, sarif or junit a
+conformance report covering all traffic seen is written on exit.
+
+With --learn the proxy also records traffic and writes a draft specification
+inferred from it on exit: concrete paths are collapsed into templates
+(/users/123 becomes /users/{id}) and query, body and response fields are
+typed from the observed values. Without an api.yaml, traffic is only
+recorded.`,
+		Example: `  architect proxy --upstream http://localhost:3000
+  architect proxy --upstream http://localhost:3000 --port 8080 --output-format junit --report-file traffic.xml
+  architect proxy --upstream http://localhost:3000 --learn draft.yaml`,
+		RunE: runProxy,
+	}
+
+	cmd.Flags().String("upstream", "", "Base URL of the service to forward requests to (required)")
+	cmd.Flags().IntP("port", "p", 4011, "Port to listen on")
+	cmd.Flags().String("host", "localhost", "Host to listen on")
+	cmd.Flags().Bool("no-watch", false, "Do not reload api.yaml when it changes")
+	cmd.Flags().String("output-format", "text", "Report format written on exit (text, json, sarif, junit)")
+	cmd.Flags().String("report-file", "", "Write the report to a file instead of stdout")
+	cmd.Flags().String("learn", "", "Infer a draft api.yaml from the traffic and write it to this file on exit")
+	cmd.MarkFlagRequired("upstream")
+
+	return cmd
+}
+
+func runProxy(cmd *cobra.Command, args []string) error {
+	upstreamFlag, _ := cmd.Flags().GetString("upstream")
+	port, _ := cmd.Flags().GetInt("port")
+	host, _ := cmd.Flags().GetString("host")
+	noWatch, _ := cmd.Flags().GetBool("no-watch")
+	outputFormat, _ := cmd.Flags().GetString("output-format")
+	reportFile, _ := cmd.Flags().GetString("report-file")
+	learnFile, _ := cmd.Flags().GetString("learn")
+
+	if !report.IsSupportedFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	if reportFile != "" && outputFormat == report.FormatText {
+		return fmt.Errorf("--report-file requires --output-format json, sarif or junit")
+	}
+
+	upstream, err := url.Parse(upstreamFlag)
+	if err != nil || upstream.Scheme == "" || upstream.Host == "" {
+		return fmt.Errorf("invalid --upstream %q, expected a URL such as http://localhost:3000", upstreamFlag)
+	}
+
+	// Live traffic logs stay visible on stderr when the report goes to stdout
+	out := humanOutput(outputFormat, reportFile)
+
+	// In learn mode the service may not have a specification yet
+	var api *models.API
+	if learnFile == "" || utils.FileExists(apiSpecFile) {
+		api, err = parser.ParseAPIYAML(apiSpecFile)
+		if err != nil {
+			return fmt.Errorf("failed to parse api.yaml: %w", err)
+		}
+	}
+
+	traffic := &trafficReport{api: api, out: out, cases: make(map[string]*report.Case)}
+	if learnFile != "" {
+		traffic.learner = importers.NewTrafficLearner()
+	}
+	handler := proxy.New(upstream, traffic.observe, func(method, path string, err error) {
+		timestamp := time.Now().Format("15:04:05")
+		color.New(color.FgRed).Fprintf(out, "[%s] %s %s → upstream error: %v\n", timestamp, method, path, err)
+	})
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	color.New(color.FgGreen).Fprintf(out, "🔍 Proxying http://%s → %s\n", listener.Addr(), upstream)
+	if api != nil {
+		fmt.Fprintf(out, "Checking traffic against %d endpoints", len(api.Endpoints))
+	} else {
+		fmt.Fprintf(out, "No api.yaml found, recording traffic only")
+	}
+	fmt.Fprintf(out, ", press Ctrl+C to stop\n\n")
+
+	if api != nil && !noWatch {
+		go func() {
+			err := watchSpecs(func() {
+				updated, err := parser.ParseAPIYAML(apiSpecFile)
+				if err != nil {
+					color.New(color.FgRed).Fprintf(out, "Error reloading api.yaml, still checking the previous version: %v\n", err)
+					return
+				}
+				traffic.setAPI(updated)
+				timestamp := time.Now().Format("15:04:05")
+				color.New(color.FgBlue).Fprintf(out, "[%s] Reloaded %d endpoints\n", timestamp, len(updated.Endpoints))
+			})
+			if err != nil {
+				color.New(color.FgRed).Fprintf(out, "Hot reload disabled: %v\n", err)
+			}
+		}()
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := &http.Server{Handler: handler}
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.Serve(listener) }()
+
+	select {
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}
+
+	result := traffic.report()
+	summary := result.Summary()
+	fmt.Fprintf(out, "\nSummary:\n")
+	fmt.Fprintf(out, "- 📨 %d requests proxied\n", traffic.requests)
+	if api != nil {
+		fmt.Fprintf(out, "- ✅ %d routes conform\n", summary.Passed)
+		if summary.Failed > 0 {
+			fmt.Fprintf(out, "- ❌ %d routes with violations\n", summary.Failed)
+		}
+		if summary.Warnings > 0 {
+			fmt.Fprintf(out, "- ⚠️  %d warnings\n", summary.Warnings)
+		}
+	}
+
+	if traffic.learner != nil {
+		learned := traffic.learner.API()
+		if learned.BaseURL == "" {
+			learned.BaseURL = upstream.String()
+		}
+		data, err := yaml.Marshal(learned)
+		if err != nil {
+			return fmt.Errorf("failed to marshal learned API: %w", err)
+		}
+		if err := os.WriteFile(learnFile, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", learnFile, err)
+		}
+		color.New(color.FgGreen).Fprintf(out, "\n🧠 Learned %d endpoints from %d requests, wrote %s\n", len(learned.Endpoints), traffic.learner.Len(), learnFile)
+	}
+
+	if outputFormat != report.FormatText {
+		if reportFile != "" {
+			if err := result.WriteFile(reportFile, outputFormat); err != nil {
+				return err
+			}
+			color.New(color.FgGreen).Fprintf(out, "\n📄 Wrote %s report to %s\n", outputFormat, reportFile)
+		} else if err := result.Write(os.Stdout, outputFormat); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("traffic violated the specification on %d routes", summary.Failed)
+	}
+	return nil
+}
+
+// trafficReport collects the findings for proxied traffic, one case per
+// declared endpoint or undeclared route
+type trafficReport struct {
+	mu       sync.Mutex
+	api      *models.API
+	out      io.Writer
+	cases    map[string]*report.Case
+	order    []string
+	requests int
+
+	// learner records traffic for --learn, nil otherwise
+	learner *importers.TrafficLearner
+}
+
+func (t *trafficReport) setAPI(api *models.API) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.api = api
+}
+
+// observe checks a proxied exchange and logs it with its violations
+func (t *trafficReport) observe(exchange conformance.Exchange) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests++
+	if t.learner != nil {
+		target := exchange.Path
+		if len(exchange.Query) > 0 {
+			target += "?" + exchange.Query.Encode()
+		}
+		t.learner.Add(importers.TrafficRecord{
+			Method:          exchange.Method,
+			URL:             target,
+			RequestHeaders:  exchange.RequestHeaders,
+			RequestBody:     exchange.RequestBody,
+			Status:          exchange.Status,
+			ResponseHeaders: exchange.ResponseHeaders,
+			ResponseBody:    exchange.ResponseBody,
+		})
+	}
+
+	timestamp := time.Now().Format("15:04:05")
+	line := fmt.Sprintf("[%s] %s %s → %d", timestamp, exchange.Method, exchange.Path, exchange.Status)
+	if t.api == nil {
+		fmt.Fprintln(t.out, line)
+		return
+	}
+
+	endpoint, ok, findings := conformance.CheckExchange(t.api, exchange)
+	name := exchange.Method + " " + exchange.Path
+	if ok {
+		name = endpoint.Method + " " + endpoint.Path
+	}
+
+	c, seen := t.cases[name]
+	if !seen {
+		c = &report.Case{Name: name, Classname: "proxy"}
+		t.cases[name] = c
+		t.order = append(t.order, name)
+	}
+
+	// Repeated traffic reports the same violation once
+	for _, finding := range findings {
+		duplicate := false
+		for _, existing := range c.Findings {
+			if existing.RuleID == finding.RuleID && existing.Message == finding.Message {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			c.Findings = append(c.Findings, finding)
+		}
+	}
+
+	failed := false
+	for _, finding := range findings {
+		if finding.Severity == report.SeverityError {
+			failed = true
+		}
+	}
+	if failed {
+		color.New(color.FgRed).Fprintln(t.out, line)
+	} else {
+		fmt.Fprintln(t.out, line)
+	}
+	for _, finding := range findings {
+		switch finding.Severity {
+		case report.SeverityError:
+			color.New(color.FgRed).Fprintf(t.out, "   ✗ %s\n", finding.Message)
+		case report.SeverityWarning:
+			color.New(color.FgYellow).Fprintf(t.out, "   ! %s\n", finding.Message)
+		default:
+			fmt.Fprintf(t.out, "   - %s\n", finding.Message)
+		}
+	}
+}
+
+// report returns the collected findings in the order routes were first seen
+func (t *trafficReport) report() *report.Report {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := &report.Report{Name: "architect proxy", Rules: conformance.RuleDescriptions}
+	for _, name := range t.order {
+		result.Cases = append(result.Cases, *t.cases[name])
+	}
+	return result
+}
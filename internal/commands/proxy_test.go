@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/conformance"
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/report"
+)
+
+func TestTrafficReportLogsExchanges(t *testing.T) {
+	var out bytes.Buffer
+	api := &models.API{Endpoints: []models.Endpoint{{Method: "GET", Path: "/orders"}}}
+	traffic := &trafficReport{api: api, out: &out, cases: make(map[string]*report.Case)}
+
+	traffic.observe(conformance.Exchange{Method: "GET", Path: "/orders", Status: 200})
+	traffic.observe(conformance.Exchange{Method: "DELETE", Path: "/orders", Status: 204})
+
+	logged := out.String()
+	for _, want := range []string{"GET /orders → 200", "DELETE /orders → 204"} {
+		if !strings.Contains(logged, want) {
+			t.Errorf("log is missing %q:\n%s", want, logged)
+		}
+	}
+	if summary := traffic.report().Summary(); summary.Failed != 1 {
+		t.Errorf("expected the undeclared route to fail, got %+v", summary)
+	}
+}
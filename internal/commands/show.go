@@ -70,6 +70,37 @@ func runShow(cmd *cobra.Command, args []string) error {
 
 		fmt.Println("\n🔒 = Requires authentication")
 		fmt.Println("🔓 = Public endpoint")
+
+		if api.GraphQL != nil {
+			fmt.Println()
+			color.Cyan("GraphQL Operations (%s):", api.GraphQL.Path)
+			fmt.Println()
+			fmt.Printf("%-13s %-30s %s\n", "Type", "Name", "Output")
+			fmt.Println("────────────────────────────────────────────────────────────────")
+			for _, operation := range api.GraphQL.Operations {
+				fmt.Printf("%-13s %-30s %s\n", operation.Type, operation.Name, operation.Output)
+			}
+		}
+
+		if len(api.Channels) > 0 {
+			fmt.Println()
+			color.Cyan("Channels:")
+			fmt.Println()
+			fmt.Printf("%-9s %-30s %s\n", "Role", "Channel", "Message")
+			fmt.Println("────────────────────────────────────────────────────────────────")
+			for _, channel := range api.Channels {
+				fmt.Printf("%-9s %-30s %s\n", channel.Role, channel.Name, channel.Message)
+			}
+		}
+
+		if len(api.Webhooks) > 0 {
+			fmt.Println()
+			color.Cyan("Webhooks:")
+			fmt.Println()
+			for _, webhook := range api.Webhooks {
+				fmt.Printf("%-40s %s\n", webhook.Name, webhook.Description)
+			}
+		}
 	}
 
 	return nil
@@ -3,9 +3,14 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/faisalahmedsifat/architect/internal/generator"
+	"github.com/faisalahmedsifat/architect/internal/models"
 	"github.com/faisalahmedsifat/architect/internal/parser"
+	"github.com/faisalahmedsifat/architect/internal/plugins"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -14,8 +19,12 @@ func SyncCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "sync",
 		Short: "Sync specifications to Cursor rules",
-		Long:  "Regenerates .cursor/rules/architect.mdc from your specifications",
-		RunE:  runSync,
+		Long: `Regenerates .cursor/rules/architect.mdc from your specifications.
+
+Plugins (architect-<name> executables on PATH) enabled in
+.architect/config.yaml and declaring rule targets also receive the
+specifications and write the rules of their AI assistants.`,
+		RunE: runSync,
 	}
 }
 
@@ -56,7 +65,68 @@ func runSync(cmd *cobra.Command, args []string) error {
 	}
 
 	color.Green("✅ Updated .cursor/rules/architect.mdc")
+
+	if err := syncPluginRules(string(projectContent), api); err != nil {
+		return err
+	}
+
 	color.Green("\n✨ Cursor rules synchronized with latest specifications!")
 
 	return nil
 }
+
+// syncPluginRules writes the rules of the rule targets of plugins
+func syncPluginRules(projectContent string, api *models.API) error {
+	targets, err := plugins.RuleTargets()
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	project, err := parser.ParseProjectMarkdown(".architect/project.md")
+	if err != nil {
+		return fmt.Errorf("failed to parse project.md: %w", err)
+	}
+
+	for _, target := range targets {
+		files, err := target.Generate(api, project, projectContent)
+		if err != nil {
+			return fmt.Errorf("rule target %s: %w", target.Capability.Name, err)
+		}
+
+		paths := make([]string, 0, len(files))
+		for path := range files {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			filename, err := projectFile(path)
+			if err != nil {
+				return fmt.Errorf("rule target %s (plugin %s): %w", target.Capability.Name, target.Plugin.Name, err)
+			}
+			if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+				return fmt.Errorf("failed to create directory for %s: %w", path, err)
+			}
+			if err := os.WriteFile(filename, []byte(files[path]), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", path, err)
+			}
+			color.Green("✅ Updated %s (%s, plugin %s)", path, target.Capability.Name, target.Plugin.Name)
+		}
+	}
+
+	return nil
+}
+
+// projectFile converts a slash-separated path relative to the project root to
+// a file name, rejecting absolute paths and paths leaving the project
+func projectFile(path string) (string, error) {
+	filename := filepath.Clean(filepath.FromSlash(path))
+	if path == "" || filepath.IsAbs(filename) || filepath.VolumeName(filename) != "" ||
+		filename == ".." || strings.HasPrefix(filename, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("refusing to write %q outside the project", path)
+	}
+	return filename, nil
+}
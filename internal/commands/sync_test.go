@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+		ok   bool
+	}{
+		{".windsurf/rules/architect.md", filepath.Join(".windsurf", "rules", "architect.md"), true},
+		{"AGENTS.md", "AGENTS.md", true},
+		{"docs/../AGENTS.md", "AGENTS.md", true},
+		{"", "", false},
+		{"/etc/passwd", "", false},
+		{"..", "", false},
+		{"../outside.md", "", false},
+		{"rules/../../outside.md", "", false},
+	}
+	for _, tt := range tests {
+		got, err := projectFile(tt.path)
+		if tt.ok != (err == nil) {
+			t.Errorf("projectFile(%q) error = %v, want ok %v", tt.path, err, tt.ok)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("projectFile(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
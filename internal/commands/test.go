@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/conformance"
+	"github.com/faisalahmedsifat/architect/internal/parser"
+	"github.com/faisalahmedsifat/architect/internal/report"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func TestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Run contract tests against a running service",
+		Long: `Exercises every endpoint in .architect/api.yaml against a running service.
+
+For each endpoint a valid request is sent and its status and response body
+are checked against the specification. Endpoints with a request body also
+receive an invalid request, which must be rejected with a 4xx status and the
+declared error code. Endpoints declared with auth must reject requests
+without credentials.
+
+Request data is generated from the declared field types. Use --param to
+address existing resources, e.g. --param id=42.`,
+		Example: `  architect test --target http://localhost:8080
+  architect test --target http://localhost:8080 --token $TOKEN --param id=42
+  architect test --target http://localhost:8080 --output-format junit --report-file contract.xml`,
+		RunE: runTest,
+	}
+
+	cmd.Flags().String("target", "", "Base URL of the running service (required)")
+	cmd.Flags().String("token", "", "Credentials for endpoints that require auth")
+	cmd.Flags().StringArray("header", nil, "Extra request header as 'Name: value' (repeatable)")
+	cmd.Flags().StringArray("param", nil, "Path parameter value as name=value (repeatable)")
+	cmd.Flags().String("endpoint", "", "Only test endpoints whose path contains this string")
+	cmd.Flags().Duration("timeout", 10*time.Second, "Timeout for each request")
+	cmd.Flags().Int64("seed", 0, "Seed for generated request data (default: random)")
+	cmd.Flags().String("output-format", "text", "Output format (text, json, sarif, junit)")
+	cmd.Flags().String("report-file", "", "Write the report to a file instead of stdout")
+	cmd.MarkFlagRequired("target")
+
+	return cmd
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	target, _ := cmd.Flags().GetString("target")
+	token, _ := cmd.Flags().GetString("token")
+	headerFlags, _ := cmd.Flags().GetStringArray("header")
+	paramFlags, _ := cmd.Flags().GetStringArray("param")
+	filter, _ := cmd.Flags().GetString("endpoint")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	seed, _ := cmd.Flags().GetInt64("seed")
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	outputFormat, _ := cmd.Flags().GetString("output-format")
+	reportFile, _ := cmd.Flags().GetString("report-file")
+
+	if !report.IsSupportedFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	if reportFile != "" && outputFormat == report.FormatText {
+		return fmt.Errorf("--report-file requires --output-format json, sarif or junit")
+	}
+
+	headers, err := parseHeaderFlags(headerFlags)
+	if err != nil {
+		return err
+	}
+	params := make(map[string]string)
+	for _, param := range paramFlags {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("invalid --param %q, expected name=value", param)
+		}
+		params[name] = value
+	}
+
+	out := humanOutput(outputFormat, reportFile)
+
+	api, err := parser.ParseAPIYAML(apiSpecFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse api.yaml: %w", err)
+	}
+
+	runner := conformance.NewRunner(api, conformance.Options{
+		Target:  target,
+		Token:   token,
+		Headers: headers,
+		Params:  params,
+		Seed:    seed,
+		Client:  &http.Client{Timeout: timeout},
+	})
+
+	color.New(color.FgCyan).Fprintf(out, "🧪 Running contract tests against %s...\n\n", target)
+
+	result := &report.Report{Name: "architect test", Rules: conformance.RuleDescriptions}
+	for _, endpoint := range api.Endpoints {
+		if filter != "" && !strings.Contains(endpoint.Path, filter) {
+			continue
+		}
+
+		testCase := runner.TestEndpoint(endpoint)
+		result.Cases = append(result.Cases, testCase)
+		printTestCase(out, testCase)
+	}
+
+	summary := result.Summary()
+	fmt.Fprintf(out, "\nSummary:\n")
+	fmt.Fprintf(out, "- ✅ %d endpoints passed\n", summary.Passed)
+	if summary.Failed > 0 {
+		fmt.Fprintf(out, "- ❌ %d endpoints failed\n", summary.Failed)
+	}
+	if summary.Warnings > 0 {
+		fmt.Fprintf(out, "- ⚠️  %d warnings\n", summary.Warnings)
+	}
+	fmt.Fprintf(out, "- 🎲 seed %d (pass --seed to reproduce)\n", seed)
+
+	if outputFormat != report.FormatText {
+		if reportFile != "" {
+			if err := result.WriteFile(reportFile, outputFormat); err != nil {
+				return err
+			}
+			color.New(color.FgGreen).Fprintf(out, "\n📄 Wrote %s report to %s\n", outputFormat, reportFile)
+		} else if err := result.Write(os.Stdout, outputFormat); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("contract tests failed for %d endpoints", summary.Failed)
+	}
+	return nil
+}
+
+// printTestCase prints the outcome of a single endpoint's contract tests
+func printTestCase(out io.Writer, testCase report.Case) {
+	warned := false
+	for _, finding := range testCase.Findings {
+		if finding.Severity == report.SeverityWarning {
+			warned = true
+		}
+	}
+
+	switch {
+	case testCase.Failed():
+		color.New(color.FgRed).Fprintf(out, "❌ %s\n", testCase.Name)
+	case warned:
+		color.New(color.FgYellow).Fprintf(out, "⚠️  %s\n", testCase.Name)
+	default:
+		color.New(color.FgGreen).Fprintf(out, "✅ %s\n", testCase.Name)
+	}
+
+	for _, finding := range testCase.Findings {
+		switch finding.Severity {
+		case report.SeverityError:
+			color.New(color.FgRed).Fprintf(out, "   ✗ %s\n", finding.Message)
+		case report.SeverityWarning:
+			color.New(color.FgYellow).Fprintf(out, "   ! %s\n", finding.Message)
+		default:
+			fmt.Fprintf(out, "   - %s\n", finding.Message)
+		}
+	}
+}
+
+// parseHeaderFlags parses repeated 'Name: value' header flags
+func parseHeaderFlags(flags []string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, header := range flags {
+		name, value, ok := strings.Cut(header, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid header %q, expected 'Name: value'", header)
+		}
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
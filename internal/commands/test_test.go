@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestContractTestJSONReportKeepsProgressOnStderr(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "w1", "name": "Widget"}`))
+	}))
+	defer server.Close()
+
+	files := map[string]string{
+		".architect/api.yaml": `base_url: /
+auth_type: none
+endpoints:
+  - path: /widgets/{id}
+    method: GET
+    description: Get a widget
+    auth: false
+    response:
+      status: 200
+      body:
+        id: string, required
+        name: string, required
+`,
+	}
+
+	inProject(t, files, func() {
+		cmd := TestCmd()
+		cmd.SetArgs([]string{"--target", server.URL, "--output-format", "json", "--seed", "1"})
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		var stdout string
+		stderr := captureFile(t, &os.Stderr, func() {
+			stdout = captureStdout(t, func() {
+				if err := cmd.Execute(); err != nil {
+					t.Errorf("architect test failed: %v", err)
+				}
+			})
+		})
+
+		var decoded struct {
+			Summary struct {
+				Passed int `json:"passed"`
+			} `json:"summary"`
+		}
+		if err := json.Unmarshal([]byte(stdout), &decoded); err != nil {
+			t.Fatalf("stdout is not a JSON report: %v\n%s", err, stdout)
+		}
+		if decoded.Summary.Passed != 1 {
+			t.Errorf("summary = %+v, want one passed endpoint", decoded.Summary)
+		}
+		for _, want := range []string{"Running contract tests", "GET /widgets/{id}", "seed 1"} {
+			if !strings.Contains(stderr, want) {
+				t.Errorf("stderr is missing %q:\n%s", want, stderr)
+			}
+		}
+	})
+}
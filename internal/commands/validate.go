@@ -2,11 +2,16 @@ package commands
 
 import (
 	"fmt"
-	"io/ioutil"
+	"io"
+	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/faisalahmedsifat/architect/internal/generator"
+	"github.com/faisalahmedsifat/architect/internal/models"
 	"github.com/faisalahmedsifat/architect/internal/parser"
+	"github.com/faisalahmedsifat/architect/internal/report"
+	"github.com/faisalahmedsifat/architect/internal/validator"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 )
@@ -15,57 +20,295 @@ func ValidateCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "validate",
 		Short: "Validate implementation against specifications",
-		Long:  "Checks if your code follows the specifications",
-		RunE:  runValidate,
+		Long: `Checks if your code follows the specifications.
+
+Endpoints declared in .architect/api.yaml are matched against the routes
+found in your source code. Routes present in code but missing from the
+specification are reported as undocumented.
+
+The whole project is scanned recursively, skipping .gitignore'd paths,
+dependency directories and test files. Use validate.include and
+validate.exclude globs in .architect/config.yaml to narrow the scan.
+
+Declared request/response bodies are compared with the DTOs used by each
+handler (Go structs, Pydantic models, TypeScript interfaces and zod
+schemas) to report missing fields, wrong types and required/optional
+mismatches. Endpoints declared with auth: true must be behind detectable
+auth middleware, decorators, guards or dependencies.
+
+Results can be written as JSON, SARIF (GitHub code scanning) or JUnit XML
+for CI systems using --output-format and --report-file.
+
+Known findings can be accepted in .architect/validate-baseline.yaml, which
+--update-baseline regenerates, so that validate only fails on new errors.
+Individual findings can be suppressed with an "architect:ignore [rule]"
+comment on the offending line or the line above it.
+
+With --fix, handler stubs for missing endpoints are generated for the
+backend declared in .architect/project.md. Stubs are shown as a patch
+preview unless --write is given, in which case they are written to
+--stubs-dir (or validate.stubs_dir in .architect/config.yaml).`,
+		RunE: runValidate,
 	}
 
-	cmd.Flags().Bool("fix", false, "Show fix suggestions")
+	cmd.Flags().Bool("fix", false, "Generate handler stubs for missing endpoints")
+	cmd.Flags().Bool("write", false, "Write generated stubs to disk instead of previewing them (with --fix)")
+	cmd.Flags().String("stubs-dir", "", "Directory for generated stubs (default: validate.stubs_dir or \"stubs\")")
+	cmd.Flags().Bool("add-undocumented", false, "Add undocumented routes to api.yaml as draft endpoints")
+	cmd.Flags().String("output-format", "text", "Output format (text, json, sarif, junit)")
+	cmd.Flags().String("report-file", "", "Write the report to a file instead of stdout")
+	cmd.Flags().Bool("update-baseline", false, "Record all current findings as accepted in the baseline")
 
 	return cmd
 }
 
+const (
+	apiSpecFile     = ".architect/api.yaml"
+	projectSpecFile = ".architect/project.md"
+	configFile      = ".architect/config.yaml"
+	defaultStubsDir = "stubs"
+	baselineFile    = ".architect/validate-baseline.yaml"
+)
+
 func runValidate(cmd *cobra.Command, args []string) error {
-	color.Cyan("🔍 Validating implementation against specifications...\n")
+	outputFormat, _ := cmd.Flags().GetString("output-format")
+	reportFile, _ := cmd.Flags().GetString("report-file")
+	if !report.IsSupportedFormat(outputFormat) {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+	if reportFile != "" && outputFormat == report.FormatText {
+		return fmt.Errorf("--report-file requires --output-format json, sarif or junit")
+	}
 
-	api, err := parser.ParseAPIYAML(".architect/api.yaml")
+	out := humanOutput(outputFormat, reportFile)
+
+	color.New(color.FgCyan).Fprintln(out, "🔍 Validating implementation against specifications...")
+
+	api, err := parser.ParseAPIYAML(apiSpecFile)
 	if err != nil {
 		return fmt.Errorf("failed to parse api.yaml: %w", err)
 	}
+	specLines, err := parser.EndpointLines(apiSpecFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse api.yaml: %w", err)
+	}
+
+	config, err := parser.ParseConfigYAML(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse config.yaml: %w", err)
+	}
+
+	// Index routes and DTOs once instead of re-reading source files per endpoint
+	// Generated stubs implement every endpoint in name only, so they must
+	// not count as implementations
+	exclude := config.Validate.Exclude
+	if stubsDir := filepath.ToSlash(filepath.Clean(stubsDirectory(cmd, config))); stubsDir != "." && !strings.HasPrefix(stubsDir, "../") {
+		exclude = append(append([]string{}, exclude...), stubsDir)
+	}
+	index, err := validator.Scan(validator.ScanOptions{
+		Include: config.Validate.Include,
+		Exclude: exclude,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan source files: %w", err)
+	}
 
-	// Basic validation - check if endpoint files exist
-	// This is a simplified version - real implementation would parse actual code
+	fmt.Fprintln(out, "Checking endpoints...")
 
-	fmt.Println("Checking endpoints...")
+	baseline, err := validator.LoadBaseline(baselineFile)
+	if err != nil {
+		return err
+	}
+	filter := validator.NewFindingFilter(baseline)
 
+	result := &report.Report{Name: "architect validate", Rules: validator.RuleDescriptions}
 	valid := 0
 	warnings := 0
 	errors := 0
+	baselined := 0
+	var missing []models.Endpoint
 
 	for _, endpoint := range api.Endpoints {
-		// Simplified check - look for route definition in common locations
-		found := checkEndpointImplemented(endpoint.Method, endpoint.Path)
+		name := endpoint.Method + " " + endpoint.Path
+		testCase := report.Case{Name: name, Classname: "endpoints"}
 
-		if found {
-			color.Green("✅ %s %s - Implemented correctly", endpoint.Method, endpoint.Path)
-			valid++
-		} else {
-			color.Red("❌ %s %s - Endpoint not implemented", endpoint.Method, endpoint.Path)
+		route, found := validator.FindRoute(endpoint, index.Routes, api.BaseURL)
+		if !found {
+			missing = append(missing, endpoint)
+			entry := validator.BaselineEntry{Rule: validator.RuleEndpointNotImplemented, Endpoint: name}
+			location := validator.Location{File: apiSpecFile, Line: specLines[name]}
+			switch filter.Check(entry, location) {
+			case validator.FindingReported:
+				color.New(color.FgRed).Fprintf(out, "❌ %s - Endpoint not implemented\n", name)
+				testCase.Findings = append(testCase.Findings, report.Finding{
+					RuleID:   validator.RuleEndpointNotImplemented,
+					Severity: report.SeverityError,
+					Message:  "Endpoint not implemented",
+					File:     location.File,
+					Line:     location.Line,
+				})
+				errors++
+			case validator.FindingBaselined:
+				color.New(color.FgCyan).Fprintf(out, "📋 %s - Endpoint not implemented (baselined)\n", name)
+				testCase.Findings = append(testCase.Findings, baselinedFinding(validator.RuleEndpointNotImplemented, "Endpoint not implemented", location.File, location.Line))
+				baselined++
+			}
+			result.Cases = append(result.Cases, testCase)
+			continue
+		}
+
+		// Findings can be suppressed at the DTO field or at the route itself
+		var issues, accepted []validator.Issue
+		checks := append(validator.CheckAuth(endpoint, route), validator.CheckSchemas(endpoint, route, index)...)
+		for _, issue := range checks {
+			entry := validator.BaselineEntry{Rule: issue.Rule, Endpoint: name}
+			if issue.Field != "" {
+				entry.Field = issue.Kind + "." + issue.Field
+			}
+			switch filter.Check(entry, validator.Location{File: issue.File, Line: issue.Line}, validator.Location{File: route.File, Line: route.Line}) {
+			case validator.FindingReported:
+				issues = append(issues, issue)
+			case validator.FindingBaselined:
+				accepted = append(accepted, issue)
+			}
+		}
+
+		switch worstSeverity(issues) {
+		case validator.SeverityError:
+			color.New(color.FgRed).Fprintf(out, "❌ %s - Does not match specification (%s:%d)\n", name, route.File, route.Line)
 			errors++
+		case validator.SeverityWarning:
+			color.New(color.FgYellow).Fprintf(out, "⚠️  %s - Implemented with warnings (%s:%d)\n", name, route.File, route.Line)
+			warnings++
+		default:
+			if len(accepted) > 0 {
+				color.New(color.FgCyan).Fprintf(out, "📋 %s - Implemented with baselined findings (%s:%d)\n", name, route.File, route.Line)
+				baselined++
+				break
+			}
+			color.New(color.FgGreen).Fprintf(out, "✅ %s - Implemented correctly (%s:%d)\n", name, route.File, route.Line)
+			valid++
+		}
+		for _, issue := range issues {
+			if issue.Severity == validator.SeverityError {
+				color.New(color.FgRed).Fprintf(out, "   ✗ %s (%s:%d)\n", issue.Message, issue.File, issue.Line)
+			} else {
+				color.New(color.FgYellow).Fprintf(out, "   ! %s (%s:%d)\n", issue.Message, issue.File, issue.Line)
+			}
+			testCase.Findings = append(testCase.Findings, report.Finding{
+				RuleID:   issue.Rule,
+				Severity: string(issue.Severity),
+				Message:  issue.Message,
+				File:     issue.File,
+				Line:     issue.Line,
+			})
+		}
+		for _, issue := range accepted {
+			color.New(color.FgCyan).Fprintf(out, "   ~ %s (%s:%d, baselined)\n", issue.Message, issue.File, issue.Line)
+			testCase.Findings = append(testCase.Findings, baselinedFinding(issue.Rule, issue.Message, issue.File, issue.Line))
 		}
+		result.Cases = append(result.Cases, testCase)
 	}
 
-	fmt.Printf("\nSummary:\n")
-	fmt.Printf("- ✅ %d endpoints correct\n", valid)
+	// Reverse drift: routes that exist in code but not in the specification
+	var undocumented, acceptedRoutes []validator.Route
+	for _, route := range validator.UndocumentedRoutes(api, index.Routes) {
+		entry := validator.BaselineEntry{Rule: validator.RuleRouteUndocumented, Endpoint: route.Method + " " + route.Path}
+		switch filter.Check(entry, validator.Location{File: route.File, Line: route.Line}) {
+		case validator.FindingReported:
+			undocumented = append(undocumented, route)
+		case validator.FindingBaselined:
+			acceptedRoutes = append(acceptedRoutes, route)
+		}
+	}
+	if len(undocumented)+len(acceptedRoutes) > 0 {
+		fmt.Fprintln(out, "\nChecking for undocumented routes...")
+		for _, route := range undocumented {
+			color.New(color.FgYellow).Fprintf(out, "⚠️  %s %s - Not documented in api.yaml (%s:%d)\n", route.Method, route.Path, route.File, route.Line)
+			result.Cases = append(result.Cases, report.Case{
+				Name:      route.Method + " " + route.Path,
+				Classname: "undocumented routes",
+				Findings: []report.Finding{{
+					RuleID:   validator.RuleRouteUndocumented,
+					Severity: report.SeverityWarning,
+					Message:  "Route is not documented in api.yaml",
+					File:     route.File,
+					Line:     route.Line,
+				}},
+			})
+		}
+		for _, route := range acceptedRoutes {
+			color.New(color.FgCyan).Fprintf(out, "📋 %s %s - Not documented in api.yaml (baselined, %s:%d)\n", route.Method, route.Path, route.File, route.Line)
+			result.Cases = append(result.Cases, report.Case{
+				Name:      route.Method + " " + route.Path,
+				Classname: "undocumented routes",
+				Findings:  []report.Finding{baselinedFinding(validator.RuleRouteUndocumented, "Route is not documented in api.yaml", route.File, route.Line)},
+			})
+		}
+	}
+
+	fmt.Fprintf(out, "\nSummary:\n")
+	fmt.Fprintf(out, "- ✅ %d endpoints correct\n", valid)
+	if baselined > 0 {
+		fmt.Fprintf(out, "- 📋 %d endpoints with baselined findings only\n", baselined)
+	}
 	if warnings > 0 {
-		fmt.Printf("- ⚠️  %d endpoints with warnings\n", warnings)
+		fmt.Fprintf(out, "- ⚠️  %d endpoints with warnings\n", warnings)
 	}
 	if errors > 0 {
-		fmt.Printf("- ❌ %d endpoints with errors\n", errors)
+		fmt.Fprintf(out, "- ❌ %d endpoints with errors\n", errors)
+	}
+	if len(undocumented) > 0 {
+		fmt.Fprintf(out, "- ⚠️  %d undocumented routes\n", len(undocumented))
+	}
+	if filter.Accepted > 0 {
+		fmt.Fprintf(out, "- 📋 %d known findings accepted in %s\n", filter.Accepted, baselineFile)
+	}
+	if filter.Suppressed > 0 {
+		fmt.Fprintf(out, "- 🔇 %d findings suppressed by architect:ignore comments\n", filter.Suppressed)
 	}
 
-	showFix, _ := cmd.Flags().GetBool("fix")
-	if showFix && errors > 0 {
-		fmt.Println("\nRun 'architect validate --fix' for suggestions on fixing these issues.")
+	updateBaseline, _ := cmd.Flags().GetBool("update-baseline")
+	if stale := filter.Stale(); len(stale) > 0 && !updateBaseline {
+		fmt.Fprintf(out, "\n%d baseline entries no longer occur. Run 'architect validate --update-baseline' to remove them.\n", len(stale))
+	}
+
+	if outputFormat != report.FormatText {
+		if reportFile != "" {
+			if err := result.WriteFile(reportFile, outputFormat); err != nil {
+				return err
+			}
+			color.New(color.FgGreen).Fprintf(out, "\n📄 Wrote %s report to %s\n", outputFormat, reportFile)
+		} else if err := result.Write(os.Stdout, outputFormat); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+
+	// The report is written first so that CI gets it on baseline updates too
+	if updateBaseline {
+		if err := validator.WriteBaseline(baselineFile, filter.Seen()); err != nil {
+			return fmt.Errorf("failed to write baseline: %w", err)
+		}
+		color.New(color.FgGreen).Fprintf(out, "\n📋 Recorded %d findings in %s\n", len(filter.Seen()), baselineFile)
+		return nil
+	}
+
+	addUndocumented, _ := cmd.Flags().GetBool("add-undocumented")
+	if addUndocumented && len(undocumented) > 0 {
+		if err := addDraftEndpoints(out, undocumented); err != nil {
+			return err
+		}
+	} else if len(undocumented) > 0 {
+		fmt.Fprintln(out, "\nRun 'architect validate --add-undocumented' to add undocumented routes as draft endpoints.")
+	}
+
+	fix, _ := cmd.Flags().GetBool("fix")
+	if fix && len(missing) > 0 {
+		if err := fixMissingEndpoints(cmd, out, config, api.BaseURL, missing); err != nil {
+			return err
+		}
+	} else if len(missing) > 0 {
+		fmt.Fprintln(out, "\nRun 'architect validate --fix' to generate handler stubs for missing endpoints.")
 	}
 
 	if errors > 0 {
@@ -75,40 +318,135 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func checkEndpointImplemented(method, path string) bool {
-	// This is a very basic check - real implementation would use AST parsing
-	// Check common directories for route definitions
+// baselinedFinding reports a finding accepted in the baseline as a note
+func baselinedFinding(rule, message, file string, line int) report.Finding {
+	return report.Finding{
+		RuleID:    rule,
+		Severity:  report.SeverityNote,
+		Message:   message + " (baselined)",
+		File:      file,
+		Line:      line,
+		Baselined: true,
+	}
+}
 
-	searchDirs := []string{
-		"app", "src", "api", "routes", "routers", "handlers", "controllers",
+// worstSeverity returns the most severe severity among the issues, or an
+// empty severity when there are none
+func worstSeverity(issues []validator.Issue) validator.Severity {
+	var worst validator.Severity
+	for _, issue := range issues {
+		if issue.Severity == validator.SeverityError {
+			return validator.SeverityError
+		}
+		worst = issue.Severity
 	}
+	return worst
+}
 
-	for _, dir := range searchDirs {
-		if files, err := ioutil.ReadDir(dir); err == nil {
-			for _, file := range files {
-				if strings.HasSuffix(file.Name(), ".py") ||
-					strings.HasSuffix(file.Name(), ".js") ||
-					strings.HasSuffix(file.Name(), ".ts") {
+// addDraftEndpoints appends undocumented routes to api.yaml as draft endpoints
+func addDraftEndpoints(out io.Writer, routes []validator.Route) error {
+	api, err := parser.ParseAPIYAML(apiSpecFile)
+	if err != nil {
+		return fmt.Errorf("failed to parse api.yaml: %w", err)
+	}
 
-					content, _ := ioutil.ReadFile(filepath.Join(dir, file.Name()))
-					contentStr := string(content)
+	seen := make(map[string]bool)
+	for _, endpoint := range api.Endpoints {
+		seen[endpoint.Method+" "+endpoint.Path] = true
+	}
 
-					// Very basic check - look for method and path
-					if strings.Contains(contentStr, method) &&
-						strings.Contains(contentStr, cleanPath(path)) {
-						return true
-					}
-				}
-			}
+	added := 0
+	for _, route := range routes {
+		draft := validator.DraftEndpoint(route, api.BaseURL)
+		key := draft.Method + " " + draft.Path
+		if seen[key] {
+			continue
 		}
+		seen[key] = true
+		api.Endpoints = append(api.Endpoints, draft)
+		added++
+	}
+
+	fmt.Fprintln(out)
+	if err := writeAPISpec(out, api); err != nil {
+		return fmt.Errorf("failed to write API specification: %w", err)
 	}
+	color.New(color.FgGreen).Fprintf(out, "📝 Added %d draft endpoints - review their descriptions and schemas\n", added)
 
-	return false
+	return nil
 }
 
-func cleanPath(path string) string {
-	// Remove parameter placeholders for basic matching
-	path = strings.ReplaceAll(path, "{", "")
-	path = strings.ReplaceAll(path, "}", "")
-	return path
+// fixMissingEndpoints generates handler stubs for endpoints that are not
+// implemented. Stubs are previewed as a patch unless --write is set, and
+// existing files are never overwritten.
+func fixMissingEndpoints(cmd *cobra.Command, out io.Writer, config *models.Config, baseURL string, endpoints []models.Endpoint) error {
+	project, err := parser.ParseProjectMarkdown(projectSpecFile)
+	if err != nil {
+		return fmt.Errorf("failed to read project.md: %w", err)
+	}
+	if project.TechStack.Backend == "" {
+		return fmt.Errorf("no backend declared in %s (expected a '- Backend: ...' line under Tech Stack)", projectSpecFile)
+	}
+
+	stubs, err := generator.GenerateHandlerStubs(project.TechStack.Backend, baseURL, endpoints)
+	if err != nil {
+		return err
+	}
+
+	stubsDir := stubsDirectory(cmd, config)
+
+	write, _ := cmd.Flags().GetBool("write")
+	if !write {
+		color.New(color.FgCyan).Fprintf(out, "\n🔧 Handler stubs for %d missing endpoints (%s):\n\n", len(endpoints), project.TechStack.Backend)
+		for _, stub := range stubs {
+			fmt.Fprint(out, stubPatch(filepath.Join(stubsDir, stub.Filename), stub.Content))
+		}
+		fmt.Fprintln(out, "\nRun 'architect validate --fix --write' to write these stubs to disk.")
+		return nil
+	}
+
+	if err := os.MkdirAll(stubsDir, 0755); err != nil {
+		return fmt.Errorf("failed to create stubs directory: %w", err)
+	}
+
+	fmt.Fprintln(out)
+	for _, stub := range stubs {
+		path := filepath.Join(stubsDir, stub.Filename)
+		if _, err := os.Stat(path); err == nil {
+			color.New(color.FgYellow).Fprintf(out, "⚠️  Skipped %s (file already exists)\n", path)
+			continue
+		}
+		if err := os.WriteFile(path, []byte(stub.Content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		color.New(color.FgGreen).Fprintf(out, "📝 Wrote %s\n", path)
+	}
+
+	return nil
+}
+
+// stubsDirectory returns where generated stubs are written: --stubs-dir,
+// validate.stubs_dir or "stubs"
+func stubsDirectory(cmd *cobra.Command, config *models.Config) string {
+	if stubsDir, _ := cmd.Flags().GetString("stubs-dir"); stubsDir != "" {
+		return stubsDir
+	}
+	if config.Validate.StubsDir != "" {
+		return config.Validate.StubsDir
+	}
+	return defaultStubsDir
+}
+
+// stubPatch renders a new file as a unified diff
+func stubPatch(path, content string) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+
+	var sb strings.Builder
+	sb.WriteString("--- /dev/null\n")
+	sb.WriteString(fmt.Sprintf("+++ b/%s\n", filepath.ToSlash(path)))
+	sb.WriteString(fmt.Sprintf("@@ -0,0 +1,%d @@\n", len(lines)))
+	for _, line := range lines {
+		sb.WriteString("+" + line + "\n")
+	}
+	return sb.String()
 }
@@ -0,0 +1,194 @@
+package commands
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// inProject runs fn in a temporary directory holding the given files
+func inProject(t *testing.T, files map[string]string, fn func()) {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	fn()
+}
+
+// captureStdout returns what fn writes to os.Stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stdout, fn)
+}
+
+// captureFile returns what fn writes to the given standard stream
+func captureFile(t *testing.T, stream **os.File, fn func()) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := *stream
+	*stream = writer
+	defer func() { *stream = original }()
+
+	done := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(reader)
+		done <- string(data)
+	}()
+	fn()
+	writer.Close()
+	return <-done
+}
+
+func TestValidateJSONReportWithAddUndocumented(t *testing.T) {
+	files := map[string]string{
+		".architect/api.yaml": `base_url: /api/v1
+auth_type: none
+endpoints:
+  - path: /widgets
+    method: GET
+    description: List widgets
+    auth: false
+`,
+		".architect/project.md": "# Widgets\n",
+		"server.js": `const app = require('express')();
+app.get('/api/v1/widgets', (req, res) => res.json([]));
+app.get('/api/v1/widgets/:widgetId', (req, res) => res.json({}));
+`,
+	}
+
+	inProject(t, files, func() {
+		cmd := ValidateCmd()
+		cmd.SetArgs([]string{"--output-format", "json", "--add-undocumented"})
+		cmd.SilenceUsage = true
+
+		output := captureStdout(t, func() {
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("validate failed: %v", err)
+			}
+		})
+
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+			t.Fatalf("stdout is not a JSON report: %v\n%s", err, output)
+		}
+
+		spec, err := os.ReadFile(".architect/api.yaml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(spec), "path: /widgets/{widgetId}") {
+			t.Errorf("draft endpoint not added relative to the base URL:\n%s", spec)
+		}
+	})
+}
+
+func runValidateCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := ValidateCmd()
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	var err error
+	output := captureStdout(t, func() { err = cmd.Execute() })
+	return output, err
+}
+
+func TestValidateUpdateBaselineWritesReport(t *testing.T) {
+	files := map[string]string{
+		".architect/api.yaml": `base_url: /api
+auth_type: none
+endpoints:
+  - path: /orders
+    method: GET
+    description: List orders
+    auth: false
+`,
+		".architect/project.md": "# Orders\n",
+	}
+
+	inProject(t, files, func() {
+		if _, err := runValidateCommand(t); err == nil {
+			t.Fatal("expected validate to fail on a missing endpoint")
+		}
+
+		if _, err := runValidateCommand(t, "--update-baseline", "--output-format", "json", "--report-file", "report.json"); err != nil {
+			t.Fatalf("update-baseline failed: %v", err)
+		}
+		if _, err := os.Stat("report.json"); err != nil {
+			t.Fatalf("no report written on --update-baseline: %v", err)
+		}
+		if _, err := os.Stat(baselineFile); err != nil {
+			t.Fatalf("no baseline written: %v", err)
+		}
+
+		output, err := runValidateCommand(t, "--output-format", "json")
+		if err != nil {
+			t.Fatalf("validate failed on a baselined finding: %v", err)
+		}
+		var decoded struct {
+			Cases []struct {
+				Name     string
+				Findings []struct {
+					RuleID    string `json:"rule_id"`
+					Severity  string `json:"severity"`
+					Baselined bool   `json:"baselined"`
+				}
+			}
+		}
+		if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+			t.Fatalf("invalid JSON report: %v\n%s", err, output)
+		}
+		if len(decoded.Cases) != 1 || len(decoded.Cases[0].Findings) != 1 {
+			t.Fatalf("expected one baselined finding, got %+v", decoded.Cases)
+		}
+		finding := decoded.Cases[0].Findings[0]
+		if !finding.Baselined || finding.Severity != "note" {
+			t.Errorf("finding not reported as baselined: %+v", finding)
+		}
+	})
+}
+
+func TestValidateIgnoresStubsDir(t *testing.T) {
+	files := map[string]string{
+		".architect/api.yaml": `base_url: /api
+auth_type: none
+endpoints:
+  - path: /orders
+    method: GET
+    description: List orders
+    auth: false
+`,
+		".architect/config.yaml":   "validate:\n  stubs_dir: handlers/stubs\n",
+		".architect/project.md":    "# Orders\n",
+		"handlers/stubs/orders.py": "@router.get('/api/orders')\ndef list_orders():\n    raise NotImplementedError\n",
+	}
+
+	inProject(t, files, func() {
+		if _, err := runValidateCommand(t); err == nil {
+			t.Error("a route in the stubs directory counted as an implementation")
+		}
+	})
+}
@@ -21,6 +21,23 @@ func WatchCmd() *cobra.Command {
 }
 
 func runWatch(cmd *cobra.Command, args []string) error {
+	color.Yellow("👀 Watching .architect/ for changes...")
+	fmt.Print("Press Ctrl+C to stop watching\n\n")
+
+	return watchSpecs(func() {
+		timestamp := time.Now().Format("15:04:05")
+		color.Blue("[%s] Syncing specifications...", timestamp)
+		if err := runSync(cmd, args); err != nil {
+			color.Red("Error syncing: %v", err)
+		}
+	})
+}
+
+// watchSpecs watches the .architect directory and calls onChange after
+// specification files change. Changes are debounced so that an editor
+// saving several files triggers a single call. It blocks until the watcher
+// is closed.
+func watchSpecs(onChange func()) error {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return fmt.Errorf("failed to create watcher: %w", err)
@@ -33,22 +50,13 @@ func runWatch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to watch directory: %w", err)
 	}
 
-	color.Yellow("👀 Watching .architect/ for changes...")
-	fmt.Println("Press Ctrl+C to stop watching\n")
-
 	// Debounce timer to avoid multiple syncs
 	var debounceTimer *time.Timer
 	syncFunc := func() {
 		if debounceTimer != nil {
 			debounceTimer.Stop()
 		}
-		debounceTimer = time.AfterFunc(500*time.Millisecond, func() {
-			timestamp := time.Now().Format("15:04:05")
-			color.Blue("[%s] Syncing specifications...", timestamp)
-			if err := runSync(cmd, args); err != nil {
-				color.Red("Error syncing: %v", err)
-			}
-		})
+		debounceTimer = time.AfterFunc(500*time.Millisecond, onChange)
 	}
 
 	// Watch for events
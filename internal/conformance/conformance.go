@@ -0,0 +1,311 @@
+// Package conformance checks HTTP traffic against the endpoints declared in
+// api.yaml: matching request paths to path templates, validating JSON bodies
+// against declared fields and running contract tests against a live server.
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// Violation is a single mismatch between a body and its declared fields
+type Violation struct {
+	Field   string
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Field == "" {
+		return v.Message
+	}
+	return fmt.Sprintf("%s: %s", v.Field, v.Message)
+}
+
+// MatchPath matches a request path against a path template such as
+// /users/{id} and returns the path parameters
+func MatchPath(template, path string) (map[string]string, bool) {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(templateSegments) != len(pathSegments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range templateSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if pathSegments[i] == "" {
+				return nil, false
+			}
+			value, err := url.PathUnescape(pathSegments[i])
+			if err != nil {
+				value = pathSegments[i]
+			}
+			params[strings.Trim(segment, "{}")] = value
+			continue
+		}
+		if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// FindEndpoint returns the endpoint declared for a request. Paths are matched
+// relative to the API base URL, and static segments take precedence over
+// parameters (/users/me matches before /users/{id}).
+func FindEndpoint(api *models.API, method, path string) (models.Endpoint, map[string]string, bool) {
+	path = StripBaseURL(api.BaseURL, path)
+
+	var best models.Endpoint
+	var bestParams map[string]string
+	found := false
+	for _, endpoint := range api.Endpoints {
+		if !strings.EqualFold(endpoint.Method, method) {
+			continue
+		}
+		params, ok := MatchPath(endpoint.Path, path)
+		if !ok {
+			continue
+		}
+		if !found || len(params) < len(bestParams) {
+			best, bestParams, found = endpoint, params, true
+		}
+	}
+	return best, bestParams, found
+}
+
+// StripBaseURL removes the path of the API base URL from a request path
+func StripBaseURL(baseURL, path string) string {
+	base := baseURL
+	if parsed, err := url.Parse(baseURL); err == nil && parsed.Host != "" {
+		base = parsed.Path
+	}
+	base = strings.TrimRight(base, "/")
+	if base != "" && (path == base || strings.HasPrefix(path, base+"/")) {
+		path = strings.TrimPrefix(path, base)
+	}
+	if path == "" {
+		path = "/"
+	}
+	return path
+}
+
+// DecodeJSON decodes a JSON document preserving integer/float distinction
+func DecodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// ValidateBody checks a decoded JSON body against declared fields. Fields
+// not declared in api.yaml are allowed.
+func ValidateBody(declared map[string]string, body interface{}) []Violation {
+	if len(declared) == 0 {
+		return nil
+	}
+
+	object, ok := body.(map[string]interface{})
+	if !ok {
+		return []Violation{{Message: fmt.Sprintf("expected a JSON object, got %s", jsonType(body))}}
+	}
+
+	var violations []Violation
+	for _, name := range sortedNames(declared) {
+		field := models.ParseField(declared[name])
+		value, present := object[name]
+		if !present || value == nil {
+			if field.Required {
+				violations = append(violations, Violation{Field: name, Message: "required field is missing"})
+			}
+			continue
+		}
+		if message := ValidateValue(field, value); message != "" {
+			violations = append(violations, Violation{Field: name, Message: message})
+		}
+	}
+	return violations
+}
+
+// ValidateQuery checks query string values against declared query fields
+func ValidateQuery(declared map[string]string, query url.Values) []Violation {
+	values := make(map[string]string, len(query))
+	for name := range query {
+		values[name] = query.Get(name)
+	}
+	return validateStrings(declared, values)
+}
+
+// ValidatePathParams checks path parameter values against declared params
+func ValidatePathParams(declared map[string]string, params map[string]string) []Violation {
+	return validateStrings(declared, params)
+}
+
+// validateStrings checks textual values, such as query and path parameters,
+// by converting them to the declared type before validating them
+func validateStrings(declared map[string]string, values map[string]string) []Violation {
+	var violations []Violation
+	for _, name := range sortedNames(declared) {
+		field := models.ParseField(declared[name])
+		raw, present := values[name]
+		if !present {
+			if field.Required {
+				violations = append(violations, Violation{Field: name, Message: "required parameter is missing"})
+			}
+			continue
+		}
+		if message := ValidateValue(field, parseScalar(field, raw)); message != "" {
+			violations = append(violations, Violation{Field: name, Message: message})
+		}
+	}
+	return violations
+}
+
+// parseScalar converts a textual value to the JSON type a field expects, so
+// that "42" is accepted for an integer parameter but "abc" is not
+func parseScalar(field models.Field, raw string) interface{} {
+	switch field.Type {
+	case "integer", "number":
+		n := json.Number(raw)
+		if _, err := n.Float64(); err == nil {
+			return n
+		}
+	case "boolean":
+		switch raw {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+	}
+	return raw
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ValidateValue checks a single decoded JSON value against a field
+// definition and returns a description of the problem, if any
+func ValidateValue(field models.Field, value interface{}) string {
+	expected := field.Type
+	switch field.Type {
+	case "string", "email", "url":
+		s, ok := value.(string)
+		if !ok {
+			break
+		}
+		if (field.Type == "email" || field.HasRule("email")) && !strings.Contains(s, "@") {
+			return fmt.Sprintf("%q is not a valid email", s)
+		}
+		return ""
+	case "uuid":
+		s, ok := value.(string)
+		if !ok {
+			break
+		}
+		if !uuidPattern.MatchString(s) {
+			return fmt.Sprintf("%q is not a valid uuid", s)
+		}
+		return ""
+	case "datetime":
+		s, ok := value.(string)
+		if !ok {
+			break
+		}
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return fmt.Sprintf("%q is not an RFC 3339 datetime", s)
+		}
+		return ""
+	case "integer":
+		if n, ok := value.(json.Number); ok {
+			if _, err := n.Int64(); err == nil {
+				return ""
+			}
+		}
+		if f, ok := value.(float64); ok && f == float64(int64(f)) {
+			return ""
+		}
+	case "number":
+		switch value.(type) {
+		case json.Number, float64, int:
+			return ""
+		}
+	case "boolean":
+		if _, ok := value.(bool); ok {
+			return ""
+		}
+	case "array":
+		if _, ok := value.([]interface{}); ok {
+			return ""
+		}
+	case "object":
+		if _, ok := value.(map[string]interface{}); ok {
+			return ""
+		}
+	default:
+		// Types architect does not know about are not checked
+		return ""
+	}
+	return fmt.Sprintf("expected %s, got %s", expected, jsonType(value))
+}
+
+// ErrorCode extracts the error code from an error response body in the
+// {"error": {"code": "..."}} format, falling back to a top-level "code"
+func ErrorCode(body interface{}) string {
+	object, ok := body.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if nested, ok := object["error"].(map[string]interface{}); ok {
+		if code, ok := nested["code"].(string); ok {
+			return code
+		}
+	}
+	if code, ok := object["code"].(string); ok {
+		return code
+	}
+	return ""
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case float64, int:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func sortedNames(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,165 @@
+package conformance
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/report"
+)
+
+// Exchange is a recorded HTTP request and its response. A nil RequestBody
+// with a multipart form content type means the form was not recorded.
+type Exchange struct {
+	Method          string
+	Path            string
+	Query           url.Values
+	RequestHeaders  http.Header
+	RequestBody     []byte
+	Status          int
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+}
+
+// CheckExchange checks a recorded request/response pair against the API and
+// returns the matched endpoint, if any, and every deviation found
+func CheckExchange(api *models.API, exchange Exchange) (models.Endpoint, bool, []report.Finding) {
+	var findings []report.Finding
+	add := func(rule, severity, format string, args ...interface{}) {
+		findings = append(findings, report.Finding{RuleID: rule, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	endpoint, params, ok := FindEndpoint(api, exchange.Method, exchange.Path)
+	if !ok {
+		add(RuleUnknownRoute, report.SeverityError, "%s %s is not declared in api.yaml (returned %d)", exchange.Method, exchange.Path, exchange.Status)
+		return endpoint, false, findings
+	}
+
+	// Request: invalid requests are only a problem when the server accepts them
+	if endpoint.Request != nil {
+		var violations []Violation
+		violations = append(violations, ValidatePathParams(endpoint.Request.Params, params)...)
+		violations = append(violations, ValidateQuery(endpoint.Request.Query, exchange.Query)...)
+		if len(endpoint.Request.Body) > 0 {
+			if form, isForm, err := decodeForm(exchange.RequestHeaders.Get("Content-Type"), exchange.RequestBody); isForm {
+				if err != nil {
+					violations = append(violations, Violation{Message: "request body is not valid form data"})
+				} else if form != nil {
+					violations = append(violations, validateStrings(endpoint.Request.Body, form)...)
+				}
+			} else if body, ok := decodeBody(exchange.RequestBody); ok {
+				violations = append(violations, ValidateBody(endpoint.Request.Body, body)...)
+			} else {
+				violations = append(violations, Violation{Message: "request body is not JSON"})
+			}
+		}
+		for _, violation := range violations {
+			if exchange.Status < 400 {
+				add(RuleInvalidRequestAccepted, report.SeverityError, "request %s, but the server accepted it with status %d", violation, exchange.Status)
+			} else {
+				add(RuleRequestSchema, report.SeverityNote, "request %s (rejected with %d)", violation, exchange.Status)
+			}
+		}
+	}
+
+	// Response status
+	expected := 0
+	if endpoint.Response != nil {
+		expected = endpoint.Response.Status
+	}
+	if exchange.Status == expected || (expected == 0 && exchange.Status >= 200 && exchange.Status < 300) {
+		if endpoint.Response != nil && len(endpoint.Response.Body) > 0 {
+			body, ok := decodeBody(exchange.ResponseBody)
+			if !ok {
+				add(RuleResponseSchema, report.SeverityError, "response body is not JSON")
+			} else {
+				for _, violation := range ValidateBody(endpoint.Response.Body, body) {
+					add(RuleResponseSchema, report.SeverityError, "response %s", violation)
+				}
+			}
+		}
+		return endpoint, true, findings
+	}
+
+	for _, declared := range endpoint.Errors {
+		if declared.Status != exchange.Status {
+			continue
+		}
+		if declared.Code != "" {
+			body, _ := decodeBody(exchange.ResponseBody)
+			if code := ErrorCode(body); code != declared.Code {
+				add(RuleErrorCodeMismatch, report.SeverityWarning, "status %d returned error code %q, expected %q", exchange.Status, code, declared.Code)
+			}
+		}
+		return endpoint, true, findings
+	}
+
+	add(RuleUndeclaredStatus, report.SeverityError, "status %d is not declared for %s %s", exchange.Status, endpoint.Method, endpoint.Path)
+	return endpoint, true, findings
+}
+
+// decodeForm decodes URL-encoded and multipart form bodies into their text
+// values; file parts are present with their file name. isForm is false for
+// other content types. A multipart form that was not recorded has no values.
+func decodeForm(contentType string, data []byte) (values map[string]string, isForm bool, err error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false, nil
+	}
+
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, true, err
+		}
+		values = make(map[string]string, len(form))
+		for name := range form {
+			values[name] = form.Get(name)
+		}
+		return values, true, nil
+
+	case "multipart/form-data":
+		if data == nil {
+			return nil, true, nil
+		}
+		reader := multipart.NewReader(bytes.NewReader(data), params["boundary"])
+		values = make(map[string]string)
+		for {
+			part, err := reader.NextPart()
+			if err == io.EOF {
+				return values, true, nil
+			}
+			if err != nil {
+				return nil, true, err
+			}
+			if part.FileName() != "" {
+				values[part.FormName()] = part.FileName()
+				continue
+			}
+			value, err := io.ReadAll(part)
+			if err != nil {
+				return nil, true, err
+			}
+			values[part.FormName()] = string(value)
+		}
+	}
+	return nil, false, nil
+}
+
+// decodeBody decodes a JSON body, treating an empty body as an empty object
+func decodeBody(data []byte) (interface{}, bool) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return map[string]interface{}{}, true
+	}
+	body, err := DecodeJSON(data)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
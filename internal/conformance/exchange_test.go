@@ -0,0 +1,75 @@
+package conformance
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestCheckExchangeRequestBodies(t *testing.T) {
+	api := &models.API{Endpoints: []models.Endpoint{{
+		Method:   "POST",
+		Path:     "/users",
+		Request:  &models.EndpointRequest{Body: map[string]string{"email": "string, required", "age": "integer, optional"}},
+		Response: &models.EndpointResponse{Status: 201},
+	}}}
+
+	multipartForm := func(fields map[string]string) (string, []byte) {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		for name, value := range fields {
+			writer.WriteField(name, value)
+		}
+		file, _ := writer.CreateFormFile("avatar", "me.png")
+		file.Write([]byte("png"))
+		writer.Close()
+		return writer.FormDataContentType(), body.Bytes()
+	}
+	completeType, complete := multipartForm(map[string]string{"email": "a@b.co", "age": "42"})
+	missingType, missing := multipartForm(map[string]string{"age": "42"})
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+		want        string
+	}{
+		{"json", "application/json", []byte(`{"email": "a@b.co", "age": 42}`), ""},
+		{"json missing field", "application/json", []byte(`{"age": 42}`), "email"},
+		{"not json", "application/json", []byte(`email=a@b.co`), "not JSON"},
+		{"url-encoded form", "application/x-www-form-urlencoded", []byte("email=a%40b.co&age=42"), ""},
+		{"url-encoded form missing field", "application/x-www-form-urlencoded", []byte("age=42"), "email"},
+		{"url-encoded form wrong type", "application/x-www-form-urlencoded; charset=utf-8", []byte("email=a%40b.co&age=old"), "age"},
+		{"malformed url-encoded form", "application/x-www-form-urlencoded", []byte("email=%zz"), "not valid form data"},
+		{"multipart form", completeType, complete, ""},
+		{"multipart form missing field", missingType, missing, "email"},
+		{"unrecorded multipart form", completeType, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, matched, findings := CheckExchange(api, Exchange{
+				Method:         "POST",
+				Path:           "/users",
+				RequestHeaders: http.Header{"Content-Type": {tt.contentType}},
+				RequestBody:    tt.body,
+				Status:         201,
+			})
+			if !matched {
+				t.Fatal("exchange did not match POST /users")
+			}
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("findings = %+v, want none", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].RuleID != RuleInvalidRequestAccepted || !strings.Contains(findings[0].Message, tt.want) {
+				t.Errorf("findings = %+v, want one %s mentioning %q", findings, RuleInvalidRequestAccepted, tt.want)
+			}
+		})
+	}
+}
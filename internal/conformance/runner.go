@@ -0,0 +1,355 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/fakedata"
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/report"
+)
+
+// Rule identifiers reported by contract tests and the validating proxy
+const (
+	RuleRequestFailed          = "request-failed"
+	RuleStatusMismatch         = "status-mismatch"
+	RuleResponseSchema         = "response-schema"
+	RuleInvalidRequestAccepted = "invalid-request-accepted"
+	RuleErrorCodeMismatch      = "error-code-mismatch"
+	RuleAuthNotEnforced        = "auth-not-enforced"
+	RuleSkipped                = "skipped"
+	RuleUnknownRoute           = "unknown-route"
+	RuleRequestSchema          = "request-schema"
+	RuleUndeclaredStatus       = "undeclared-status"
+)
+
+// RuleDescriptions describes every rule reported by contract tests and the
+// validating proxy
+var RuleDescriptions = map[string]string{
+	RuleRequestFailed:          "The request could not be sent or its response could not be read",
+	RuleStatusMismatch:         "The response status differs from the status declared in api.yaml",
+	RuleResponseSchema:         "The response body does not match the body declared in api.yaml",
+	RuleInvalidRequestAccepted: "A request violating the declared request body was accepted",
+	RuleErrorCodeMismatch:      "The error code differs from the code declared for the status",
+	RuleAuthNotEnforced:        "An endpoint declared with auth accepted an unauthenticated request",
+	RuleSkipped:                "A check was skipped",
+	RuleUnknownRoute:           "A request was sent to a route that is not declared in api.yaml",
+	RuleRequestSchema:          "A request does not match the request declared in api.yaml",
+	RuleUndeclaredStatus:       "A response status is neither the success status nor a declared error",
+}
+
+// Options configures a contract test run
+type Options struct {
+	// Target is the base URL of the running service, e.g. http://localhost:8080
+	Target string
+
+	// Token authenticates requests to endpoints declared with auth, using
+	// the scheme given by the API's auth_type
+	Token string
+
+	// Headers are sent with every request
+	Headers map[string]string
+
+	// Params holds fixed path parameter values, used instead of generated
+	// ones so that tests can address existing resources
+	Params map[string]string
+
+	// Client sends the requests, a client with a 10 second timeout when nil
+	Client *http.Client
+
+	// Seed makes generated request data reproducible
+	Seed int64
+}
+
+// Runner exercises the endpoints of an API against a running server
+type Runner struct {
+	api  *models.API
+	opts Options
+	fake *fakedata.Generator
+}
+
+// NewRunner creates a contract test runner
+func NewRunner(api *models.API, opts Options) *Runner {
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Runner{api: api, opts: opts, fake: fakedata.New(opts.Seed)}
+}
+
+// response is a completed HTTP exchange
+type response struct {
+	status int
+	body   interface{}
+}
+
+// TestEndpoint sends a valid request, an invalid request and, for endpoints
+// declared with auth, an unauthenticated request, and reports every
+// deviation from the specification
+func (r *Runner) TestEndpoint(endpoint models.Endpoint) report.Case {
+	c := report.Case{Name: endpoint.Method + " " + endpoint.Path, Classname: "contract"}
+	add := func(rule, severity, format string, args ...interface{}) {
+		c.Findings = append(c.Findings, report.Finding{RuleID: rule, Severity: severity, Message: fmt.Sprintf(format, args...)})
+	}
+
+	target := r.url(endpoint)
+	body := r.validBody(endpoint)
+
+	// Valid request
+	if endpoint.Auth && r.opts.Token == "" {
+		add(RuleSkipped, report.SeverityNote, "valid request skipped: endpoint requires auth, pass --token")
+	} else {
+		resp, err := r.send(endpoint.Method, target, body, endpoint.Auth)
+		if err != nil {
+			add(RuleRequestFailed, report.SeverityError, "%v", err)
+			return c
+		}
+		r.checkSuccess(endpoint, resp, add)
+	}
+
+	// Invalid request: every declared body field with a value of the wrong type
+	if invalid := r.invalidBody(endpoint); invalid != nil && (!endpoint.Auth || r.opts.Token != "") {
+		resp, err := r.send(endpoint.Method, target, invalid, endpoint.Auth)
+		if err != nil {
+			add(RuleRequestFailed, report.SeverityError, "%v", err)
+		} else if resp.status < 400 {
+			add(RuleInvalidRequestAccepted, report.SeverityError, "invalid request body was accepted with status %d", resp.status)
+		} else if resp.status < 500 {
+			r.checkError(endpoint, resp, []int{400, 422}, add)
+		} else {
+			add(RuleStatusMismatch, report.SeverityError, "invalid request body caused a server error (%d) instead of a 4xx response", resp.status)
+		}
+	}
+
+	// Unauthenticated request
+	if endpoint.Auth {
+		resp, err := r.send(endpoint.Method, target, body, false)
+		if err != nil {
+			add(RuleRequestFailed, report.SeverityError, "%v", err)
+		} else if resp.status < 400 {
+			add(RuleAuthNotEnforced, report.SeverityError, "request without credentials succeeded with status %d", resp.status)
+		} else if resp.status == http.StatusUnauthorized || resp.status == http.StatusForbidden {
+			r.checkError(endpoint, resp, []int{401, 403}, add)
+		} else {
+			add(RuleStatusMismatch, report.SeverityWarning, "request without credentials returned %d, expected 401 or 403", resp.status)
+		}
+	}
+
+	return c
+}
+
+// checkSuccess checks the response to a valid request
+func (r *Runner) checkSuccess(endpoint models.Endpoint, resp *response, add func(rule, severity, format string, args ...interface{})) {
+	expected := 0
+	if endpoint.Response != nil {
+		expected = endpoint.Response.Status
+	}
+
+	ok := resp.status == expected || (expected == 0 && resp.status >= 200 && resp.status < 300)
+	if ok {
+		if endpoint.Response != nil && len(endpoint.Response.Body) > 0 {
+			if resp.body == nil {
+				add(RuleResponseSchema, report.SeverityError, "response body is not JSON")
+				return
+			}
+			for _, violation := range ValidateBody(endpoint.Response.Body, resp.body) {
+				add(RuleResponseSchema, report.SeverityError, "response %s", violation)
+			}
+		}
+		return
+	}
+
+	if resp.status == http.StatusNotFound && strings.Contains(endpoint.Path, "{") && !r.hasAllParams(endpoint.Path) {
+		add(RuleStatusMismatch, report.SeverityWarning, "returned 404 for generated path parameters; pass --param to test an existing resource")
+		return
+	}
+	for _, declared := range endpoint.Errors {
+		if declared.Status == resp.status {
+			add(RuleStatusMismatch, report.SeverityWarning, "valid request returned declared error %d (%s)", resp.status, declared.Code)
+			return
+		}
+	}
+
+	if expected == 0 {
+		add(RuleStatusMismatch, report.SeverityError, "expected a 2xx status, got %d", resp.status)
+	} else {
+		add(RuleStatusMismatch, report.SeverityError, "expected status %d, got %d", expected, resp.status)
+	}
+}
+
+// checkError compares an error response with the errors declared for the
+// endpoint. acceptable lists the statuses that are correct without being
+// declared explicitly.
+func (r *Runner) checkError(endpoint models.Endpoint, resp *response, acceptable []int, add func(rule, severity, format string, args ...interface{})) {
+	var declaredStatuses []string
+	for _, declared := range endpoint.Errors {
+		if declared.Status == resp.status {
+			if declared.Code != "" {
+				if code := ErrorCode(resp.body); code != declared.Code {
+					add(RuleErrorCodeMismatch, report.SeverityWarning, "status %d returned error code %q, expected %q", resp.status, code, declared.Code)
+				}
+			}
+			return
+		}
+		for _, status := range acceptable {
+			if declared.Status == status {
+				declaredStatuses = append(declaredStatuses, fmt.Sprint(status))
+			}
+		}
+	}
+
+	if len(declaredStatuses) > 0 {
+		add(RuleStatusMismatch, report.SeverityWarning, "returned %d, but api.yaml declares %s", resp.status, strings.Join(declaredStatuses, " or "))
+	}
+}
+
+// url builds the request URL, filling path parameters and required query
+// parameters
+func (r *Runner) url(endpoint models.Endpoint) string {
+	var params, query map[string]string
+	if endpoint.Request != nil {
+		params, query = endpoint.Request.Params, endpoint.Request.Query
+	}
+
+	segments := strings.Split(endpoint.Path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name := strings.Trim(segment, "{}")
+			value, ok := r.opts.Params[name]
+			if !ok {
+				value = r.fake.PathParam(name, params[name])
+			}
+			segments[i] = url.PathEscape(value)
+		}
+	}
+
+	target := strings.TrimRight(r.opts.Target, "/") + joinBase(r.api.BaseURL, strings.Join(segments, "/"))
+
+	values := url.Values{}
+	for _, name := range sortedNames(query) {
+		field := models.ParseField(query[name])
+		if field.Required {
+			values.Set(name, fmt.Sprint(r.fake.Value(name, field)))
+		}
+	}
+	if len(values) > 0 {
+		target += "?" + values.Encode()
+	}
+	return target
+}
+
+// joinBase prefixes a path with the path component of the API base URL
+func joinBase(baseURL, path string) string {
+	base := baseURL
+	if parsed, err := url.Parse(baseURL); err == nil && parsed.Host != "" {
+		base = parsed.Path
+	}
+	return strings.TrimRight(base, "/") + path
+}
+
+func (r *Runner) hasAllParams(path string) bool {
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") {
+			if _, ok := r.opts.Params[strings.Trim(segment, "{}")]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (r *Runner) validBody(endpoint models.Endpoint) map[string]interface{} {
+	if endpoint.Request == nil || len(endpoint.Request.Body) == 0 {
+		return nil
+	}
+	return r.fake.Body(endpoint.Request.Body)
+}
+
+func (r *Runner) invalidBody(endpoint models.Endpoint) map[string]interface{} {
+	if endpoint.Request == nil || len(endpoint.Request.Body) == 0 {
+		return nil
+	}
+	body := make(map[string]interface{}, len(endpoint.Request.Body))
+	for name, def := range endpoint.Request.Body {
+		body[name] = r.fake.Invalid(models.ParseField(def))
+	}
+	return body
+}
+
+// credentialHeaderParts are substrings of header names carrying credentials,
+// such as Authorization, X-API-Key, X-Auth-Token or Cookie
+var credentialHeaderParts = []string{"auth", "token", "api-key", "apikey", "api_key", "cookie", "session", "secret"}
+
+// isCredentialHeader reports whether a header may authenticate a request
+func isCredentialHeader(name string) bool {
+	name = strings.ToLower(name)
+	for _, part := range credentialHeaderParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// send performs a request, authenticating it when authenticate is set
+func (r *Runner) send(method, target string, body map[string]interface{}, authenticate bool) (*response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for name, value := range r.opts.Headers {
+		// Credentials passed as headers would authenticate the
+		// unauthenticated request
+		if !authenticate && isCredentialHeader(name) {
+			continue
+		}
+		req.Header.Set(name, value)
+	}
+	if authenticate && r.opts.Token != "" {
+		switch r.api.AuthType {
+		case "basic":
+			req.Header.Set("Authorization", "Basic "+r.opts.Token)
+		case "apikey", "api_key":
+			req.Header.Set("X-API-Key", r.opts.Token)
+		default:
+			req.Header.Set("Authorization", "Bearer "+r.opts.Token)
+		}
+	}
+
+	resp, err := r.opts.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	result := &response{status: resp.StatusCode}
+	if len(bytes.TrimSpace(raw)) > 0 {
+		if decoded, err := DecodeJSON(raw); err == nil {
+			result.body = decoded
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,103 @@
+package conformance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/report"
+)
+
+// authServer accepts requests carrying any credential and records the
+// headers of the requests it rejects
+func authServer(t *testing.T, rejected *[]http.Header) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") == "" && req.Header.Get("X-API-Key") == "" && req.Header.Get("Cookie") == "" {
+			*rejected = append(*rejected, req.Header.Clone())
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": {"code": "UNAUTHORIZED", "message": "missing credentials"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": 1}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestUnauthenticatedRequestDropsCredentialHeaders(t *testing.T) {
+	api := &models.API{AuthType: "bearer", Endpoints: []models.Endpoint{
+		{Method: "GET", Path: "/orders", Auth: true},
+	}}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"authorization header", map[string]string{"Authorization": "Bearer secret"}},
+		{"api key header", map[string]string{"X-API-Key": "secret"}},
+		{"cookie", map[string]string{"Cookie": "session=secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rejected []http.Header
+			server := authServer(t, &rejected)
+
+			headers := map[string]string{"X-Tenant": "acme"}
+			for name, value := range tt.headers {
+				headers[name] = value
+			}
+			runner := NewRunner(api, Options{Target: server.URL, Headers: headers})
+			c := runner.TestEndpoint(api.Endpoints[0])
+
+			for _, finding := range c.Findings {
+				if finding.RuleID == RuleAuthNotEnforced {
+					t.Fatalf("credential header sent with the unauthenticated request: %s", finding.Message)
+				}
+			}
+			if len(rejected) != 1 {
+				t.Fatalf("expected one unauthenticated request, got %d", len(rejected))
+			}
+			if got := rejected[0].Get("X-Tenant"); got != "acme" {
+				t.Errorf("non-credential header X-Tenant = %q, want acme", got)
+			}
+		})
+	}
+}
+
+func TestAuthenticatedRequestKeepsHeaders(t *testing.T) {
+	var rejected []http.Header
+	server := authServer(t, &rejected)
+
+	api := &models.API{Endpoints: []models.Endpoint{
+		{Method: "GET", Path: "/orders", Auth: true},
+	}}
+	runner := NewRunner(api, Options{Target: server.URL, Token: "token", Headers: map[string]string{"X-API-Key": "secret"}})
+	c := runner.TestEndpoint(api.Endpoints[0])
+
+	for _, finding := range c.Findings {
+		if finding.Severity == report.SeverityError {
+			t.Errorf("unexpected finding: %+v", finding)
+		}
+	}
+}
+
+func TestIsCredentialHeader(t *testing.T) {
+	tests := map[string]bool{
+		"Authorization":       true,
+		"Proxy-Authorization": true,
+		"X-API-Key":           true,
+		"X-Auth-Token":        true,
+		"Cookie":              true,
+		"X-Tenant":            false,
+		"Accept-Language":     false,
+	}
+	for name, want := range tests {
+		if got := isCredentialHeader(name); got != want {
+			t.Errorf("isCredentialHeader(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package exporters
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// AsyncAPIExporter exports the channels of the specification as an
+// AsyncAPI 3.0 document
+type AsyncAPIExporter struct{}
+
+// Name returns the format name
+func (e *AsyncAPIExporter) Name() string { return "asyncapi" }
+
+// Description returns a one-line description of the format
+func (e *AsyncAPIExporter) Description() string { return "AsyncAPI 3.0 document of the channels" }
+
+// GetSupportedExtensions returns the file extensions of the exported files
+func (e *AsyncAPIExporter) GetSupportedExtensions() []string { return []string{".yaml", ".yml"} }
+
+// DefaultFilename returns the file written when no output is given
+func (e *AsyncAPIExporter) DefaultFilename() string { return "asyncapi.yaml" }
+
+// Options returns the options the exporter accepts
+func (e *AsyncAPIExporter) Options() []Option {
+	return []Option{
+		{Name: "title", Description: "Title of the document", Default: "API Events"},
+		{Name: "version", Description: "Version of the document", Default: "1.0.0"},
+	}
+}
+
+// Export converts the channels to an AsyncAPI document
+func (e *AsyncAPIExporter) Export(api *models.API, options map[string]string) (*Output, error) {
+	if len(api.Channels) == 0 {
+		return nil, fmt.Errorf("api.yaml has no channels to export")
+	}
+	return &Output{Content: exportAsyncAPI(api, options["title"], options["version"])}, nil
+}
+
+// exportAsyncAPI writes an AsyncAPI 3.0 document with one channel per
+// address, one operation per message and role, and the messages as
+// components
+func exportAsyncAPI(api *models.API, title, version string) string {
+	channels := make(map[string]interface{})
+	operations := make(map[string]interface{})
+	messages := make(map[string]interface{})
+	channelIDs := make(map[string]string)
+
+	for _, channel := range api.Channels {
+		id, ok := channelIDs[channel.Name]
+		if !ok {
+			id = protoIdentifier(channel.Name)
+			if id == "" {
+				id = "channel"
+			}
+			id = strings.ToLower(id[:1]) + id[1:]
+			for base, n := id, 2; channels[id] != nil; n++ {
+				id = fmt.Sprintf("%s%d", base, n)
+			}
+			channelIDs[channel.Name] = id
+			definition := map[string]interface{}{
+				"address":  channel.Name,
+				"messages": make(map[string]interface{}),
+			}
+			// Addresses such as payments.{id} declare their parameters
+			parameters := make(map[string]interface{})
+			for _, match := range regexp.MustCompile(`\{(\w+)\}`).FindAllStringSubmatch(channel.Name, -1) {
+				parameters[match[1]] = map[string]interface{}{}
+			}
+			if len(parameters) > 0 {
+				definition["parameters"] = parameters
+			}
+			channels[id] = definition
+		}
+
+		message := channel.Message
+		if message == "" {
+			message = protoIdentifier(channel.Name)
+		}
+		definition := map[string]interface{}{"name": message}
+		if len(channel.Payload) > 0 {
+			definition["payload"] = buildSchema(channel.Payload)
+		}
+		if len(channel.Headers) > 0 {
+			definition["headers"] = buildSchema(channel.Headers)
+		}
+		messages[message] = definition
+		channels[id].(map[string]interface{})["messages"].(map[string]interface{})[message] = map[string]string{
+			"$ref": "#/components/messages/" + message,
+		}
+
+		action := "send"
+		if channel.Role == "consumer" {
+			action = "receive"
+		}
+		operationID := action + protoIdentifier(message)
+		for base, n := operationID, 2; operations[operationID] != nil; n++ {
+			operationID = fmt.Sprintf("%s%d", base, n)
+		}
+		operation := map[string]interface{}{
+			"action":   action,
+			"channel":  map[string]string{"$ref": "#/channels/" + id},
+			"messages": []map[string]string{{"$ref": "#/channels/" + id + "/messages/" + message}},
+		}
+		if channel.Description != "" {
+			operation["summary"] = channel.Description
+		}
+		operations[operationID] = operation
+	}
+
+	document := map[string]interface{}{
+		"asyncapi": "3.0.0",
+		"info": map[string]string{
+			"title":   title,
+			"version": version,
+		},
+		"channels":   channels,
+		"operations": operations,
+		"components": map[string]interface{}{"messages": messages},
+	}
+
+	data, _ := yaml.Marshal(document)
+	return string(data)
+}
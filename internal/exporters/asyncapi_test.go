@@ -0,0 +1,55 @@
+package exporters_test
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/importers"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestAsyncAPIRoundTrip(t *testing.T) {
+	for _, fixture := range []string{"../importers/testdata/events-v2.yaml", "../importers/testdata/events-v3.json"} {
+		t.Run(fixture, func(t *testing.T) {
+			api, err := (&importers.AsyncAPIImporter{}).Import(fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			imported := exportAndImport(t, "asyncapi", &importers.AsyncAPIImporter{}, api)
+			if got, want := sortedChannels(imported.Channels), sortedChannels(api.Channels); !reflect.DeepEqual(got, want) {
+				t.Errorf("channels =\n%+v\nwant\n%+v", got, want)
+			}
+		})
+	}
+}
+
+func TestAsyncAPIRoundTripBothRoles(t *testing.T) {
+	// A channel the service both produces and consumes keeps one entry per role
+	api := &models.API{Channels: []models.Channel{
+		{Name: "orders", Role: "producer", Message: "OrderPlaced", Payload: map[string]string{"id": "string, required"}},
+		{Name: "orders", Role: "consumer", Message: "OrderPlaced", Payload: map[string]string{"id": "string, required"}},
+	}}
+	imported := exportAndImport(t, "asyncapi", &importers.AsyncAPIImporter{}, api)
+	if got, want := sortedChannels(imported.Channels), sortedChannels(api.Channels); !reflect.DeepEqual(got, want) {
+		t.Errorf("channels = %+v, want %+v", got, want)
+	}
+}
+
+// sortedChannels orders channels by name, message and role, the export
+// order of the operations being by operation id
+func sortedChannels(channels []models.Channel) []models.Channel {
+	sorted := append([]models.Channel(nil), channels...)
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Message != b.Message {
+			return a.Message < b.Message
+		}
+		return a.Role < b.Role
+	})
+	return sorted
+}
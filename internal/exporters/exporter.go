@@ -0,0 +1,126 @@
+package exporters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// Exporter defines the interface for exporting API specifications to different formats
+type Exporter interface {
+	// Name returns the format name used with --format
+	Name() string
+
+	// Description returns a one-line description of the format
+	Description() string
+
+	// GetSupportedExtensions returns the file extensions of the exported files
+	GetSupportedExtensions() []string
+
+	// DefaultFilename returns the file written when no output is given
+	DefaultFilename() string
+
+	// Options returns the options the exporter accepts
+	Options() []Option
+
+	// Export converts our internal API model to the format. Options hold a
+	// value for every option the exporter declares.
+	Export(api *models.API, options map[string]string) (*Output, error)
+}
+
+// Option is a setting of an exporter, given as --option name=value
+type Option struct {
+	Name        string
+	Description string
+	Default     string
+}
+
+// Output is the result of an export
+type Output struct {
+	Content string
+
+	// Companions are files written next to the main output, keyed by file
+	// name
+	Companions map[string]string
+}
+
+// Registry holds the available exporters by format name
+type Registry struct {
+	exporters map[string]Exporter
+}
+
+// NewRegistry returns a registry with the built-in exporters
+func NewRegistry() *Registry {
+	registry := &Registry{exporters: make(map[string]Exporter)}
+	registry.Register(&OpenAPIExporter{})
+	registry.Register(&MarkdownExporter{})
+	registry.Register(&PostmanExporter{})
+	registry.Register(&InsomniaExporter{})
+	registry.Register(&GraphQLExporter{})
+	registry.Register(&ProtoExporter{})
+	registry.Register(&AsyncAPIExporter{})
+	return registry
+}
+
+// Register adds an exporter, replacing any exporter with the same name
+func (r *Registry) Register(exporter Exporter) {
+	r.exporters[exporter.Name()] = exporter
+}
+
+// Get returns the exporter for a format
+func (r *Registry) Get(format string) (Exporter, error) {
+	exporter, ok := r.exporters[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported format: %s (available: %s)", format, strings.Join(r.Names(), ", "))
+	}
+	return exporter, nil
+}
+
+// Names returns the registered format names in sorted order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.exporters))
+	for name := range r.exporters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// List returns the registered exporters sorted by name
+func (r *Registry) List() []Exporter {
+	var list []Exporter
+	for _, name := range r.Names() {
+		list = append(list, r.exporters[name])
+	}
+	return list
+}
+
+// ResolveOptions fills in the defaults of the options an exporter declares
+// and rejects options it does not know
+func ResolveOptions(exporter Exporter, given map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string)
+	known := make(map[string]bool)
+	for _, option := range exporter.Options() {
+		known[option.Name] = true
+		resolved[option.Name] = option.Default
+	}
+
+	for name, value := range given {
+		if !known[name] {
+			return nil, fmt.Errorf("format %s has no option %q", exporter.Name(), name)
+		}
+		resolved[name] = value
+	}
+	return resolved, nil
+}
+
+func sortedFieldNames(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
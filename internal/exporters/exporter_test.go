@@ -0,0 +1,82 @@
+package exporters_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/exporters"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// titledExporter is a stand-in for a plugin exporter
+type titledExporter struct{ name string }
+
+func (e *titledExporter) Name() string                     { return e.name }
+func (e *titledExporter) Description() string              { return "test format" }
+func (e *titledExporter) GetSupportedExtensions() []string { return []string{".txt"} }
+func (e *titledExporter) DefaultFilename() string          { return "api.txt" }
+func (e *titledExporter) Options() []exporters.Option {
+	return []exporters.Option{{Name: "title", Default: "API"}, {Name: "style"}}
+}
+func (e *titledExporter) Export(api *models.API, options map[string]string) (*exporters.Output, error) {
+	return &exporters.Output{Content: options["title"]}, nil
+}
+
+func TestRegistry(t *testing.T) {
+	registry := exporters.NewRegistry()
+	want := []string{"asyncapi", "graphql", "insomnia", "markdown", "openapi", "postman", "proto"}
+	if got := registry.Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+	for idx, exporter := range registry.List() {
+		if exporter.Name() != want[idx] {
+			t.Errorf("List()[%d] = %s, want %s", idx, exporter.Name(), want[idx])
+		}
+	}
+
+	_, err := registry.Get("pdf")
+	if err == nil || !strings.Contains(err.Error(), "unsupported format: pdf") || !strings.Contains(err.Error(), "openapi, postman") {
+		t.Errorf("error = %v, want the available formats listed", err)
+	}
+
+	// Registering a format again replaces it
+	registry.Register(&titledExporter{name: "markdown"})
+	exporter, err := registry.Get("markdown")
+	if err != nil || exporter.Description() != "test format" {
+		t.Errorf("markdown = %v, %v, want the replacement", exporter, err)
+	}
+	if len(registry.Names()) != len(want) {
+		t.Errorf("names = %v", registry.Names())
+	}
+}
+
+func TestResolveOptions(t *testing.T) {
+	exporter := &titledExporter{name: "text"}
+
+	options, err := exporters.ResolveOptions(exporter, nil)
+	if err != nil || !reflect.DeepEqual(options, map[string]string{"title": "API", "style": ""}) {
+		t.Errorf("defaults = %v, %v", options, err)
+	}
+
+	options, err = exporters.ResolveOptions(exporter, map[string]string{"title": "Shop"})
+	if err != nil || options["title"] != "Shop" {
+		t.Errorf("options = %v, %v", options, err)
+	}
+
+	if _, err := exporters.ResolveOptions(exporter, map[string]string{"colour": "red"}); err == nil || !strings.Contains(err.Error(), `format text has no option "colour"`) {
+		t.Errorf("error = %v, want an unknown option error", err)
+	}
+}
+
+func TestExportersRequireTheirSection(t *testing.T) {
+	api := &models.API{BaseURL: "/api", AuthType: "bearer", Endpoints: []models.Endpoint{{Path: "/users", Method: "GET"}}}
+	registry := exporters.NewRegistry()
+	for _, format := range []string{"graphql", "asyncapi"} {
+		exporter, _ := registry.Get(format)
+		options, _ := exporters.ResolveOptions(exporter, nil)
+		if _, err := exporter.Export(api, options); err == nil {
+			t.Errorf("%s export of an API without its section succeeded", format)
+		}
+	}
+}
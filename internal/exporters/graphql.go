@@ -0,0 +1,129 @@
+package exporters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// GraphQLExporter exports the graphql section of the specification as an
+// SDL schema
+type GraphQLExporter struct{}
+
+// Name returns the format name
+func (e *GraphQLExporter) Name() string { return "graphql" }
+
+// Description returns a one-line description of the format
+func (e *GraphQLExporter) Description() string { return "GraphQL SDL schema of the graphql section" }
+
+// GetSupportedExtensions returns the file extensions of the exported files
+func (e *GraphQLExporter) GetSupportedExtensions() []string {
+	return []string{".graphql", ".graphqls", ".gql"}
+}
+
+// DefaultFilename returns the file written when no output is given
+func (e *GraphQLExporter) DefaultFilename() string { return "schema.graphql" }
+
+// Options returns the options the exporter accepts
+func (e *GraphQLExporter) Options() []Option { return nil }
+
+// Export converts the graphql section to an SDL schema
+func (e *GraphQLExporter) Export(api *models.API, options map[string]string) (*Output, error) {
+	if api.GraphQL == nil {
+		return nil, fmt.Errorf("api.yaml has no graphql section to export")
+	}
+	return &Output{Content: exportGraphQL(api.GraphQL)}, nil
+}
+
+func exportGraphQL(schema *models.GraphQL) string {
+	var sb strings.Builder
+
+	// Root types first, with one field per operation
+	var roots []string
+	for _, operationType := range []string{"query", "mutation", "subscription"} {
+		var fields []string
+		for _, operation := range schema.Operations {
+			if operation.Type != operationType {
+				continue
+			}
+			field := graphqlDescription(operation.Description, "  ") + "  " + operation.Name
+			fields = append(fields, field+graphqlArguments(operation.Input)+": "+operation.Output)
+		}
+		if len(fields) > 0 {
+			name := strings.ToUpper(operationType[:1]) + operationType[1:]
+			roots = append(roots, "  "+operationType+": "+name)
+			sb.WriteString("type " + name + " {\n" + strings.Join(fields, "\n") + "\n}\n\n")
+		}
+	}
+
+	// A named type called like a root type, such as a Subscription type in a
+	// schema without subscriptions, needs the roots spelled out
+	for _, definition := range schema.Types {
+		if len(roots) > 0 && (definition.Name == "Query" || definition.Name == "Mutation" || definition.Name == "Subscription") {
+			rootTypes := sb.String()
+			sb.Reset()
+			sb.WriteString("schema {\n" + strings.Join(roots, "\n") + "\n}\n\n" + rootTypes)
+			break
+		}
+	}
+
+	for _, definition := range schema.Types {
+		sb.WriteString(graphqlDescription(definition.Description, ""))
+		switch definition.Kind {
+		case "scalar":
+			sb.WriteString("scalar " + definition.Name + "\n\n")
+		case "union":
+			sb.WriteString("union " + definition.Name + " = " + strings.Join(definition.Values, " | ") + "\n\n")
+		case "enum":
+			sb.WriteString("enum " + definition.Name + " {\n")
+			for _, value := range definition.Values {
+				sb.WriteString("  " + value + "\n")
+			}
+			sb.WriteString("}\n\n")
+		default:
+			sb.WriteString(definition.Kind + " " + definition.Name)
+			if len(definition.Implements) > 0 {
+				sb.WriteString(" implements " + strings.Join(definition.Implements, " & "))
+			}
+			sb.WriteString(" {\n")
+			for _, name := range sortedFieldNames(definition.Fields) {
+				// Earlier imports stored the arguments in the type: "(args): Type"
+				if strings.HasPrefix(definition.Fields[name], "(") {
+					sb.WriteString("  " + name + definition.Fields[name] + "\n")
+				} else {
+					sb.WriteString("  " + name + graphqlArguments(definition.Arguments[name]) + ": " + definition.Fields[name] + "\n")
+				}
+			}
+			sb.WriteString("}\n\n")
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// graphqlArguments renders an argument list such as "(first: Int = 10)", or
+// nothing for a field without arguments
+func graphqlArguments(args map[string]string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	list := make([]string, 0, len(args))
+	for _, name := range sortedFieldNames(args) {
+		list = append(list, name+": "+args[name])
+	}
+	return "(" + strings.Join(list, ", ") + ")"
+}
+
+// graphqlDescription renders a description line, or a block string for
+// multi-line descriptions
+func graphqlDescription(description, indent string) string {
+	if description == "" {
+		return ""
+	}
+	if strings.Contains(description, "\n") {
+		return indent + `"""` + "\n" + indent + strings.ReplaceAll(description, "\n", "\n"+indent) + "\n" + indent + `"""` + "\n"
+	}
+	return indent + strconv.Quote(description) + "\n"
+}
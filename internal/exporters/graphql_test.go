@@ -0,0 +1,55 @@
+package exporters_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/exporters"
+	"github.com/faisalahmedsifat/architect/internal/importers"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestGraphQLRoundTrip(t *testing.T) {
+	api, err := (&importers.GraphQLImporter{}).Import("../importers/testdata/blog.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported := exportAndImport(t, "graphql", &importers.GraphQLImporter{}, api)
+	if !reflect.DeepEqual(imported.GraphQL.Operations, api.GraphQL.Operations) {
+		t.Errorf("operations = %+v, want %+v", imported.GraphQL.Operations, api.GraphQL.Operations)
+	}
+
+	// The fixture renames its roots, so Subscription is a plain type that
+	// must not come back as a root
+	if !reflect.DeepEqual(imported.GraphQL.Types, api.GraphQL.Types) {
+		t.Errorf("types = %+v, want %+v", imported.GraphQL.Types, api.GraphQL.Types)
+	}
+}
+
+func TestGraphQLExportArguments(t *testing.T) {
+	api := &models.API{GraphQL: &models.GraphQL{
+		Operations: []models.GraphQLOperation{{Name: "users", Type: "query", Output: "[User!]!"}},
+		Types: []models.GraphQLType{
+			{
+				Name: "User", Kind: "type",
+				Fields:    map[string]string{"id": "ID!", "posts": "[Post!]!", "legacy": "(first: Int): [Post!]!"},
+				Arguments: map[string]map[string]string{"posts": {"first": "Int = 10", "after": "String"}},
+			},
+		},
+	}}
+	output, err := (&exporters.GraphQLExporter{}).Export(api, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"  posts(after: String, first: Int = 10): [Post!]!\n",
+		"  legacy(first: Int): [Post!]!\n",
+		"  id: ID!\n",
+	} {
+		if !strings.Contains(output.Content, want) {
+			t.Errorf("export lacks %q:\n%s", want, output.Content)
+		}
+	}
+}
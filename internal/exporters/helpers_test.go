@@ -0,0 +1,48 @@
+package exporters_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/exporters"
+	"github.com/faisalahmedsifat/architect/internal/importers"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// exportAndImport exports the API in a format with the default options and
+// imports the written file back
+func exportAndImport(t *testing.T, format string, importer importers.Importer, api *models.API) *models.API {
+	t.Helper()
+	exporter, err := exporters.NewRegistry().Get(format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options, err := exporters.ResolveOptions(exporter, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, err := exporter.Export(api, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), exporter.DefaultFilename())
+	if err := os.WriteFile(file, []byte(output.Content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	imported, err := importer.Import(file)
+	if err != nil {
+		t.Fatalf("importing the %s export: %v\n%s", format, err, output.Content)
+	}
+	return imported
+}
+
+// endpointsByRoute keys endpoints by "METHOD path"
+func endpointsByRoute(api *models.API) map[string]models.Endpoint {
+	endpoints := make(map[string]models.Endpoint)
+	for _, endpoint := range api.Endpoints {
+		endpoints[endpoint.Method+" "+endpoint.Path] = endpoint
+	}
+	return endpoints
+}
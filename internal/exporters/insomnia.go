@@ -0,0 +1,159 @@
+package exporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/fakedata"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// InsomniaExporter exports the specification as an Insomnia v4 export
+type InsomniaExporter struct{}
+
+// Name returns the format name
+func (e *InsomniaExporter) Name() string { return "insomnia" }
+
+// Description returns a one-line description of the format
+func (e *InsomniaExporter) Description() string { return "Insomnia v4 export" }
+
+// GetSupportedExtensions returns the file extensions of the exported files
+func (e *InsomniaExporter) GetSupportedExtensions() []string { return []string{".json"} }
+
+// DefaultFilename returns the file written when no output is given
+func (e *InsomniaExporter) DefaultFilename() string { return "insomnia.json" }
+
+// Options returns the options the exporter accepts
+func (e *InsomniaExporter) Options() []Option {
+	return []Option{
+		{Name: "name", Description: "Name of the workspace", Default: "API Collection"},
+	}
+}
+
+// Export converts the API to an Insomnia export
+func (e *InsomniaExporter) Export(api *models.API, options map[string]string) (*Output, error) {
+	return &Output{Content: exportInsomnia(api, options["name"])}, nil
+}
+
+func exportInsomnia(api *models.API, name string) string {
+	// Insomnia v4 export: a workspace with a base environment holding the base
+	// URL and credentials, and one request group per top-level resource
+	workspaceID := "wrk_architect"
+	resources := []map[string]interface{}{
+		{
+			"_id":         workspaceID,
+			"_type":       "workspace",
+			"parentId":    nil,
+			"name":        name,
+			"description": "Exported from Architect",
+			"scope":       "collection",
+		},
+	}
+
+	environment := map[string]interface{}{"base_url": api.BaseURL}
+	var authentication map[string]interface{}
+	switch api.AuthType {
+	case "basic":
+		environment["username"] = ""
+		environment["password"] = ""
+		authentication = map[string]interface{}{"type": "basic", "username": "{{ _.username }}", "password": "{{ _.password }}"}
+	case "apikey", "api_key":
+		environment["api_key"] = ""
+		authentication = map[string]interface{}{"type": "apikey", "key": "X-API-Key", "value": "{{ _.api_key }}", "addTo": "header"}
+	default:
+		environment["token"] = ""
+		authentication = map[string]interface{}{"type": "bearer", "token": "{{ _.token }}"}
+	}
+	resources = append(resources, map[string]interface{}{
+		"_id":      "env_architect_base",
+		"_type":    "environment",
+		"parentId": workspaceID,
+		"name":     "Base Environment",
+		"data":     environment,
+	})
+
+	groups := make(map[string]string)
+	fake := fakedata.New(1)
+	for idx, endpoint := range api.Endpoints {
+		// Group requests by their first path segment
+		resource := strings.Split(strings.Trim(endpoint.Path, "/"), "/")[0]
+		if resource == "" || strings.HasPrefix(resource, "{") {
+			resource = "root"
+		}
+		groupID, ok := groups[resource]
+		if !ok {
+			groupID = fmt.Sprintf("fld_architect_%d", len(groups)+1)
+			groups[resource] = groupID
+			resources = append(resources, map[string]interface{}{
+				"_id":      groupID,
+				"_type":    "request_group",
+				"parentId": workspaceID,
+				"name":     resource,
+			})
+		}
+
+		// Path parameters become environment variables: /users/{{ _.id }}
+		segments := strings.Split(endpoint.Path, "/")
+		for i, segment := range segments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				segments[i] = "{{ _." + strings.Trim(segment, "{}") + " }}"
+			}
+		}
+
+		name := endpoint.Description
+		if name == "" {
+			name = endpoint.Method + " " + endpoint.Path
+		}
+		request := map[string]interface{}{
+			"_id":            fmt.Sprintf("req_architect_%d", idx+1),
+			"_type":          "request",
+			"parentId":       groupID,
+			"name":           name,
+			"method":         endpoint.Method,
+			"url":            "{{ _.base_url }}" + strings.Join(segments, "/"),
+			"headers":        []map[string]string{},
+			"parameters":     []map[string]interface{}{},
+			"authentication": map[string]interface{}{},
+			"body":           map[string]interface{}{},
+		}
+		if endpoint.Auth {
+			request["authentication"] = authentication
+		}
+
+		if endpoint.Request != nil {
+			var parameters []map[string]interface{}
+			for _, name := range sortedFieldNames(endpoint.Request.Query) {
+				field := models.ParseField(endpoint.Request.Query[name])
+				parameters = append(parameters, map[string]interface{}{
+					"name":     name,
+					"value":    fmt.Sprint(fake.Value(name, field)),
+					"disabled": !field.Required,
+				})
+			}
+			if parameters != nil {
+				request["parameters"] = parameters
+			}
+
+			if len(endpoint.Request.Body) > 0 {
+				bodyJSON, _ := json.MarshalIndent(fake.Body(endpoint.Request.Body), "", "  ")
+				request["body"] = map[string]interface{}{"mimeType": "application/json", "text": string(bodyJSON)}
+				request["headers"] = []map[string]string{{"name": "Content-Type", "value": "application/json"}}
+			}
+		}
+
+		resources = append(resources, request)
+	}
+
+	export := map[string]interface{}{
+		"_type":           "export",
+		"__export_format": 4,
+		"__export_date":   time.Now().UTC().Format(time.RFC3339),
+		"__export_source": "architect",
+		"resources":       resources,
+	}
+
+	data, _ := json.MarshalIndent(export, "", "  ")
+	return string(data)
+}
@@ -0,0 +1,59 @@
+package exporters_test
+
+import (
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/importers"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestInsomniaRoundTrip(t *testing.T) {
+	api := &models.API{
+		BaseURL:  "https://api.example.com/v1",
+		AuthType: "basic",
+		Endpoints: []models.Endpoint{
+			{
+				Path: "/users", Method: "GET", Description: "List users", Auth: true,
+				Request: &models.EndpointRequest{Query: map[string]string{"page": "integer, optional"}},
+			},
+			{
+				Path: "/users", Method: "POST", Description: "Create a user", Auth: true,
+				Request: &models.EndpointRequest{Body: map[string]string{"email": "string, required, email", "age": "integer, optional"}},
+			},
+			{Path: "/users/{id}", Method: "DELETE", Description: "Delete a user", Auth: true},
+			{Path: "/health", Method: "GET", Description: "Health check"},
+		},
+	}
+
+	imported := exportAndImport(t, "insomnia", &importers.InsomniaImporter{}, api)
+	// The importer keeps the base path of the environment URL
+	if imported.BaseURL != "/v1" || imported.AuthType != "basic" {
+		t.Errorf("base_url = %q, auth_type = %q", imported.BaseURL, imported.AuthType)
+	}
+
+	endpoints := endpointsByRoute(imported)
+	if len(endpoints) != len(api.Endpoints) {
+		t.Errorf("imported %d endpoints, want %d: %v", len(endpoints), len(api.Endpoints), endpoints)
+	}
+	for _, want := range api.Endpoints {
+		got, ok := endpoints[want.Method+" "+want.Path]
+		if !ok {
+			t.Errorf("missing %s %s", want.Method, want.Path)
+			continue
+		}
+		if got.Description != want.Description || got.Auth != want.Auth {
+			t.Errorf("%s %s = %q auth %v, want %q auth %v", want.Method, want.Path, got.Description, got.Auth, want.Description, want.Auth)
+		}
+	}
+
+	if list := endpoints["GET /users"]; list.Request == nil || list.Request.Query["page"] == "" {
+		t.Errorf("query parameters lost: %+v", list.Request)
+	}
+	create := endpoints["POST /users"]
+	if create.Request == nil || create.Request.Body["email"] == "" || create.Request.Body["age"] == "" {
+		t.Errorf("body fields lost: %+v", create.Request)
+	}
+	if remove := endpoints["DELETE /users/{id}"]; remove.Request == nil || remove.Request.Params["id"] == "" {
+		t.Errorf("path parameter lost: %+v", remove.Request)
+	}
+}
@@ -0,0 +1,137 @@
+package exporters
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// MarkdownExporter exports the specification as Markdown documentation
+type MarkdownExporter struct{}
+
+// Name returns the format name
+func (e *MarkdownExporter) Name() string { return "markdown" }
+
+// Description returns a one-line description of the format
+func (e *MarkdownExporter) Description() string { return "Markdown API documentation" }
+
+// GetSupportedExtensions returns the file extensions of the exported files
+func (e *MarkdownExporter) GetSupportedExtensions() []string { return []string{".md"} }
+
+// DefaultFilename returns the file written when no output is given
+func (e *MarkdownExporter) DefaultFilename() string { return "API_DOCUMENTATION.md" }
+
+// Options returns the options the exporter accepts
+func (e *MarkdownExporter) Options() []Option {
+	return []Option{
+		{Name: "title", Description: "Title of the document", Default: "API Documentation"},
+	}
+}
+
+// Export converts the API to Markdown documentation
+func (e *MarkdownExporter) Export(api *models.API, options map[string]string) (*Output, error) {
+	return &Output{Content: exportMarkdown(api, options["title"])}, nil
+}
+
+func exportMarkdown(api *models.API, title string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# " + title + "\n\n")
+	sb.WriteString("Base URL: `" + api.BaseURL + "`\n\n")
+
+	if api.AuthType != "none" {
+		sb.WriteString("## Authentication\n")
+		sb.WriteString("This API uses " + api.AuthType + " authentication.\n\n")
+	}
+
+	sb.WriteString("## Endpoints\n\n")
+
+	for _, endpoint := range api.Endpoints {
+		sb.WriteString("### " + endpoint.Method + " " + endpoint.Path + "\n")
+		sb.WriteString(endpoint.Description + "\n\n")
+
+		if endpoint.Auth {
+			sb.WriteString("**Authentication Required**\n\n")
+		}
+
+		if endpoint.Request != nil && endpoint.Request.Body != nil {
+			sb.WriteString("**Request Body:**\n```json\n{\n")
+			for field, def := range endpoint.Request.Body {
+				sb.WriteString(fmt.Sprintf("  \"%s\": \"%s\",\n", field, def))
+			}
+			sb.WriteString("}\n```\n\n")
+		}
+
+		if endpoint.Response != nil && endpoint.Response.Body != nil {
+			sb.WriteString(fmt.Sprintf("**Response (%d):**\n```json\n{\n", endpoint.Response.Status))
+			for field, def := range endpoint.Response.Body {
+				sb.WriteString(fmt.Sprintf("  \"%s\": \"%s\",\n", field, def))
+			}
+			sb.WriteString("}\n```\n\n")
+		}
+
+		if len(endpoint.Errors) > 0 {
+			sb.WriteString("**Errors:**\n")
+			for _, err := range endpoint.Errors {
+				sb.WriteString(fmt.Sprintf("- %d %s: %s\n", err.Status, err.Code, err.Message))
+			}
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString("---\n\n")
+	}
+
+	if len(api.Webhooks) > 0 {
+		sb.WriteString("## Webhooks\n\n")
+		sb.WriteString("Webhooks are delivered as `POST` requests with a JSON body. Return a 2xx status to acknowledge a delivery.\n\n")
+	}
+
+	for _, webhook := range api.Webhooks {
+		sb.WriteString("### " + webhook.Name + "\n")
+		if webhook.Description != "" {
+			sb.WriteString(webhook.Description + "\n")
+		}
+		sb.WriteString("\n")
+
+		if len(webhook.Headers) > 0 {
+			sb.WriteString("**Headers:**\n")
+			for _, name := range sortedFieldNames(webhook.Headers) {
+				sb.WriteString(fmt.Sprintf("- `%s`: %s\n", name, webhook.Headers[name]))
+			}
+			sb.WriteString("\n")
+		}
+
+		if len(webhook.Payload) > 0 {
+			sb.WriteString("**Payload:**\n```json\n{\n")
+			for _, field := range sortedFieldNames(webhook.Payload) {
+				sb.WriteString(fmt.Sprintf("  \"%s\": \"%s\",\n", field, webhook.Payload[field]))
+			}
+			sb.WriteString("}\n```\n\n")
+		}
+
+		if webhook.Signature != nil {
+			sb.WriteString(fmt.Sprintf("**Signature:** `%s` header. %s.\n\n", webhook.Signature.Header, webhookSignatureDescription(webhook.Signature)))
+		}
+
+		if webhook.Retry != nil {
+			sb.WriteString("**Retries:** ")
+			if webhook.Retry.MaxAttempts > 0 {
+				sb.WriteString(fmt.Sprintf("up to %d attempts", webhook.Retry.MaxAttempts))
+			} else {
+				sb.WriteString("retried")
+			}
+			if webhook.Retry.Backoff != "" {
+				sb.WriteString(", " + webhook.Retry.Backoff + " backoff")
+			}
+			if webhook.Retry.Interval != "" {
+				sb.WriteString(" starting at " + webhook.Retry.Interval)
+			}
+			sb.WriteString("\n\n")
+		}
+
+		sb.WriteString("---\n\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,281 @@
+package exporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// OpenAPIExporter exports the specification as an OpenAPI 3.0 document, or
+// OpenAPI 3.1 when it declares webhooks
+type OpenAPIExporter struct{}
+
+// Name returns the format name
+func (e *OpenAPIExporter) Name() string { return "openapi" }
+
+// Description returns a one-line description of the format
+func (e *OpenAPIExporter) Description() string {
+	return "OpenAPI 3.0 document (3.1 with webhooks)"
+}
+
+// GetSupportedExtensions returns the file extensions of the exported files
+func (e *OpenAPIExporter) GetSupportedExtensions() []string { return []string{".json"} }
+
+// DefaultFilename returns the file written when no output is given
+func (e *OpenAPIExporter) DefaultFilename() string { return "openapi.json" }
+
+// Options returns the options the exporter accepts
+func (e *OpenAPIExporter) Options() []Option {
+	return []Option{
+		{Name: "title", Description: "Title of the API", Default: "API Documentation"},
+		{Name: "version", Description: "Version of the API", Default: "1.0.0"},
+	}
+}
+
+// Export converts the API to an OpenAPI document
+func (e *OpenAPIExporter) Export(api *models.API, options map[string]string) (*Output, error) {
+	return &Output{Content: exportOpenAPI(api, options["title"], options["version"])}, nil
+}
+
+func exportOpenAPI(api *models.API, title, version string) string {
+	// Simplified OpenAPI 3.0 export
+	openapi := map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]string{
+			"title":   title,
+			"version": version,
+		},
+		"servers": []map[string]string{
+			{"url": api.BaseURL},
+		},
+		"paths": make(map[string]interface{}),
+	}
+
+	if len(api.Servers) > 0 {
+		servers := []map[string]string{}
+		for _, server := range api.Servers {
+			servers = append(servers, map[string]string{"url": server.URL, "description": server.Name})
+		}
+		openapi["servers"] = servers
+	}
+
+	paths := openapi["paths"].(map[string]interface{})
+
+	for _, endpoint := range api.Endpoints {
+		path := endpoint.Path
+		if _, exists := paths[path]; !exists {
+			paths[path] = make(map[string]interface{})
+		}
+
+		method := strings.ToLower(endpoint.Method)
+		paths[path].(map[string]interface{})[method] = map[string]interface{}{
+			"summary":   endpoint.Description,
+			"security":  []map[string][]string{},
+			"responses": buildResponses(endpoint),
+		}
+
+		if endpoint.Auth {
+			paths[path].(map[string]interface{})[method].(map[string]interface{})["security"] = []map[string][]string{
+				{"bearerAuth": []string{}},
+			}
+		}
+
+		if endpoint.Request != nil && endpoint.Request.Body != nil {
+			paths[path].(map[string]interface{})[method].(map[string]interface{})["requestBody"] = buildRequestBody(endpoint.Request)
+		}
+	}
+
+	// Webhooks need OpenAPI 3.1
+	if len(api.Webhooks) > 0 {
+		openapi["openapi"] = "3.1.0"
+		openapi["webhooks"] = buildWebhooks(api.Webhooks)
+	}
+
+	if api.AuthType == "bearer" {
+		openapi["components"] = map[string]interface{}{
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]string{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+				},
+			},
+		}
+	}
+
+	data, _ := json.MarshalIndent(openapi, "", "  ")
+	return string(data)
+}
+
+// buildWebhooks describes each webhook as the POST request the API sends.
+// Retry semantics have no OpenAPI equivalent and are kept as x-retry.
+func buildWebhooks(webhooks []models.Webhook) map[string]interface{} {
+	result := make(map[string]interface{})
+	for _, webhook := range webhooks {
+		operation := map[string]interface{}{
+			"summary":     webhook.Description,
+			"operationId": webhook.Name,
+			"responses": map[string]interface{}{
+				"200": map[string]string{"description": "Return a 2xx status to acknowledge the delivery"},
+			},
+		}
+
+		parameters := []map[string]interface{}{}
+		for _, name := range sortedFieldNames(webhook.Headers) {
+			field := models.ParseField(webhook.Headers[name])
+			parameters = append(parameters, map[string]interface{}{
+				"name":     name,
+				"in":       "header",
+				"required": field.Required,
+				"schema":   map[string]string{"type": mapType(field.Type)},
+			})
+		}
+		if webhook.Signature != nil {
+			parameters = append(parameters, map[string]interface{}{
+				"name":        webhook.Signature.Header,
+				"in":          "header",
+				"required":    true,
+				"description": webhookSignatureDescription(webhook.Signature),
+				"schema":      map[string]string{"type": "string"},
+			})
+		}
+		if len(parameters) > 0 {
+			operation["parameters"] = parameters
+		}
+
+		if len(webhook.Payload) > 0 {
+			operation["requestBody"] = buildRequestBody(&models.EndpointRequest{Body: webhook.Payload})
+		}
+
+		if webhook.Retry != nil {
+			retry := map[string]interface{}{}
+			if webhook.Retry.MaxAttempts > 0 {
+				retry["maxAttempts"] = webhook.Retry.MaxAttempts
+			}
+			if webhook.Retry.Backoff != "" {
+				retry["backoff"] = webhook.Retry.Backoff
+			}
+			if webhook.Retry.Interval != "" {
+				retry["interval"] = webhook.Retry.Interval
+			}
+			operation["x-retry"] = retry
+		}
+
+		result[webhook.Name] = map[string]interface{}{"post": operation}
+	}
+	return result
+}
+
+// webhookSignatureDescription explains how a delivery is signed
+func webhookSignatureDescription(signature *models.WebhookSignature) string {
+	algorithm := signature.Algorithm
+	if algorithm == "" {
+		algorithm = "hmac-sha256"
+	}
+	description := fmt.Sprintf("Hex-encoded %s of the raw request body, keyed with the shared secret", strings.ToUpper(algorithm))
+	if signature.Prefix != "" {
+		description += fmt.Sprintf(", prefixed with `%s`", signature.Prefix)
+	}
+	return description
+}
+
+func buildResponses(endpoint models.Endpoint) map[string]interface{} {
+	responses := make(map[string]interface{})
+
+	if endpoint.Response != nil {
+		status := fmt.Sprintf("%d", endpoint.Response.Status)
+		responses[status] = map[string]interface{}{
+			"description": "Success",
+		}
+
+		if endpoint.Response.Body != nil {
+			responses[status].(map[string]interface{})["content"] = map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": buildSchema(endpoint.Response.Body),
+				},
+			}
+		}
+
+		if len(endpoint.Response.Headers) > 0 {
+			headers := make(map[string]interface{})
+			for name, def := range endpoint.Response.Headers {
+				headers[name] = map[string]interface{}{
+					"schema": map[string]string{"type": mapType(models.ParseField(def).Type)},
+				}
+			}
+			responses[status].(map[string]interface{})["headers"] = headers
+		}
+	}
+
+	for _, err := range endpoint.Errors {
+		status := fmt.Sprintf("%d", err.Status)
+		responses[status] = map[string]interface{}{
+			"description": err.Message,
+		}
+	}
+
+	return responses
+}
+
+func buildRequestBody(request *models.EndpointRequest) map[string]interface{} {
+	return map[string]interface{}{
+		"required": true,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{
+				"schema": buildSchema(request.Body),
+			},
+		},
+	}
+}
+
+func buildSchema(fields map[string]string) map[string]interface{} {
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": make(map[string]interface{}),
+		"required":   []string{},
+	}
+
+	props := schema["properties"].(map[string]interface{})
+	required := []string{}
+
+	for field, def := range fields {
+		parts := strings.Split(def, ",")
+		fieldType := strings.TrimSpace(parts[0])
+
+		props[field] = map[string]string{
+			"type": mapType(fieldType),
+		}
+
+		for _, part := range parts[1:] {
+			part = strings.TrimSpace(part)
+			if part == "required" {
+				required = append(required, field)
+			}
+		}
+	}
+
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+func mapType(t string) string {
+	switch t {
+	case "uuid", "datetime":
+		return "string"
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		return "array"
+	case "object":
+		return "object"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,177 @@
+package exporters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// PostmanExporter exports the specification as a Postman v2.1 collection,
+// with one Postman environment per server
+type PostmanExporter struct{}
+
+// Name returns the format name
+func (e *PostmanExporter) Name() string { return "postman" }
+
+// Description returns a one-line description of the format
+func (e *PostmanExporter) Description() string {
+	return "Postman v2.1 collection with one environment per server"
+}
+
+// GetSupportedExtensions returns the file extensions of the exported files
+func (e *PostmanExporter) GetSupportedExtensions() []string {
+	return []string{".json", ".postman_environment.json"}
+}
+
+// DefaultFilename returns the file written when no output is given
+func (e *PostmanExporter) DefaultFilename() string { return "postman_collection.json" }
+
+// Options returns the options the exporter accepts
+func (e *PostmanExporter) Options() []Option {
+	return []Option{
+		{Name: "name", Description: "Name of the collection", Default: "API Collection"},
+		{Name: "environments", Description: "Write Postman environments next to the collection (true or false)", Default: "true"},
+	}
+}
+
+// Export converts the API to a Postman collection
+func (e *PostmanExporter) Export(api *models.API, options map[string]string) (*Output, error) {
+	output := &Output{Content: exportPostman(api, options["name"])}
+	switch options["environments"] {
+	case "true":
+		output.Companions = exportPostmanEnvironments(api)
+	case "false":
+	default:
+		return nil, fmt.Errorf("option environments must be true or false")
+	}
+	return output, nil
+}
+
+func exportPostman(api *models.API, name string) string {
+	// Simplified Postman collection export
+	collection := map[string]interface{}{
+		"info": map[string]interface{}{
+			"name":   name,
+			"schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json",
+		},
+		"item": []interface{}{},
+		// The environments exported alongside override this default
+		"variable": []map[string]string{
+			{"key": "baseUrl", "value": postmanBaseURL(api)},
+		},
+	}
+
+	items := []interface{}{}
+
+	for _, endpoint := range api.Endpoints {
+		// Construct proper Postman URL object; path parameters use the
+		// :param syntax so that Postman lists them as path variables
+		segments := strings.Split(strings.Trim(endpoint.Path, "/"), "/")
+		variables := []map[string]string{}
+		for idx, segment := range segments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				name := strings.Trim(segment, "{}")
+				segments[idx] = ":" + name
+				variables = append(variables, map[string]string{"key": name, "value": ""})
+			}
+		}
+		urlObject := map[string]interface{}{
+			"raw":  "{{baseUrl}}/" + strings.Join(segments, "/"),
+			"host": []string{"{{baseUrl}}"},
+			"path": segments,
+		}
+		if len(variables) > 0 {
+			urlObject["variable"] = variables
+		}
+
+		item := map[string]interface{}{
+			"name": endpoint.Description,
+			"request": map[string]interface{}{
+				"method": endpoint.Method,
+				"url":    urlObject,
+				"header": []map[string]string{},
+			},
+		}
+
+		if endpoint.Auth {
+			item["request"].(map[string]interface{})["header"] = append(
+				item["request"].(map[string]interface{})["header"].([]map[string]string),
+				map[string]string{
+					"key":   "Authorization",
+					"value": "Bearer {{token}}",
+				},
+			)
+		}
+
+		if endpoint.Request != nil && endpoint.Request.Body != nil {
+			body := make(map[string]interface{})
+			for field := range endpoint.Request.Body {
+				body[field] = ""
+			}
+
+			bodyJSON, _ := json.Marshal(body)
+			item["request"].(map[string]interface{})["body"] = map[string]interface{}{
+				"mode": "raw",
+				"raw":  string(bodyJSON),
+				"options": map[string]interface{}{
+					"raw": map[string]string{
+						"language": "json",
+					},
+				},
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	collection["item"] = items
+
+	data, _ := json.MarshalIndent(collection, "", "  ")
+	return string(data)
+}
+
+// exportPostmanEnvironments returns one Postman environment per server, or a
+// single environment for the base URL, keyed by file name
+func exportPostmanEnvironments(api *models.API) map[string]string {
+	servers := api.Servers
+	if len(servers) == 0 {
+		servers = []models.Server{{Name: "Architect", URL: api.BaseURL}}
+	}
+
+	environments := make(map[string]string)
+	for _, server := range servers {
+		environment := map[string]interface{}{
+			"name": server.Name,
+			"values": []map[string]interface{}{
+				{"key": "baseUrl", "value": server.URL, "type": "default", "enabled": true},
+				{"key": "token", "value": "", "type": "secret", "enabled": true},
+			},
+			"_postman_variable_scope": "environment",
+		}
+
+		slug := strings.Trim(strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return unicode.ToLower(r)
+			}
+			return '-'
+		}, server.Name), "-")
+		if slug == "" {
+			slug = "environment"
+		}
+
+		data, _ := json.MarshalIndent(environment, "", "  ")
+		environments[slug+".postman_environment.json"] = string(data)
+	}
+	return environments
+}
+
+// postmanBaseURL returns the URL of the first server, or the base URL
+func postmanBaseURL(api *models.API) string {
+	if len(api.Servers) > 0 {
+		return api.Servers[0].URL
+	}
+	return api.BaseURL
+}
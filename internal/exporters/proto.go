@@ -0,0 +1,294 @@
+package exporters
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// ProtoExporter exports the specification as a proto3 file with
+// google.api.http bindings
+type ProtoExporter struct{}
+
+// Name returns the format name
+func (e *ProtoExporter) Name() string { return "proto" }
+
+// Description returns a one-line description of the format
+func (e *ProtoExporter) Description() string {
+	return "Protocol Buffers services with google.api.http bindings"
+}
+
+// GetSupportedExtensions returns the file extensions of the exported files
+func (e *ProtoExporter) GetSupportedExtensions() []string { return []string{".proto"} }
+
+// DefaultFilename returns the file written when no output is given
+func (e *ProtoExporter) DefaultFilename() string { return "api.proto" }
+
+// Options returns the options the exporter accepts
+func (e *ProtoExporter) Options() []Option {
+	return []Option{
+		{Name: "package", Description: "Package of the proto file (default: the package of the rpcs, or api.v1)"},
+	}
+}
+
+// Export converts the API to a proto file
+func (e *ProtoExporter) Export(api *models.API, options map[string]string) (*Output, error) {
+	return &Output{Content: exportProto(api, options["package"])}, nil
+}
+
+// exportProto writes a proto3 file with one rpc per endpoint, annotated with
+// its HTTP binding. Endpoints imported from .proto files keep their package,
+// service and rpc names; the others are named after their resource.
+func exportProto(api *models.API, pkg string) string {
+	if pkg == "" {
+		pkg = "api.v1"
+		for _, endpoint := range api.Endpoints {
+			if service, _, ok := strings.Cut(endpoint.RPC, "/"); ok && strings.Contains(service, ".") {
+				pkg = service[:strings.LastIndex(service, ".")]
+				break
+			}
+		}
+	}
+
+	basePath := api.BaseURL
+	if parsed, err := url.Parse(basePath); err == nil {
+		basePath = parsed.Path
+	}
+	basePath = strings.TrimRight(basePath, "/")
+
+	imports := make(map[string]bool)
+	services := make(map[string]*strings.Builder)
+	var serviceOrder []string
+	var messages strings.Builder
+	used := make(map[string]bool)
+
+	for _, endpoint := range api.Endpoints {
+		service, method := protoNames(endpoint)
+		for name, n := method, 2; used[name]; n++ {
+			method = fmt.Sprintf("%s%d", name, n)
+		}
+		used[method] = true
+
+		if services[service] == nil {
+			services[service] = &strings.Builder{}
+			serviceOrder = append(serviceOrder, service)
+		}
+		sb := services[service]
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		if endpoint.Description != method {
+			for _, line := range strings.Split(endpoint.Description, "\n") {
+				if line != "" {
+					sb.WriteString("  // " + line + "\n")
+				}
+			}
+		}
+
+		// With query parameters next to the body, the body moves to a nested
+		// message so that the query parameters stay out of it
+		hasBody := endpoint.Request != nil && len(endpoint.Request.Body) > 0
+		nestedBody := hasBody && len(endpoint.Request.Query) > 0
+		sb.WriteString(fmt.Sprintf("  rpc %s(%sRequest) returns (%sResponse)", method, method, method))
+
+		// Endpoints on the gRPC path need no HTTP binding
+		if endpoint.RPC != "" && endpoint.Path == "/"+endpoint.RPC {
+			sb.WriteString(";\n")
+		} else {
+			imports["google/api/annotations.proto"] = true
+			path := basePath + endpoint.Path
+			sb.WriteString(" {\n    option (google.api.http) = {\n")
+			switch endpoint.Method {
+			case "GET", "POST", "PUT", "PATCH", "DELETE":
+				sb.WriteString(fmt.Sprintf("      %s: %q\n", strings.ToLower(endpoint.Method), path))
+			default:
+				sb.WriteString(fmt.Sprintf("      custom: { kind: %q path: %q }\n", endpoint.Method, path))
+			}
+			switch {
+			case nestedBody:
+				sb.WriteString("      body: \"body\"\n")
+			case hasBody:
+				sb.WriteString("      body: \"*\"\n")
+			}
+			sb.WriteString("    };\n  }\n")
+		}
+
+		// Request messages hold the path, query and body fields
+		request := make(map[string]string)
+		if endpoint.Request != nil {
+			fieldSets := []map[string]string{endpoint.Request.Query, endpoint.Request.Params}
+			if nestedBody {
+				request["body"] = method + "Body, required"
+			} else {
+				fieldSets = append(fieldSets, endpoint.Request.Body)
+			}
+			for _, fields := range fieldSets {
+				for name, def := range fields {
+					request[name] = def
+				}
+			}
+			for name, def := range endpoint.Request.Params {
+				if !models.ParseField(def).Required {
+					request[name] = def + ", required"
+				}
+			}
+		}
+		var response map[string]string
+		if endpoint.Response != nil {
+			response = endpoint.Response.Body
+		}
+		messages.WriteString(protoMessage(method+"Request", request, imports))
+		if nestedBody {
+			messages.WriteString(protoMessage(method+"Body", endpoint.Request.Body, imports))
+		}
+		messages.WriteString(protoMessage(method+"Response", response, imports))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("syntax = \"proto3\";\n\npackage " + pkg + ";\n")
+	if len(imports) > 0 {
+		sb.WriteString("\n")
+		var names []string
+		for name := range imports {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sb.WriteString("import \"" + name + "\";\n")
+		}
+	}
+	for _, service := range serviceOrder {
+		sb.WriteString("\nservice " + service + " {\n" + services[service].String() + "}\n")
+	}
+	sb.WriteString(messages.String())
+
+	return sb.String()
+}
+
+// protoNames returns the service and rpc names of an endpoint: those of its
+// rpc, or names derived from the method and path such as UserService and
+// GetUser
+func protoNames(endpoint models.Endpoint) (string, string) {
+	if service, method, ok := strings.Cut(endpoint.RPC, "/"); ok {
+		return service[strings.LastIndex(service, ".")+1:], method
+	}
+
+	var resources []string
+	for _, segment := range strings.Split(strings.Trim(endpoint.Path, "/"), "/") {
+		if segment != "" && !strings.HasPrefix(segment, "{") {
+			resources = append(resources, segment)
+		}
+	}
+	if len(resources) == 0 {
+		resources = []string{"root"}
+	}
+
+	item := strings.HasSuffix(endpoint.Path, "}")
+	verb := map[string]string{"GET": "Get", "POST": "Create", "PUT": "Update", "PATCH": "Update", "DELETE": "Delete"}[endpoint.Method]
+	if verb == "" {
+		verb = protoIdentifier(strings.ToLower(endpoint.Method))
+	}
+	if endpoint.Method == "GET" && !item {
+		verb = "List"
+	}
+
+	name := verb
+	for idx, resource := range resources {
+		// Single items use the singular resource name
+		if idx == len(resources)-1 && (item || endpoint.Method != "GET") {
+			resource = singular(resource)
+		}
+		name += protoIdentifier(resource)
+	}
+	return protoIdentifier(singular(resources[0])) + "Service", name
+}
+
+// protoMessage renders a message with one field per api.yaml field
+func protoMessage(name string, fields map[string]string, imports map[string]bool) string {
+	if len(fields) == 0 {
+		return "\nmessage " + name + " {}\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\nmessage " + name + " {\n")
+	for idx, field := range sortedFieldNames(fields) {
+		parsed := models.ParseField(fields[field])
+		typ := "string"
+		switch parsed.Type {
+		case "integer":
+			typ = "int64"
+		case "number":
+			typ = "double"
+		case "boolean":
+			typ = "bool"
+		case "datetime":
+			typ = "google.protobuf.Timestamp"
+			imports["google/protobuf/timestamp.proto"] = true
+		case "array":
+			typ = "repeated string"
+		case "object":
+			typ = "google.protobuf.Struct"
+			imports["google/protobuf/struct.proto"] = true
+		default:
+			// The nested body message of a request
+			if strings.HasSuffix(parsed.Type, "Body") {
+				typ = parsed.Type
+			}
+		}
+
+		options := ""
+		if parsed.Required {
+			options = " [(google.api.field_behavior) = REQUIRED]"
+			imports["google/api/field_behavior.proto"] = true
+		}
+
+		fieldName := strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+				return r
+			}
+			return '_'
+		}, field)
+		if fieldName == "" || unicode.IsDigit(rune(fieldName[0])) {
+			fieldName = "f_" + fieldName
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s = %d%s;\n", typ, fieldName, idx+1, options))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// protoIdentifier converts a path segment such as user-profiles to
+// UserProfiles
+func protoIdentifier(segment string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range segment {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// singular turns a plural resource name into its singular form
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "ss"):
+		return name
+	case strings.HasSuffix(name, "s") && len(name) > 3:
+		return name[:len(name)-1]
+	}
+	return name
+}
@@ -0,0 +1,66 @@
+package exporters_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/exporters"
+	"github.com/faisalahmedsifat/architect/internal/importers"
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/specdiff"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	api, err := (&importers.ProtoImporter{}).Import("../importers/testdata/users.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	imported := exportAndImport(t, "proto", &importers.ProtoImporter{}, api)
+	diff := specdiff.Compare(api, imported)
+	for _, endpoint := range diff.Added {
+		t.Errorf("added %s", specdiff.EndpointKey(endpoint))
+	}
+	for _, endpoint := range diff.Removed {
+		t.Errorf("removed %s", specdiff.EndpointKey(endpoint))
+	}
+	for _, changed := range diff.Changed {
+		for _, change := range changed.Changes {
+			t.Errorf("%s %s: %s", changed.Method, changed.Path, change)
+		}
+	}
+}
+
+func TestProtoExportNamesEndpoints(t *testing.T) {
+	api := &models.API{
+		BaseURL: "https://api.example.com/api/v1",
+		Endpoints: []models.Endpoint{
+			{Path: "/users", Method: "GET", Description: "List users", Request: &models.EndpointRequest{Query: map[string]string{"page": "integer, optional"}}},
+			{Path: "/users/{id}", Method: "GET"},
+			{Path: "/users/{id}", Method: "PUT", Request: &models.EndpointRequest{
+				Params: map[string]string{"id": "string"},
+				Query:  map[string]string{"notify": "boolean, optional"},
+				Body:   map[string]string{"email": "string, required", "tags": "array, optional"},
+			}},
+		},
+	}
+	output, err := (&exporters.ProtoExporter{}).Export(api, map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"package api.v1;",
+		"service UserService {",
+		"  // List users\n  rpc ListUsers(ListUsersRequest) returns (ListUsersResponse)",
+		`get: "/api/v1/users/{id}"`,
+		"rpc UpdateUser(UpdateUserRequest)",
+		`body: "body"`,
+		"  UpdateUserBody body = ",
+		"  string id = 2 [(google.api.field_behavior) = REQUIRED];",
+		"  repeated string tags = 2;",
+	} {
+		if !strings.Contains(output.Content, want) {
+			t.Errorf("export lacks %q:\n%s", want, output.Content)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package exporters_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/importers"
+	"github.com/faisalahmedsifat/architect/internal/parser"
+	"github.com/faisalahmedsifat/architect/internal/specdiff"
+)
+
+// TestRoundTrip exports the fixture and imports the result back. Every
+// endpoint must survive, along with the fields the format can carry.
+func TestRoundTrip(t *testing.T) {
+	api, err := parser.ParseAPIYAML("testdata/shop.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		format   string
+		importer importers.Importer
+		// fields are the prefixes of the endpoint fields kept by the format
+		fields []string
+		// types reports whether field types are kept, not just names
+		types bool
+	}{
+		{"openapi", &importers.OpenAPIImporter{}, []string{"description", "auth", "request.body", "response.status", "response.body"}, true},
+		{"postman", &importers.PostmanImporter{}, []string{"description", "auth", "request.body"}, false},
+		{"insomnia", &importers.InsomniaImporter{}, []string{"description", "auth", "request.query", "request.body"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			imported := exportAndImport(t, tt.format, tt.importer, api)
+
+			diff := specdiff.Compare(api, imported)
+			for _, endpoint := range diff.Added {
+				t.Errorf("added %s", specdiff.EndpointKey(endpoint))
+			}
+			for _, endpoint := range diff.Removed {
+				t.Errorf("removed %s", specdiff.EndpointKey(endpoint))
+			}
+
+			for _, changed := range diff.Changed {
+				for _, change := range changed.Changes {
+					if !kept(tt.fields, change.Field) {
+						continue
+					}
+					if change.Kind() != specdiff.Changed {
+						t.Errorf("%s %s: %s", changed.Method, changed.Path, change)
+					} else if tt.types && fieldType(change.Before) != fieldType(change.After) {
+						t.Errorf("%s %s: %s", changed.Method, changed.Path, change)
+					}
+				}
+			}
+		})
+	}
+}
+
+func kept(prefixes []string, field string) bool {
+	for _, prefix := range prefixes {
+		if field == prefix || strings.HasPrefix(field, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldType is the type of a field definition such as "string, optional"
+func fieldType(definition string) string {
+	return strings.TrimSpace(strings.Split(definition, ",")[0])
+}
@@ -3,6 +3,7 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
@@ -62,13 +63,28 @@ Base URL: ` + "`" + `{{ .BaseURL }}` + "`" + `
 Check ` + "`" + `.architect/api.yaml` + "`" + ` for complete specifications.
 
 {{ .EndpointsList }}
+{{ if .GraphQLOperations }}
+### GraphQL Operations
+Served at ` + "`" + `{{ .GraphQLPath }}` + "`" + `. Check the ` + "`" + `graphql` + "`" + ` section of ` + "`" + `.architect/api.yaml` + "`" + ` for input and output types.
 
+{{ .GraphQLOperations }}
+{{ end }}
 ## Request/Response Formats
 
 ### IMPORTANT: Follow exact schema from ` + "`" + `.architect/api.yaml` + "`" + `
 
 {{ .EndpointExamples }}
+{{ if .Events }}
+## Events
+Event payloads are part of the contract. Check the ` + "`" + `channels` + "`" + ` section of ` + "`" + `.architect/api.yaml` + "`" + ` and emit exactly these fields.
 
+{{ .Events }}
+{{ end }}{{ if .Webhooks }}
+## Webhooks
+Outbound webhooks are part of the contract. Check the ` + "`" + `webhooks` + "`" + ` section of ` + "`" + `.architect/api.yaml` + "`" + ` and send exactly these payloads, headers and signatures.
+
+{{ .Webhooks }}
+{{ end }}
 ## Business Logic Implementation
 
 ### CRITICAL: Read ` + "`" + `.architect/project.md` + "`" + ` for all business rules
@@ -165,6 +181,12 @@ func (g *Generator) prepareTemplateData() map[string]interface{} {
 	// Endpoints list
 	data["EndpointsList"] = g.generateEndpointsList()
 	data["EndpointExamples"] = g.generateEndpointExamples()
+	data["GraphQLOperations"] = g.generateGraphQLOperations()
+	data["Events"] = g.generateEvents()
+	data["Webhooks"] = g.generateWebhooks()
+	if g.API.GraphQL != nil {
+		data["GraphQLPath"] = g.API.GraphQL.Path
+	}
 	data["BusinessLogicSummary"] = g.generateBusinessLogicSummary()
 
 	// Sample endpoint
@@ -187,6 +209,9 @@ func (g *Generator) generateEndpointsList() string {
 
 	for _, ep := range g.API.Endpoints {
 		line := fmt.Sprintf("- `%s %s` - %s", ep.Method, ep.Path, ep.Description)
+		if ep.RPC != "" {
+			line += fmt.Sprintf(" (gRPC `%s`)", ep.RPC)
+		}
 		if ep.Auth {
 			authEndpoints = append(authEndpoints, line)
 		} else {
@@ -209,6 +234,80 @@ func (g *Generator) generateEndpointsList() string {
 	return result.String()
 }
 
+func (g *Generator) generateGraphQLOperations() string {
+	if g.API.GraphQL == nil || len(g.API.GraphQL.Operations) == 0 {
+		return ""
+	}
+
+	var result strings.Builder
+	headings := map[string]string{"query": "Queries", "mutation": "Mutations", "subscription": "Subscriptions"}
+	for _, operationType := range []string{"query", "mutation", "subscription"} {
+		var lines []string
+		for _, op := range g.API.GraphQL.Operations {
+			if op.Type != operationType {
+				continue
+			}
+			var args []string
+			for name, def := range op.Input {
+				args = append(args, name+": "+def)
+			}
+			sort.Strings(args)
+			signature := op.Name
+			if len(args) > 0 {
+				signature += "(" + strings.Join(args, ", ") + ")"
+			}
+			line := fmt.Sprintf("- `%s: %s`", signature, op.Output)
+			if op.Description != "" {
+				line += " - " + strings.Split(op.Description, "\n")[0]
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) > 0 {
+			result.WriteString(fmt.Sprintf("#### %s:\n%s\n\n", headings[operationType], strings.Join(lines, "\n")))
+		}
+	}
+
+	return strings.TrimRight(result.String(), "\n")
+}
+
+func (g *Generator) generateEvents() string {
+	if len(g.API.Channels) == 0 {
+		return ""
+	}
+
+	var result strings.Builder
+	headings := map[string]string{"producer": "Published Events", "consumer": "Consumed Events"}
+	for _, role := range []string{"producer", "consumer"} {
+		var lines []string
+		for _, channel := range g.API.Channels {
+			if channel.Role != role {
+				continue
+			}
+			line := fmt.Sprintf("- `%s`", channel.Name)
+			if channel.Message != "" {
+				line += fmt.Sprintf(" (%s)", channel.Message)
+			}
+			if channel.Description != "" {
+				line += " - " + strings.Split(channel.Description, "\n")[0]
+			}
+			var fields []string
+			for field := range channel.Payload {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				line += fmt.Sprintf("\n  - `%s`: %s", field, channel.Payload[field])
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) > 0 {
+			result.WriteString(fmt.Sprintf("#### %s:\n%s\n\n", headings[role], strings.Join(lines, "\n")))
+		}
+	}
+
+	return strings.TrimRight(result.String(), "\n")
+}
+
 func (g *Generator) generateEndpointExamples() string {
 	if len(g.API.Endpoints) == 0 {
 		return "No endpoint examples available."
@@ -270,3 +369,32 @@ func (g *Generator) generateBusinessLogicSummary() string {
 	}
 	return "See .architect/project.md for detailed business logic."
 }
+
+func (g *Generator) generateWebhooks() string {
+	var lines []string
+	for _, webhook := range g.API.Webhooks {
+		line := fmt.Sprintf("- `%s`", webhook.Name)
+		if webhook.Description != "" {
+			line += " - " + strings.Split(webhook.Description, "\n")[0]
+		}
+		var fields []string
+		for field := range webhook.Payload {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			line += fmt.Sprintf("\n  - `%s`: %s", field, webhook.Payload[field])
+		}
+		if webhook.Signature != nil {
+			line += fmt.Sprintf("\n  - Signed with %s in the `%s` header", webhook.Signature.Algorithm, webhook.Signature.Header)
+		}
+		if webhook.Retry != nil && webhook.Retry.MaxAttempts > 0 {
+			line += fmt.Sprintf("\n  - Retried up to %d times", webhook.Retry.MaxAttempts)
+			if webhook.Retry.Backoff != "" {
+				line += " with " + webhook.Retry.Backoff + " backoff"
+			}
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
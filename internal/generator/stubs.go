@@ -0,0 +1,898 @@
+package generator
+
+import (
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// Stub is a generated source file containing handler stubs
+type Stub struct {
+	Filename string
+	Content  string
+}
+
+// stubTemplate renders the stubs for all endpoints of one resource
+type stubTemplate func(resource string, endpoints []models.Endpoint) []Stub
+
+// stubTemplates maps normalized backend names to their stub template
+var stubTemplates = map[string]stubTemplate{
+	"fastapi": fastAPIStubs,
+	"flask":   flaskStubs,
+	"django":  djangoStubs,
+	"express": expressStubs,
+	"spring":  springStubs,
+	"rails":   railsStubs,
+	"go":      goStubs,
+	"chi":     chiStubs,
+	"gin":     ginStubs,
+}
+
+// stubSupport maps backends to a support file shared by their stubs
+var stubSupport = map[string]func() Stub{
+	"go":  goStubSupport,
+	"chi": goStubSupport,
+	"gin": ginStubSupport,
+}
+
+// stubBackendAliases maps backend names, lowercased with spaces and
+// punctuation removed, onto stub template names. Names are matched exactly:
+// "Node" or "Koa" are not Express, and get no stubs.
+var stubBackendAliases = map[string]string{
+	"fastapi":     "fastapi",
+	"flask":       "flask",
+	"django":      "django",
+	"express":     "express",
+	"expressjs":   "express",
+	"spring":      "spring",
+	"springboot":  "spring",
+	"rails":       "rails",
+	"rubyonrails": "rails",
+	"go":          "go",
+	"golang":      "go",
+	"nethttp":     "go",
+	"gonethttp":   "go",
+	"chi":         "chi",
+	"gochi":       "chi",
+	"gin":         "gin",
+	"gingonic":    "gin",
+}
+
+var backendPunctuation = regexp.MustCompile(`[^a-z0-9]+`)
+
+// stubBackend maps a TechStack.Backend value onto a stub template name
+func stubBackend(backend string) string {
+	return stubBackendAliases[backendPunctuation.ReplaceAllString(strings.ToLower(backend), "")]
+}
+
+// GenerateHandlerStubs generates framework-appropriate handler stubs for the
+// given endpoints, grouped into one file per resource
+func GenerateHandlerStubs(backend, baseURL string, endpoints []models.Endpoint) ([]Stub, error) {
+	template, ok := stubTemplates[stubBackend(backend)]
+	if !ok {
+		return nil, fmt.Errorf("no handler stub template for backend %q (supported: FastAPI, Flask, Django, Express, Spring Boot, Rails, Go net/http, chi, gin)", backend)
+	}
+
+	groups := make(map[string][]models.Endpoint)
+	for _, endpoint := range endpoints {
+		resource := resourceName(baseURL, endpoint.Path)
+		groups[resource] = append(groups[resource], endpoint)
+	}
+
+	resources := make([]string, 0, len(groups))
+	for resource := range groups {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	var stubs []Stub
+	for _, resource := range resources {
+		stubs = append(stubs, template(resource, groups[resource])...)
+	}
+	if support, ok := stubSupport[stubBackend(backend)]; ok {
+		stubs = append(stubs, support())
+	}
+	return stubs, nil
+}
+
+var versionSegment = regexp.MustCompile(`^v\d+$`)
+
+// resourceName returns the first static path segment after the base URL
+func resourceName(baseURL, path string) string {
+	trimmed := strings.Trim(path, "/")
+	if base := strings.Trim(baseURL, "/"); base != "" && strings.HasPrefix(trimmed+"/", base+"/") {
+		trimmed = strings.Trim(strings.TrimPrefix(trimmed, base), "/")
+	}
+	for _, segment := range strings.Split(trimmed, "/") {
+		if segment == "" || segment == "api" || versionSegment.MatchString(segment) || isParamSegment(segment) {
+			continue
+		}
+		return snakeCase(segment)
+	}
+	return "root"
+}
+
+func isParamSegment(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// pathParams returns the parameter names used in a {param} style path
+func pathParams(path string) []string {
+	var params []string
+	for _, segment := range strings.Split(path, "/") {
+		if isParamSegment(segment) {
+			params = append(params, strings.Trim(segment, "{}"))
+		}
+	}
+	return params
+}
+
+// convertPathParams rewrites {param} segments using the given format
+func convertPathParams(path, format string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if isParamSegment(segment) {
+			segments[i] = fmt.Sprintf(format, strings.Trim(segment, "{}"))
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// operationName derives a camelCase handler name such as listUsers,
+// getUsers, createUsers or deleteUsersPosts
+func operationName(endpoint models.Endpoint) string {
+	segments := strings.Split(strings.Trim(endpoint.Path, "/"), "/")
+	var words []string
+	for _, segment := range segments {
+		if segment == "" || segment == "api" || versionSegment.MatchString(segment) || isParamSegment(segment) {
+			continue
+		}
+		words = append(words, pascalCase(segment))
+	}
+	if len(words) == 0 {
+		words = []string{"Root"}
+	}
+
+	endsWithParam := len(segments) > 0 && isParamSegment(segments[len(segments)-1])
+	verb := strings.ToLower(endpoint.Method)
+	switch endpoint.Method {
+	case "GET":
+		verb = "list"
+		if endsWithParam {
+			verb = "get"
+		}
+	case "POST":
+		verb = "create"
+	case "PUT":
+		verb = "replace"
+	case "PATCH":
+		verb = "update"
+	}
+
+	return verb + strings.Join(words, "")
+}
+
+// operationNames returns a handler name for each endpoint of a resource.
+// Endpoints sharing a name, such as PUT /users and PUT /users/{id}, are told
+// apart by their last path parameter, then by a number.
+func operationNames(endpoints []models.Endpoint) []string {
+	names := make([]string, len(endpoints))
+	count := make(map[string]int)
+	for idx, endpoint := range endpoints {
+		names[idx] = operationName(endpoint)
+		count[names[idx]]++
+	}
+
+	for idx, endpoint := range endpoints {
+		if count[names[idx]] < 2 {
+			continue
+		}
+		segments := strings.Split(strings.Trim(endpoint.Path, "/"), "/")
+		if last := segments[len(segments)-1]; isParamSegment(last) {
+			names[idx] += "By" + pascalCase(strings.Trim(last, "{}"))
+		}
+	}
+
+	seen := make(map[string]int)
+	for idx, name := range names {
+		seen[name]++
+		if seen[name] > 1 {
+			names[idx] = fmt.Sprintf("%s%d", name, seen[name])
+		}
+	}
+	return names
+}
+
+var wordSplitter = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+func pascalCase(s string) string {
+	var sb strings.Builder
+	for _, word := range wordSplitter.Split(s, -1) {
+		if word == "" {
+			continue
+		}
+		if strings.EqualFold(word, "id") {
+			sb.WriteString("ID")
+			continue
+		}
+		sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return sb.String()
+}
+
+var camelBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+func snakeCase(s string) string {
+	s = camelBoundary.ReplaceAllString(s, "${1}_${2}")
+	return strings.Trim(strings.ToLower(wordSplitter.ReplaceAllString(s, "_")), "_")
+}
+
+// stubField is a request/response field prepared for rendering
+type stubField struct {
+	Name  string
+	Field models.Field
+}
+
+func sortedFields(fields map[string]string) []stubField {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]stubField, 0, len(names))
+	for _, name := range names {
+		result = append(result, stubField{Name: name, Field: models.ParseField(fields[name])})
+	}
+	return result
+}
+
+func requestBody(endpoint models.Endpoint) map[string]string {
+	if endpoint.Request == nil {
+		return nil
+	}
+	return endpoint.Request.Body
+}
+
+func requestQuery(endpoint models.Endpoint) map[string]string {
+	if endpoint.Request == nil {
+		return nil
+	}
+	return endpoint.Request.Query
+}
+
+func responseBody(endpoint models.Endpoint) map[string]string {
+	if endpoint.Response == nil {
+		return nil
+	}
+	return endpoint.Response.Body
+}
+
+func responseStatus(endpoint models.Endpoint) int {
+	if endpoint.Response != nil && endpoint.Response.Status != 0 {
+		return endpoint.Response.Status
+	}
+	if endpoint.Method == "POST" {
+		return 201
+	}
+	return 200
+}
+
+func stubHeader(comment, resource string) string {
+	return fmt.Sprintf("%s Handler stubs for the %s resource, generated by `architect validate --fix`.\n%s Fill in the business logic described in .architect/project.md.\n", comment, resource, comment)
+}
+
+// commentText keeps a description on a single comment line
+func commentText(description string) string {
+	return strings.Join(strings.Fields(description), " ")
+}
+
+// pythonDocstring quotes a description as a docstring, escaping quotes and
+// backslashes so it cannot end the string early
+func pythonDocstring(description string) string {
+	escaped := strings.ReplaceAll(description, `\`, `\\`)
+	return `"""` + strings.ReplaceAll(escaped, `"`, `\"`) + `"""`
+}
+
+// FastAPI
+
+func pythonType(field models.Field) string {
+	switch field.Type {
+	case "integer":
+		return "int"
+	case "number":
+		return "float"
+	case "boolean":
+		return "bool"
+	case "uuid":
+		return "UUID"
+	case "datetime":
+		return "datetime"
+	case "array":
+		return "list[Any]"
+	case "object":
+		return "dict[str, Any]"
+	default:
+		return "str"
+	}
+}
+
+func pydanticModel(sb *strings.Builder, name string, fields map[string]string) {
+	sb.WriteString(fmt.Sprintf("\n\nclass %s(BaseModel):\n", name))
+	for _, f := range sortedFields(fields) {
+		if f.Field.Required {
+			sb.WriteString(fmt.Sprintf("    %s: %s\n", f.Name, pythonType(f.Field)))
+		} else {
+			sb.WriteString(fmt.Sprintf("    %s: Optional[%s] = None\n", f.Name, pythonType(f.Field)))
+		}
+	}
+}
+
+func fastAPIStubs(resource string, endpoints []models.Endpoint) []Stub {
+	names := operationNames(endpoints)
+	var sb strings.Builder
+	sb.WriteString(stubHeader("#", resource))
+	sb.WriteString("from datetime import datetime\nfrom typing import Any, Optional\nfrom uuid import UUID\n\n")
+	sb.WriteString("from fastapi import APIRouter, HTTPException\nfrom pydantic import BaseModel\n\nrouter = APIRouter()\n")
+
+	for idx, endpoint := range endpoints {
+		name := pascalCase(names[idx])
+		if body := requestBody(endpoint); len(body) > 0 {
+			pydanticModel(&sb, name+"Request", body)
+		}
+		if body := responseBody(endpoint); len(body) > 0 {
+			pydanticModel(&sb, name+"Response", body)
+		}
+	}
+
+	for idx, endpoint := range endpoints {
+		name := pascalCase(names[idx])
+		var args []string
+		for _, param := range pathParams(endpoint.Path) {
+			args = append(args, param+": str")
+		}
+		if len(requestBody(endpoint)) > 0 {
+			args = append(args, "body: "+name+"Request")
+		}
+		for _, f := range sortedFields(requestQuery(endpoint)) {
+			args = append(args, fmt.Sprintf("%s: Optional[%s] = None", f.Name, pythonType(f.Field)))
+		}
+
+		decorator := fmt.Sprintf("@router.%s(%q", strings.ToLower(endpoint.Method), endpoint.Path)
+		if len(responseBody(endpoint)) > 0 {
+			decorator += ", response_model=" + name + "Response"
+		}
+		decorator += fmt.Sprintf(", status_code=%d)", responseStatus(endpoint))
+
+		sb.WriteString("\n\n" + decorator + "\n")
+		sb.WriteString(fmt.Sprintf("async def %s(%s):\n", snakeCase(names[idx]), strings.Join(args, ", ")))
+		sb.WriteString("    " + pythonDocstring(endpoint.Description) + "\n")
+		if endpoint.Auth {
+			sb.WriteString("    # TODO: require authentication, e.g. Depends(get_current_user)\n")
+		}
+		sb.WriteString("    raise HTTPException(status_code=501, detail=\"Not implemented\")\n")
+	}
+
+	return []Stub{{Filename: resource + ".py", Content: sb.String()}}
+}
+
+// Flask
+
+func flaskStubs(resource string, endpoints []models.Endpoint) []Stub {
+	names := operationNames(endpoints)
+	var sb strings.Builder
+	sb.WriteString(stubHeader("#", resource))
+	sb.WriteString("from flask import Blueprint, jsonify, request\n\n")
+	sb.WriteString(fmt.Sprintf("bp = Blueprint(%q, __name__)\n", resource))
+
+	for idx, endpoint := range endpoints {
+		params := pathParams(endpoint.Path)
+		sb.WriteString(fmt.Sprintf("\n\n@bp.route(%q, methods=[%q])\n", convertPathParams(endpoint.Path, "<%s>"), endpoint.Method))
+		sb.WriteString(fmt.Sprintf("def %s(%s):\n", snakeCase(names[idx]), strings.Join(params, ", ")))
+		sb.WriteString("    " + pythonDocstring(endpoint.Description) + "\n")
+		writePythonFieldComments(&sb, endpoint)
+		if len(requestBody(endpoint)) > 0 {
+			sb.WriteString("    body = request.get_json()\n")
+		}
+		sb.WriteString("    return jsonify({\"error\": {\"code\": \"NOT_IMPLEMENTED\", \"message\": \"Not implemented\"}}), 501\n")
+	}
+
+	return []Stub{{Filename: resource + ".py", Content: sb.String()}}
+}
+
+func writePythonFieldComments(sb *strings.Builder, endpoint models.Endpoint) {
+	if endpoint.Auth {
+		sb.WriteString("    # TODO: require authentication\n")
+	}
+	for _, f := range sortedFields(requestBody(endpoint)) {
+		sb.WriteString(fmt.Sprintf("    # request.%s: %s\n", f.Name, requestBody(endpoint)[f.Name]))
+	}
+	if body := responseBody(endpoint); len(body) > 0 {
+		sb.WriteString(fmt.Sprintf("    # response (%d):\n", responseStatus(endpoint)))
+		for _, f := range sortedFields(body) {
+			sb.WriteString(fmt.Sprintf("    #   %s: %s\n", f.Name, body[f.Name]))
+		}
+	}
+}
+
+// Django
+
+func djangoStubs(resource string, endpoints []models.Endpoint) []Stub {
+	names := operationNames(endpoints)
+	var sb strings.Builder
+	sb.WriteString(stubHeader("#", resource))
+	sb.WriteString("import json\n\nfrom django.http import JsonResponse\nfrom django.urls import path\nfrom django.views.decorators.http import require_http_methods\n")
+
+	var patterns []string
+	for idx, endpoint := range endpoints {
+		name := snakeCase(names[idx])
+		params := append([]string{"request"}, pathParams(endpoint.Path)...)
+
+		sb.WriteString(fmt.Sprintf("\n\n@require_http_methods([%q])\n", endpoint.Method))
+		sb.WriteString(fmt.Sprintf("def %s(%s):\n", name, strings.Join(params, ", ")))
+		sb.WriteString("    " + pythonDocstring(endpoint.Description) + "\n")
+		writePythonFieldComments(&sb, endpoint)
+		if len(requestBody(endpoint)) > 0 {
+			sb.WriteString("    body = json.loads(request.body)\n")
+		}
+		sb.WriteString("    return JsonResponse({\"error\": {\"code\": \"NOT_IMPLEMENTED\", \"message\": \"Not implemented\"}}, status=501)\n")
+
+		route := strings.TrimPrefix(convertPathParams(endpoint.Path, "<str:%s>"), "/")
+		patterns = append(patterns, fmt.Sprintf("    path(%q, %s),", route, name))
+	}
+
+	sb.WriteString("\n\nurlpatterns = [\n" + strings.Join(patterns, "\n") + "\n]\n")
+
+	return []Stub{{Filename: resource + "_views.py", Content: sb.String()}}
+}
+
+// Express
+
+func jsDocType(field models.Field) string {
+	switch field.Type {
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "array":
+		return "Array<*>"
+	case "object":
+		return "Object"
+	default:
+		return "string"
+	}
+}
+
+func jsDocTypedef(sb *strings.Builder, name string, fields map[string]string) {
+	sb.WriteString(fmt.Sprintf("\n/**\n * @typedef {Object} %s\n", name))
+	for _, f := range sortedFields(fields) {
+		fieldName := f.Name
+		if !f.Field.Required {
+			fieldName = "[" + fieldName + "]"
+		}
+		sb.WriteString(fmt.Sprintf(" * @property {%s} %s\n", jsDocType(f.Field), fieldName))
+	}
+	sb.WriteString(" */\n")
+}
+
+func expressStubs(resource string, endpoints []models.Endpoint) []Stub {
+	names := operationNames(endpoints)
+	var sb strings.Builder
+	sb.WriteString(stubHeader("//", resource))
+	sb.WriteString("const express = require('express');\n\nconst router = express.Router();\n")
+
+	for idx, endpoint := range endpoints {
+		name := pascalCase(names[idx])
+		if body := requestBody(endpoint); len(body) > 0 {
+			jsDocTypedef(&sb, name+"Request", body)
+		}
+		if body := responseBody(endpoint); len(body) > 0 {
+			jsDocTypedef(&sb, name+"Response", body)
+		}
+
+		sb.WriteString(fmt.Sprintf("\n// %s\n", commentText(endpoint.Description)))
+		if endpoint.Auth {
+			sb.WriteString("// TODO: require authentication middleware\n")
+		}
+		sb.WriteString(fmt.Sprintf("router.%s('%s', async (req, res) => {\n", strings.ToLower(endpoint.Method), convertPathParams(endpoint.Path, ":%s")))
+		if len(requestBody(endpoint)) > 0 {
+			sb.WriteString(fmt.Sprintf("  /** @type {%sRequest} */\n  const body = req.body;\n", name))
+		}
+		if len(responseBody(endpoint)) > 0 {
+			sb.WriteString(fmt.Sprintf("  // Respond with %d and a %sResponse\n", responseStatus(endpoint), name))
+		}
+		sb.WriteString("  res.status(501).json({ error: { code: 'NOT_IMPLEMENTED', message: 'Not implemented' } });\n});\n")
+	}
+
+	sb.WriteString("\nmodule.exports = router;\n")
+
+	return []Stub{{Filename: resource + ".js", Content: sb.String()}}
+}
+
+// Spring Boot
+
+func javaType(field models.Field) string {
+	switch field.Type {
+	case "integer":
+		return "Long"
+	case "number":
+		return "Double"
+	case "boolean":
+		return "Boolean"
+	case "uuid":
+		return "UUID"
+	case "datetime":
+		return "OffsetDateTime"
+	case "array":
+		return "List<Object>"
+	case "object":
+		return "Map<String, Object>"
+	default:
+		return "String"
+	}
+}
+
+func javaRecord(sb *strings.Builder, name string, fields map[string]string) {
+	var components []string
+	for _, f := range sortedFields(fields) {
+		component := javaType(f.Field) + " " + camelCase(f.Name)
+		if camelCase(f.Name) != f.Name {
+			component = fmt.Sprintf("@JsonProperty(%q) %s", f.Name, component)
+		}
+		components = append(components, component)
+	}
+	sb.WriteString(fmt.Sprintf("    public record %s(%s) {}\n\n", name, strings.Join(components, ", ")))
+}
+
+func camelCase(s string) string {
+	p := pascalCase(s)
+	if p == "ID" {
+		return "id"
+	}
+	if p == "" {
+		return p
+	}
+	return strings.ToLower(p[:1]) + p[1:]
+}
+
+func springStubs(resource string, endpoints []models.Endpoint) []Stub {
+	names := operationNames(endpoints)
+	className := pascalCase(resource) + "Controller"
+
+	var sb strings.Builder
+	sb.WriteString(stubHeader("//", resource))
+	sb.WriteString("package com.example.api;\n\n")
+	sb.WriteString("import java.time.OffsetDateTime;\nimport java.util.List;\nimport java.util.Map;\nimport java.util.UUID;\n\n")
+	sb.WriteString("import com.fasterxml.jackson.annotation.JsonProperty;\n")
+	sb.WriteString("import org.springframework.http.HttpStatus;\nimport org.springframework.http.ResponseEntity;\nimport org.springframework.web.bind.annotation.*;\n\n")
+	sb.WriteString("@RestController\npublic class " + className + " {\n\n")
+
+	for idx, endpoint := range endpoints {
+		name := pascalCase(names[idx])
+		if body := requestBody(endpoint); len(body) > 0 {
+			javaRecord(&sb, name+"Request", body)
+		}
+		if body := responseBody(endpoint); len(body) > 0 {
+			javaRecord(&sb, name+"Response", body)
+		}
+	}
+
+	for idx, endpoint := range endpoints {
+		name := pascalCase(names[idx])
+		responseType := "Void"
+		if len(responseBody(endpoint)) > 0 {
+			responseType = name + "Response"
+		}
+
+		var args []string
+		for _, param := range pathParams(endpoint.Path) {
+			args = append(args, fmt.Sprintf("@PathVariable(%q) String %s", param, camelCase(param)))
+		}
+		for _, f := range sortedFields(requestQuery(endpoint)) {
+			args = append(args, fmt.Sprintf("@RequestParam(name = %q, required = %t) %s %s", f.Name, f.Field.Required, javaType(f.Field), camelCase(f.Name)))
+		}
+		if len(requestBody(endpoint)) > 0 {
+			args = append(args, "@RequestBody "+name+"Request body")
+		}
+
+		method := pascalCase(strings.ToLower(endpoint.Method))
+		sb.WriteString(fmt.Sprintf("    /** %s */\n", strings.ReplaceAll(commentText(endpoint.Description), "*/", "* /")))
+		if endpoint.Auth {
+			sb.WriteString("    // TODO: require authentication, e.g. @PreAuthorize(\"isAuthenticated()\")\n")
+		}
+		sb.WriteString(fmt.Sprintf("    @%sMapping(%q)\n", method, endpoint.Path))
+		sb.WriteString(fmt.Sprintf("    public ResponseEntity<%s> %s(%s) {\n", responseType, names[idx], strings.Join(args, ", ")))
+		sb.WriteString(fmt.Sprintf("        // Respond with HTTP %d\n", responseStatus(endpoint)))
+		sb.WriteString("        return ResponseEntity.status(HttpStatus.NOT_IMPLEMENTED).build();\n    }\n\n")
+	}
+
+	sb.WriteString("}\n")
+
+	return []Stub{{Filename: className + ".java", Content: sb.String()}}
+}
+
+// Rails
+
+func railsStubs(resource string, endpoints []models.Endpoint) []Stub {
+	names := operationNames(endpoints)
+	className := pascalCase(resource) + "Controller"
+
+	var controller strings.Builder
+	controller.WriteString(stubHeader("#", resource))
+	controller.WriteString(fmt.Sprintf("class %s < ApplicationController\n", className))
+
+	var routes strings.Builder
+	routes.WriteString(stubHeader("#", resource))
+	routes.WriteString("# Copy these routes into config/routes.rb\nRails.application.routes.draw do\n")
+
+	for idx, endpoint := range endpoints {
+		action := snakeCase(names[idx])
+		if idx > 0 {
+			controller.WriteString("\n")
+		}
+		controller.WriteString(fmt.Sprintf("  # %s %s - %s\n", endpoint.Method, endpoint.Path, commentText(endpoint.Description)))
+		if endpoint.Auth {
+			controller.WriteString("  # TODO: require authentication, e.g. before_action :authenticate_user!\n")
+		}
+		controller.WriteString(fmt.Sprintf("  def %s\n", action))
+		if body := requestBody(endpoint); len(body) > 0 {
+			var permitted []string
+			for _, f := range sortedFields(body) {
+				permitted = append(permitted, ":"+f.Name)
+			}
+			controller.WriteString(fmt.Sprintf("    body = params.permit(%s)\n", strings.Join(permitted, ", ")))
+		}
+		controller.WriteString("    render json: { error: { code: 'NOT_IMPLEMENTED', message: 'Not implemented' } }, status: :not_implemented\n  end\n")
+
+		routes.WriteString(fmt.Sprintf("  %s '%s', to: '%s#%s'\n", strings.ToLower(endpoint.Method), convertPathParams(endpoint.Path, ":%s"), resource, action))
+	}
+
+	controller.WriteString("end\n")
+	routes.WriteString("end\n")
+
+	return []Stub{
+		{Filename: resource + "_controller.rb", Content: controller.String()},
+		{Filename: resource + "_routes.rb", Content: routes.String()},
+	}
+}
+
+// Go (net/http with Go 1.22 routing patterns, chi and gin)
+
+func goType(field models.Field) string {
+	switch field.Type {
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "datetime":
+		return "time.Time"
+	case "array":
+		return "[]any"
+	case "object":
+		return "map[string]any"
+	default:
+		return "string"
+	}
+}
+
+func goStruct(sb *strings.Builder, name string, fields map[string]string) {
+	sb.WriteString(fmt.Sprintf("\ntype %s struct {\n", name))
+	for _, f := range sortedFields(fields) {
+		fieldType, tag := goType(f.Field), f.Name
+		if !f.Field.Required {
+			fieldType = "*" + fieldType
+			tag += ",omitempty"
+		}
+		sb.WriteString(fmt.Sprintf("\t%s %s `json:%q`\n", pascalCase(f.Name), fieldType, tag))
+	}
+	sb.WriteString("}\n")
+}
+
+// goRouter describes how handlers are registered and written for one Go
+// router
+type goRouter struct {
+	importPath    string // router package, empty for net/http
+	registerParam string
+	handlerParams string
+	route         func(endpoint models.Endpoint, handler string) string
+	pathParam     func(param string) string
+	decodeBody    func(name string) string
+	decodesJSON   bool // whether decodeBody uses encoding/json
+	writeError    func(status, code, message string) string
+}
+
+var netHTTPRouter = goRouter{
+	registerParam: "mux *http.ServeMux",
+	handlerParams: "w http.ResponseWriter, r *http.Request",
+	route: func(endpoint models.Endpoint, handler string) string {
+		return fmt.Sprintf("mux.HandleFunc(\"%s %s\", %s)", endpoint.Method, endpoint.Path, handler)
+	},
+	pathParam: func(param string) string { return fmt.Sprintf("r.PathValue(%q)", param) },
+	decodeBody: func(name string) string {
+		return fmt.Sprintf("var req %sRequest\n\tif err := json.NewDecoder(r.Body).Decode(&req); err != nil {", name)
+	},
+	decodesJSON: true,
+	writeError: func(status, code, message string) string {
+		return fmt.Sprintf("writeStubError(w, %s, %q, %q)", status, code, message)
+	},
+}
+
+var chiRouter = goRouter{
+	importPath:    "github.com/go-chi/chi/v5",
+	registerParam: "r chi.Router",
+	handlerParams: "w http.ResponseWriter, r *http.Request",
+	route: func(endpoint models.Endpoint, handler string) string {
+		switch endpoint.Method {
+		case "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS":
+			return fmt.Sprintf("r.%s(%q, %s)", pascalCase(strings.ToLower(endpoint.Method)), endpoint.Path, handler)
+		default:
+			return fmt.Sprintf("r.Method(%q, %q, http.HandlerFunc(%s))", endpoint.Method, endpoint.Path, handler)
+		}
+	},
+	pathParam: func(param string) string { return fmt.Sprintf("chi.URLParam(r, %q)", param) },
+	decodeBody: func(name string) string {
+		return fmt.Sprintf("var req %sRequest\n\tif err := json.NewDecoder(r.Body).Decode(&req); err != nil {", name)
+	},
+	decodesJSON: true,
+	writeError: func(status, code, message string) string {
+		return fmt.Sprintf("writeStubError(w, %s, %q, %q)", status, code, message)
+	},
+}
+
+var ginRouter = goRouter{
+	importPath:    "github.com/gin-gonic/gin",
+	registerParam: "r gin.IRouter",
+	handlerParams: "c *gin.Context",
+	route: func(endpoint models.Endpoint, handler string) string {
+		path := convertPathParams(endpoint.Path, ":%s")
+		switch endpoint.Method {
+		case "GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS":
+			return fmt.Sprintf("r.%s(%q, %s)", endpoint.Method, path, handler)
+		default:
+			return fmt.Sprintf("r.Handle(%q, %q, %s)", endpoint.Method, path, handler)
+		}
+	},
+	pathParam: func(param string) string { return fmt.Sprintf("c.Param(%q)", param) },
+	decodeBody: func(name string) string {
+		return fmt.Sprintf("var req %sRequest\n\tif err := c.ShouldBindJSON(&req); err != nil {", name)
+	},
+	writeError: func(status, code, message string) string {
+		return fmt.Sprintf("c.JSON(%s, stubError(%q, %q))", status, code, message)
+	},
+}
+
+func goStubs(resource string, endpoints []models.Endpoint) []Stub {
+	return goRouterStubs(netHTTPRouter, resource, endpoints)
+}
+
+func chiStubs(resource string, endpoints []models.Endpoint) []Stub {
+	return goRouterStubs(chiRouter, resource, endpoints)
+}
+
+func ginStubs(resource string, endpoints []models.Endpoint) []Stub {
+	return goRouterStubs(ginRouter, resource, endpoints)
+}
+
+func goRouterStubs(router goRouter, resource string, endpoints []models.Endpoint) []Stub {
+	usesJSON, usesTime := false, false
+	for _, endpoint := range endpoints {
+		if len(requestBody(endpoint)) > 0 && router.decodesJSON {
+			usesJSON = true
+		}
+		for _, fields := range []map[string]string{requestBody(endpoint), responseBody(endpoint)} {
+			for _, def := range fields {
+				if models.ParseField(def).Type == "datetime" {
+					usesTime = true
+				}
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(stubHeader("//", resource))
+	sb.WriteString("package handlers\n\nimport (\n")
+	if usesJSON {
+		sb.WriteString("\t\"encoding/json\"\n")
+	}
+	sb.WriteString("\t\"net/http\"\n")
+	if usesTime {
+		sb.WriteString("\t\"time\"\n")
+	}
+	if router.importPath != "" {
+		sb.WriteString(fmt.Sprintf("\n\t%q\n", router.importPath))
+	}
+	sb.WriteString(")\n")
+
+	names := operationNames(endpoints)
+	for idx, endpoint := range endpoints {
+		name := pascalCase(names[idx])
+		if body := requestBody(endpoint); len(body) > 0 {
+			goStruct(&sb, name+"Request", body)
+		}
+		if body := responseBody(endpoint); len(body) > 0 {
+			goStruct(&sb, name+"Response", body)
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n// Register%sRoutes registers the %s handlers\n", pascalCase(resource), resource))
+	sb.WriteString(fmt.Sprintf("func Register%sRoutes(%s) {\n", pascalCase(resource), router.registerParam))
+	for idx, endpoint := range endpoints {
+		sb.WriteString("\t" + router.route(endpoint, pascalCase(names[idx])) + "\n")
+	}
+	sb.WriteString("}\n")
+
+	for idx, endpoint := range endpoints {
+		name := pascalCase(names[idx])
+		sb.WriteString(fmt.Sprintf("\n// %s handles %s %s: %s\n", name, endpoint.Method, endpoint.Path, commentText(endpoint.Description)))
+		sb.WriteString(fmt.Sprintf("func %s(%s) {\n", name, router.handlerParams))
+		if endpoint.Auth {
+			sb.WriteString("\t// TODO: require authentication\n")
+		}
+		for _, param := range pathParams(endpoint.Path) {
+			sb.WriteString("\t_ = " + router.pathParam(param) + "\n")
+		}
+		if len(requestBody(endpoint)) > 0 {
+			sb.WriteString("\t" + router.decodeBody(name) + "\n")
+			sb.WriteString("\t\t" + router.writeError("http.StatusBadRequest", "VALIDATION_ERROR", "Invalid request body") + "\n\t\treturn\n\t}\n")
+			sb.WriteString("\t_ = req\n")
+		}
+		if len(responseBody(endpoint)) > 0 {
+			sb.WriteString(fmt.Sprintf("\t// Respond with %d and a %sResponse\n", responseStatus(endpoint), name))
+		}
+		sb.WriteString("\t" + router.writeError("http.StatusNotImplemented", "NOT_IMPLEMENTED", "Not implemented") + "\n}\n")
+	}
+
+	return []Stub{{Filename: resource + ".go", Content: formatGo(sb.String())}}
+}
+
+// goStubSupport holds helpers shared by the generated net/http and chi
+// handler files
+func goStubSupport() Stub {
+	return Stub{Filename: "stub_errors.go", Content: `// Helpers shared by the handler stubs generated by ` + "`architect validate --fix`" + `.
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// writeStubError writes an error response in the project's error format
+func writeStubError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"error": map[string]string{"code": code, "message": message},
+	})
+}
+`}
+}
+
+// ginStubSupport holds helpers shared by the generated gin handler files
+func ginStubSupport() Stub {
+	return Stub{Filename: "stub_errors.go", Content: `// Helpers shared by the handler stubs generated by ` + "`architect validate --fix`" + `.
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+// stubError builds an error response in the project's error format
+func stubError(code, message string) gin.H {
+	return gin.H{"error": gin.H{"code": code, "message": message}}
+}
+`}
+}
+
+// formatGo runs gofmt over generated Go source, leaving it untouched if it
+// does not parse
+func formatGo(src string) string {
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return src
+	}
+	return string(formatted)
+}
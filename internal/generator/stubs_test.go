@@ -0,0 +1,255 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestStubBackend(t *testing.T) {
+	tests := map[string]string{
+		"FastAPI":       "fastapi",
+		"Express":       "express",
+		"Express.js":    "express",
+		"Spring Boot":   "spring",
+		"Ruby on Rails": "rails",
+		"Go":            "go",
+		"net/http":      "go",
+		"chi":           "chi",
+		"Gin":           "gin",
+		"gin-gonic":     "gin",
+		"Node":          "",
+		"Node.js":       "",
+		"Koa":           "",
+		"Other":         "",
+		"Flask-RESTful": "",
+		"Engine":        "",
+	}
+	for backend, want := range tests {
+		if got := stubBackend(backend); got != want {
+			t.Errorf("stubBackend(%q) = %q, want %q", backend, got, want)
+		}
+	}
+}
+
+func TestGenerateHandlerStubsUnknownBackend(t *testing.T) {
+	if _, err := GenerateHandlerStubs("Node", "/api", stubEndpoints()); err == nil {
+		t.Fatal("expected an error for a backend without a stub template")
+	}
+}
+
+func stubEndpoints() []models.Endpoint {
+	return []models.Endpoint{
+		{
+			Path:        "/orders/{orderId}",
+			Method:      "GET",
+			Description: "Get an order",
+			Auth:        true,
+			Response:    &models.EndpointResponse{Status: 200, Body: map[string]string{"id": "uuid, required", "created_at": "datetime, optional"}},
+		},
+		{
+			Path:        "/orders",
+			Method:      "POST",
+			Description: "Create an order",
+			Request:     &models.EndpointRequest{Body: map[string]string{"sku": "string, required"}},
+		},
+		{
+			Path:        "/orders/{orderId}",
+			Method:      "PURGE",
+			Description: "Purge an order from caches",
+		},
+	}
+}
+
+func TestGoRouterStubs(t *testing.T) {
+	tests := []struct {
+		backend  string
+		contains []string
+		absent   []string
+	}{
+		{
+			backend:  "Go",
+			contains: []string{`func RegisterOrdersRoutes(mux *http.ServeMux)`, `mux.HandleFunc("GET /orders/{orderId}", GetOrders)`, `r.PathValue("orderId")`, `json.NewDecoder(r.Body).Decode(&req)`},
+			absent:   []string{"go-chi", "gin-gonic"},
+		},
+		{
+			backend:  "chi",
+			contains: []string{`"github.com/go-chi/chi/v5"`, `func RegisterOrdersRoutes(r chi.Router)`, `r.Get("/orders/{orderId}", GetOrders)`, `r.Post("/orders", CreateOrders)`, `r.Method("PURGE", "/orders/{orderId}", http.HandlerFunc(PurgeOrders))`, `chi.URLParam(r, "orderId")`},
+			absent:   []string{"PathValue", "ServeMux", "gin-gonic"},
+		},
+		{
+			backend:  "gin",
+			contains: []string{`"github.com/gin-gonic/gin"`, `func RegisterOrdersRoutes(r gin.IRouter)`, `r.GET("/orders/:orderId", GetOrders)`, `r.Handle("PURGE", "/orders/:orderId", PurgeOrders)`, `func GetOrders(c *gin.Context)`, `c.Param("orderId")`, `c.ShouldBindJSON(&req)`},
+			absent:   []string{"PathValue", "ServeMux", "encoding/json", "writeStubError"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			stubs, err := GenerateHandlerStubs(tt.backend, "/api", stubEndpoints())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stubs) != 2 || stubs[0].Filename != "orders.go" || stubs[1].Filename != "stub_errors.go" {
+				t.Fatalf("unexpected stub files: %+v", stubs)
+			}
+
+			for _, stub := range stubs {
+				if _, err := parser.ParseFile(token.NewFileSet(), stub.Filename, stub.Content, 0); err != nil {
+					t.Errorf("%s does not parse: %v\n%s", stub.Filename, err, stub.Content)
+				}
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(stubs[0].Content, want) {
+					t.Errorf("stub lacks %s:\n%s", want, stubs[0].Content)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(stubs[0].Content, unwanted) {
+					t.Errorf("stub contains %s:\n%s", unwanted, stubs[0].Content)
+				}
+			}
+		})
+	}
+}
+
+func TestOperationNames(t *testing.T) {
+	endpoints := []models.Endpoint{
+		{Method: "GET", Path: "/users"},
+		{Method: "GET", Path: "/users/{id}"},
+		{Method: "PUT", Path: "/users"},
+		{Method: "PUT", Path: "/users/{id}"},
+		{Method: "DELETE", Path: "/users"},
+		{Method: "DELETE", Path: "/users/{userId}"},
+		{Method: "POST", Path: "/v1/users"},
+		{Method: "POST", Path: "/v2/users"},
+	}
+	want := []string{
+		"listUsers", "getUsers",
+		"replaceUsers", "replaceUsersByID",
+		"deleteUsers", "deleteUsersByUserId",
+		"createUsers", "createUsers2",
+	}
+
+	got := operationNames(endpoints)
+	for idx := range want {
+		if got[idx] != want[idx] {
+			t.Errorf("%s %s: name %q, want %q", endpoints[idx].Method, endpoints[idx].Path, got[idx], want[idx])
+		}
+	}
+}
+
+// collidingEndpoints share a resource and, without deduplication, handler
+// names
+func collidingEndpoints() []models.Endpoint {
+	return []models.Endpoint{
+		{Method: "PUT", Path: "/users", Description: "Replace all users", Request: &models.EndpointRequest{Body: map[string]string{"users": "array, required"}}},
+		{Method: "PUT", Path: "/users/{id}", Description: `Replace a "user"`, Request: &models.EndpointRequest{Body: map[string]string{"name": "string, required"}}},
+		{Method: "DELETE", Path: "/users", Description: "Delete all users"},
+		{Method: "DELETE", Path: "/users/{id}", Description: "Delete a user\n*/ with \"\"\" quotes \\"},
+		{Method: "GET", Path: "/users/{id}", Response: &models.EndpointResponse{Body: map[string]string{"created_at": "datetime, required"}}},
+	}
+}
+
+func TestGoStubsCompile(t *testing.T) {
+	goBinary, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+
+	stubs, err := GenerateHandlerStubs("Go", "/api", collidingEndpoints())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module stubs\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, stub := range stubs {
+		if err := os.WriteFile(filepath.Join(dir, stub.Filename), []byte(stub.Content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	build := exec.Command(goBinary, "build", "./...")
+	build.Dir = dir
+	build.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off")
+	if output, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("generated stubs do not compile: %v\n%s\n%s", err, output, stubs[0].Content)
+	}
+}
+
+func TestStubsUniqueHandlerNames(t *testing.T) {
+	tests := []struct {
+		backend string
+		pattern string
+	}{
+		{"Spring Boot", `public ResponseEntity<\w+> (\w+)\(`},
+		{"FastAPI", `(?m)^async def (\w+)\(`},
+		{"Flask", `(?m)^def (\w+)\(`},
+		{"Rails", `(?m)^  def (\w+)$`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			stubs, err := GenerateHandlerStubs(tt.backend, "/api", collidingEndpoints())
+			if err != nil {
+				t.Fatal(err)
+			}
+			seen := make(map[string]bool)
+			matches := regexp.MustCompile(tt.pattern).FindAllStringSubmatch(stubs[0].Content, -1)
+			if len(matches) != len(collidingEndpoints()) {
+				t.Fatalf("found %d handlers, want %d:\n%s", len(matches), len(collidingEndpoints()), stubs[0].Content)
+			}
+			for _, m := range matches {
+				if seen[m[1]] {
+					t.Errorf("handler %s is declared twice:\n%s", m[1], stubs[0].Content)
+				}
+				seen[m[1]] = true
+			}
+		})
+	}
+}
+
+func TestPythonStubsParse(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is not installed")
+	}
+
+	for _, backend := range []string{"FastAPI", "Flask", "Django"} {
+		t.Run(backend, func(t *testing.T) {
+			stubs, err := GenerateHandlerStubs(backend, "/api", collidingEndpoints())
+			if err != nil {
+				t.Fatal(err)
+			}
+			// ast.parse checks the syntax without importing the framework
+			check := exec.Command(python, "-c", "import ast, sys; ast.parse(sys.stdin.read())")
+			check.Stdin = strings.NewReader(stubs[0].Content)
+			if output, err := check.CombinedOutput(); err != nil {
+				t.Errorf("stub does not parse: %v\n%s\n%s", err, output, stubs[0].Content)
+			}
+		})
+	}
+}
+
+func TestPythonDocstring(t *testing.T) {
+	tests := map[string]string{
+		"Get a user":          `"""Get a user"""`,
+		`Say """hi"""`:        `"""Say \"\"\"hi\"\"\""""`,
+		`Ends with a "quote"`: `"""Ends with a \"quote\""""`,
+		`C:\path`:             `"""C:\\path"""`,
+	}
+	for description, want := range tests {
+		if got := pythonDocstring(description); got != want {
+			t.Errorf("pythonDocstring(%q) = %s, want %s", description, got, want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package importers
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// AsyncAPIImporter handles importing AsyncAPI 2.x and 3.x documents (JSON or
+// YAML). Every message a channel carries becomes a channel entry with the
+// role the service plays for it.
+type AsyncAPIImporter struct{}
+
+// Import parses an AsyncAPI document and converts it to our internal API model
+func (i *AsyncAPIImporter) Import(filename string) (*models.API, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	// JSON is valid YAML
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse AsyncAPI document: %w", err)
+	}
+
+	version, _ := doc["asyncapi"].(string)
+	if version == "" {
+		return nil, fmt.Errorf("invalid AsyncAPI document: missing asyncapi version")
+	}
+
+	api := &models.API{Endpoints: []models.Endpoint{}}
+	if strings.HasPrefix(version, "2.") {
+		api.Channels = i.channelsV2(doc)
+	} else {
+		api.Channels = i.channelsV3(doc)
+	}
+	if len(api.Channels) == 0 {
+		return nil, fmt.Errorf("no channel operations found in %s", filename)
+	}
+
+	return api, nil
+}
+
+// Validate checks if the imported API is valid
+func (i *AsyncAPIImporter) Validate(api *models.API) error {
+	if api == nil {
+		return fmt.Errorf("API cannot be nil")
+	}
+
+	for idx, channel := range api.Channels {
+		if channel.Name == "" {
+			return fmt.Errorf("channel %d: name is required", idx)
+		}
+		if channel.Role != "producer" && channel.Role != "consumer" {
+			return fmt.Errorf("channel %s: role must be producer or consumer", channel.Name)
+		}
+	}
+
+	return nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (i *AsyncAPIImporter) GetSupportedExtensions() []string {
+	return []string{".yaml", ".yml", ".json"}
+}
+
+// channelsV2 reads AsyncAPI 2.x channels. Operations are described from the
+// client's point of view: a channel the client subscribes to is one the
+// service publishes to.
+func (i *AsyncAPIImporter) channelsV2(doc map[string]interface{}) []models.Channel {
+	channels, _ := doc["channels"].(map[string]interface{})
+
+	var result []models.Channel
+	for _, name := range sortedKeys(channels) {
+		channel, _ := channels[name].(map[string]interface{})
+		for _, operation := range []struct{ key, role string }{{"subscribe", "producer"}, {"publish", "consumer"}} {
+			op, ok := channel[operation.key].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			description := firstString(op["summary"], op["description"], channel["description"])
+			for _, message := range i.messages(doc, op["message"]) {
+				result = append(result, i.channel(doc, name, description, operation.role, message))
+			}
+		}
+	}
+	return result
+}
+
+// channelsV3 reads AsyncAPI 3.x operations, which reference their channel
+// and state the action of the service itself
+func (i *AsyncAPIImporter) channelsV3(doc map[string]interface{}) []models.Channel {
+	operations, _ := doc["operations"].(map[string]interface{})
+
+	var result []models.Channel
+	for _, operationID := range sortedKeys(operations) {
+		op, _ := operations[operationID].(map[string]interface{})
+		role := "producer"
+		if op["action"] == "receive" {
+			role = "consumer"
+		}
+
+		channel, channelID := resolveAsyncAPIRef(doc, op["channel"])
+		name, _ := channel["address"].(string)
+		if name == "" {
+			name = channelID
+		}
+		description := firstString(op["summary"], op["description"], channel["description"])
+
+		// Without a message list the operation carries every channel message
+		var messages []namedMessage
+		if list, ok := op["messages"].([]interface{}); ok {
+			for _, ref := range list {
+				messages = append(messages, i.messages(doc, ref)...)
+			}
+		} else {
+			channelMessages, _ := channel["messages"].(map[string]interface{})
+			for _, key := range sortedKeys(channelMessages) {
+				for _, message := range i.messages(doc, channelMessages[key]) {
+					if message.name == "" {
+						message.name = key
+					}
+					messages = append(messages, message)
+				}
+			}
+		}
+
+		for _, message := range messages {
+			result = append(result, i.channel(doc, name, description, role, message))
+		}
+	}
+	return result
+}
+
+type namedMessage struct {
+	name    string
+	message map[string]interface{}
+}
+
+// messages resolves a message, a reference to one or a oneOf list
+func (i *AsyncAPIImporter) messages(doc map[string]interface{}, node interface{}) []namedMessage {
+	message, refName := resolveAsyncAPIRef(doc, node)
+	if message == nil {
+		return nil
+	}
+
+	if oneOf, ok := message["oneOf"].([]interface{}); ok {
+		var result []namedMessage
+		for _, item := range oneOf {
+			result = append(result, i.messages(doc, item)...)
+		}
+		return result
+	}
+
+	name := firstString(message["name"], message["messageId"], message["title"])
+	if name == "" {
+		name = refName
+	}
+	return []namedMessage{{name: name, message: message}}
+}
+
+// channel builds a channel entry from a resolved message
+func (i *AsyncAPIImporter) channel(doc map[string]interface{}, name, description, role string, message namedMessage) models.Channel {
+	schemas := &OpenAPIImporter{}
+	channel := models.Channel{
+		Name:        name,
+		Description: firstString(description, message.message["summary"], message.message["description"]),
+		Role:        role,
+		Message:     message.name,
+	}
+	if payload := schemas.parseSchemaProperties(resolveAsyncAPISchema(doc, message.message["payload"], 0)); len(payload) > 0 {
+		channel.Payload = payload
+	}
+	if headers := schemas.parseSchemaProperties(resolveAsyncAPISchema(doc, message.message["headers"], 0)); len(headers) > 0 {
+		channel.Headers = headers
+	}
+	return channel
+}
+
+// resolveAsyncAPIRef follows local $ref pointers such as
+// #/components/messages/UserSignedUp and returns the target with the name of
+// its last segment
+func resolveAsyncAPIRef(doc map[string]interface{}, node interface{}) (map[string]interface{}, string) {
+	name := ""
+	for depth := 0; depth < 10; depth++ {
+		object, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, name
+		}
+		ref, ok := object["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#/") {
+			return object, name
+		}
+
+		node = doc
+		for _, segment := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+			parent, _ := node.(map[string]interface{})
+			node = parent[segment]
+			name = segment
+		}
+	}
+	return nil, name
+}
+
+// resolveAsyncAPISchema replaces the references in a schema with their
+// targets, up to a fixed depth so that recursive schemas terminate
+func resolveAsyncAPISchema(doc map[string]interface{}, node interface{}, depth int) interface{} {
+	if depth > 8 {
+		return node
+	}
+
+	switch value := node.(type) {
+	case map[string]interface{}:
+		resolved, _ := resolveAsyncAPIRef(doc, value)
+		if resolved == nil {
+			return nil
+		}
+		result := make(map[string]interface{}, len(resolved))
+		for key, child := range resolved {
+			result[key] = resolveAsyncAPISchema(doc, child, depth+1)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(value))
+		for idx, child := range value {
+			result[idx] = resolveAsyncAPISchema(doc, child, depth+1)
+		}
+		return result
+	default:
+		return node
+	}
+}
+
+// firstString returns the first non-empty string value
+func firstString(values ...interface{}) string {
+	for _, value := range values {
+		if s, ok := value.(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of a map in sorted order
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
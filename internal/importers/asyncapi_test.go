@@ -0,0 +1,59 @@
+package importers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestAsyncAPIImporterFixtures(t *testing.T) {
+	tests := []struct {
+		file string
+		want []models.Channel
+	}{
+		{
+			file: "testdata/events-v2.yaml",
+			want: []models.Channel{
+				{Name: "user/commands", Description: "Delete a user", Role: "consumer", Message: "DeleteUser", Payload: map[string]string{"id": "string, required"}},
+				{Name: "user/commands", Role: "consumer", Message: "SuspendUser", Payload: map[string]string{"id": "string, required"}},
+				{
+					Name: "user/signedup", Description: "A user signed up", Role: "producer", Message: "UserSignedUp",
+					Payload: map[string]string{"id": "string, required", "email": "string, required", "age": "integer, optional"},
+					Headers: map[string]string{"correlationId": "string, optional"},
+				},
+			},
+		},
+		{
+			file: "testdata/events-v3.json",
+			want: []models.Channel{
+				{Name: "refunds", Description: "Refund requests from support", Role: "consumer", Message: "RefundRequested", Payload: map[string]string{"paymentId": "string, required"}},
+				{Name: "payments.{id}", Description: "Payment events", Role: "producer", Message: "PaymentCaptured", Payload: map[string]string{"amount": "integer, optional"}},
+				{Name: "payments.{id}", Description: "Payment events", Role: "producer", Message: "PaymentFailed", Payload: map[string]string{"reason": "string, optional"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			api, err := (&AsyncAPIImporter{}).Import(tt.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(api.Channels, tt.want) {
+				t.Errorf("channels =\n%+v\nwant\n%+v", api.Channels, tt.want)
+			}
+			if err := (&AsyncAPIImporter{}).Validate(api); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestDetectAsyncAPIFormat(t *testing.T) {
+	factory := &ImporterFactory{}
+	for _, file := range []string{"testdata/events-v2.yaml", "testdata/events-v3.json"} {
+		if format, err := factory.DetectFormat(file); err != nil || format != "asyncapi" {
+			t.Errorf("DetectFormat(%s) = %q, %v, want asyncapi", file, format, err)
+		}
+	}
+}
@@ -0,0 +1,711 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// BlueprintImporter handles importing API Blueprint (format 1A) documents.
+// Actions become endpoints; their MSON attributes, JSON schemas or JSON
+// bodies become fields, with named data structures resolved.
+type BlueprintImporter struct {
+	structures map[string]blueprintStructure
+	models     map[string]map[string]string
+	authType   string
+}
+
+// blueprintItem is a list item of a Blueprint section, such as
+// "+ Response 200 (application/json)", with its nested items and the text
+// below it (bodies, headers, schemas)
+type blueprintItem struct {
+	text     string
+	indent   int
+	children []*blueprintItem
+	raw      []string
+}
+
+// blueprintStructure is a named MSON data structure
+type blueprintStructure struct {
+	base    string
+	members []*blueprintItem
+}
+
+// blueprintResource is the resource the following actions belong to
+type blueprintResource struct {
+	name       string
+	uri        string
+	parameters []*blueprintItem
+	attributes *blueprintItem
+}
+
+var (
+	blueprintHeadingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	blueprintListPattern     = regexp.MustCompile(`^(\s*)[+*-]\s+(.*)$`)
+	blueprintActionPattern   = regexp.MustCompile(`^(.*?)\s*\[([A-Z]+)(?:\s+(\S+))?\]$`)
+	blueprintResourcePattern = regexp.MustCompile(`^(.*?)\s*\[(/[^\]]*)\]$`)
+	blueprintEndpointPattern = regexp.MustCompile(`^(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS)\s+(/\S*)$`)
+	blueprintMemberPattern   = regexp.MustCompile("^(`[^`]+`|[^:(]+?)(?:\\s*:\\s*([^(]*?))?\\s*(?:\\(([^)]*)\\))?\\s*(?:-\\s.*)?$")
+	blueprintTemplatePattern = regexp.MustCompile(`\{([?&+#./;]?)([^}]*)\}`)
+	blueprintModelPattern    = regexp.MustCompile(`^\[([^\]]+)\]\[\]$`)
+)
+
+// Import parses an API Blueprint document and converts it to our internal
+// API model
+func (i *BlueprintImporter) Import(filename string) (*models.API, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+	text := strings.ReplaceAll(strings.TrimPrefix(string(content), "\uFEFF"), "\r\n", "\n")
+	lines := strings.Split(strings.ReplaceAll(text, "\t", "    "), "\n")
+
+	i.structures = make(map[string]blueprintStructure)
+	i.models = make(map[string]map[string]string)
+	i.authType = ""
+
+	api := &models.API{Endpoints: []models.Endpoint{}}
+
+	// Metadata such as HOST precedes the first blank line
+	for _, line := range lines {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			break
+		}
+		if strings.EqualFold(key, "HOST") {
+			api.BaseURL = blueprintBasePath(strings.TrimSpace(value))
+		}
+	}
+
+	sections := blueprintSections(lines)
+
+	// Data structures may be declared after the actions using them
+	inStructures := false
+	for _, section := range sections {
+		switch {
+		case strings.EqualFold(section.title, "Data Structures"):
+			inStructures = true
+		case section.level == 1 || blueprintActionPattern.MatchString(section.title) ||
+			blueprintResourcePattern.MatchString(section.title) || blueprintEndpointPattern.MatchString(section.title):
+			inStructures = false
+		case inStructures:
+			name, base := blueprintTypeName(section.title)
+			i.structures[name] = blueprintStructure{base: base, members: blueprintOutline(section.body)}
+		}
+	}
+
+	var resource *blueprintResource
+	for _, section := range sections {
+		title := section.title
+		items := blueprintOutline(section.body)
+
+		switch {
+		case blueprintEndpointPattern.MatchString(title):
+			match := blueprintEndpointPattern.FindStringSubmatch(title)
+			resource = &blueprintResource{uri: match[2]}
+			api.Endpoints = append(api.Endpoints, i.action(resource, "", match[1], section, items))
+
+		case blueprintActionPattern.MatchString(title):
+			match := blueprintActionPattern.FindStringSubmatch(title)
+			current := resource
+			if match[3] != "" {
+				current = &blueprintResource{uri: match[3]}
+				if resource != nil {
+					current.name = resource.name
+					current.parameters = resource.parameters
+				}
+			}
+			if current == nil {
+				continue
+			}
+			api.Endpoints = append(api.Endpoints, i.action(current, match[1], match[2], section, items))
+
+		case blueprintResourcePattern.MatchString(title):
+			match := blueprintResourcePattern.FindStringSubmatch(title)
+			resource = &blueprintResource{name: match[1], uri: match[2]}
+			for _, item := range items {
+				switch blueprintKeyword(item.text) {
+				case "parameters":
+					resource.parameters = item.children
+				case "attributes":
+					resource.attributes = item
+				case "model":
+					i.models[resource.name] = i.payload(item)
+				}
+			}
+
+		case section.level == 1:
+			resource = nil
+		}
+	}
+
+	if len(api.Endpoints) == 0 {
+		return nil, fmt.Errorf("no actions found in %s", filename)
+	}
+	api.AuthType = i.authType
+	if api.AuthType == "" {
+		api.AuthType = "none"
+	}
+
+	return api, nil
+}
+
+// Validate checks if the imported API is valid
+func (i *BlueprintImporter) Validate(api *models.API) error {
+	if api == nil {
+		return fmt.Errorf("API cannot be nil")
+	}
+
+	for idx, endpoint := range api.Endpoints {
+		if endpoint.Path == "" {
+			return fmt.Errorf("endpoint %d: path is required", idx)
+		}
+		if endpoint.Method == "" {
+			return fmt.Errorf("endpoint %d: method is required", idx)
+		}
+	}
+
+	return nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (i *BlueprintImporter) GetSupportedExtensions() []string {
+	return []string{".apib", ".md"}
+}
+
+// action converts a Blueprint action to an endpoint
+func (i *BlueprintImporter) action(resource *blueprintResource, name, method string, section blueprintSection, items []*blueprintItem) models.Endpoint {
+	path, query := blueprintPath(resource.uri)
+	endpoint := models.Endpoint{
+		Path:        path,
+		Method:      method,
+		Description: strings.TrimSpace(name),
+	}
+	if endpoint.Description == "" {
+		endpoint.Description = section.description()
+	}
+	if endpoint.Description == "" {
+		endpoint.Description = strings.TrimSpace(resource.name)
+	}
+
+	// Action parameters override the ones of the resource
+	declared := make(map[string]string)
+	for _, parameter := range resource.parameters {
+		if name, def, ok := i.member(parameter, true); ok {
+			declared[name] = def
+		}
+	}
+
+	request := &models.EndpointRequest{}
+	for _, item := range items {
+		switch blueprintKeyword(item.text) {
+		case "parameters":
+			for _, parameter := range item.children {
+				if name, def, ok := i.member(parameter, true); ok {
+					declared[name] = def
+				}
+			}
+
+		case "attributes":
+			request.Body = blueprintMerge(request.Body, i.attributes(item, 0))
+
+		case "request":
+			request.Body = blueprintMerge(request.Body, i.payload(item))
+			i.detectAuth(&endpoint, item)
+
+		case "response":
+			status, _ := strconv.Atoi(strings.Fields(item.text + " 200")[1])
+			i.response(&endpoint, resource, status, item)
+		}
+	}
+
+	for _, match := range blueprintTemplatePattern.FindAllStringSubmatch(path, -1) {
+		if request.Params == nil {
+			request.Params = make(map[string]string)
+		}
+		request.Params[match[2]] = "string, required"
+		if def, ok := declared[match[2]]; ok {
+			request.Params[match[2]] = def
+		}
+	}
+	for _, name := range query {
+		if request.Query == nil {
+			request.Query = make(map[string]string)
+		}
+		request.Query[name] = "string, optional"
+		if def, ok := declared[name]; ok {
+			request.Query[name] = def
+		}
+	}
+	if request.Params != nil || request.Query != nil || len(request.Body) > 0 {
+		endpoint.Request = request
+	}
+
+	return endpoint
+}
+
+// response applies a response to the endpoint: the first 2xx status sets the
+// response, 4xx/5xx statuses become declared errors
+func (i *BlueprintImporter) response(endpoint *models.Endpoint, resource *blueprintResource, status int, item *blueprintItem) {
+	switch {
+	case status >= 200 && status < 300:
+		if endpoint.Response != nil && endpoint.Response.Status != status {
+			return
+		}
+		if endpoint.Response == nil {
+			endpoint.Response = &models.EndpointResponse{Status: status}
+		}
+
+		body := i.payload(item)
+		if len(body) == 0 && resource.attributes != nil && endpoint.Method == "GET" {
+			body = i.attributes(resource.attributes, 0)
+		}
+		endpoint.Response.Body = blueprintMerge(endpoint.Response.Body, body)
+
+		for name, value := range blueprintHeaders(item) {
+			if ignoredResponseHeaders[strings.ToLower(name)] {
+				continue
+			}
+			if endpoint.Response.Headers == nil {
+				endpoint.Response.Headers = make(map[string]string)
+			}
+			var parsed interface{} = value
+			json.Unmarshal([]byte(value), &parsed)
+			endpoint.Response.Headers[name] = (&PostmanImporter{}).inferJSONFieldType(parsed) + ", required"
+		}
+
+	case status >= 400:
+		for _, declared := range endpoint.Errors {
+			if declared.Status == status {
+				return
+			}
+		}
+
+		var body interface{}
+		json.Unmarshal([]byte(blueprintBody(item)), &body)
+		code, message := trafficError(body)
+		if code == "" {
+			code = strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+		}
+		if message == "" {
+			message = http.StatusText(status)
+		}
+		endpoint.Errors = append(endpoint.Errors, models.ErrorResponse{Status: status, Code: code, Message: message})
+	}
+}
+
+// detectAuth marks endpoints whose requests carry credentials and records
+// the auth type of the API
+func (i *BlueprintImporter) detectAuth(endpoint *models.Endpoint, request *blueprintItem) {
+	for name, value := range blueprintHeaders(request) {
+		authType := ""
+		switch {
+		case strings.EqualFold(name, "Authorization"):
+			authType = "bearer"
+			if strings.HasPrefix(strings.ToLower(value), "basic") {
+				authType = "basic"
+			}
+		case strings.Contains(strings.ToLower(name), "api-key"), strings.Contains(strings.ToLower(name), "apikey"):
+			authType = "apikey"
+		}
+
+		if authType != "" {
+			endpoint.Auth = true
+			if i.authType == "" {
+				i.authType = authType
+			}
+		}
+	}
+}
+
+// payload extracts the fields of a request, response or model from its MSON
+// attributes, JSON schema or JSON body, in that order of preference
+func (i *BlueprintImporter) payload(item *blueprintItem) map[string]string {
+	var schema, body string
+	for _, child := range item.children {
+		switch blueprintKeyword(child.text) {
+		case "attributes":
+			if fields := i.attributes(child, 0); len(fields) > 0 {
+				return fields
+			}
+		case "schema":
+			schema = blueprintText(child.raw)
+		case "body":
+			body = blueprintText(child.raw)
+		}
+	}
+
+	if schema != "" {
+		var parsed map[string]interface{}
+		if json.Unmarshal([]byte(schema), &parsed) == nil {
+			if fields := (&OpenAPIImporter{}).parseSchemaProperties(parsed); len(fields) > 0 {
+				return fields
+			}
+		}
+	}
+
+	if body == "" {
+		body = blueprintText(item.raw)
+	}
+	if match := blueprintModelPattern.FindStringSubmatch(body); match != nil {
+		return i.models[match[1]]
+	}
+	return (&PostmanImporter{}).parseExampleBody(body)
+}
+
+// attributes resolves an "Attributes (Type)" section: the fields of the type
+// it is based on and its own members
+func (i *BlueprintImporter) attributes(item *blueprintItem, depth int) map[string]string {
+	_, base := blueprintTypeName(item.text)
+	return blueprintMerge(i.structureFields(base, depth), i.members(item.children, depth))
+}
+
+// members converts MSON member items to field definitions
+func (i *BlueprintImporter) members(items []*blueprintItem, depth int) map[string]string {
+	fields := make(map[string]string)
+	for _, item := range items {
+		text := strings.TrimSpace(item.text)
+		switch {
+		case strings.HasPrefix(text, "Include "):
+			fields = blueprintMerge(fields, i.structureFields(strings.TrimSpace(strings.TrimPrefix(text, "Include ")), depth))
+		case text == "Properties" || text == "Members":
+			fields = blueprintMerge(fields, i.members(item.children, depth))
+		default:
+			if name, def, ok := i.member(item, false); ok {
+				fields[name] = def
+			}
+		}
+	}
+	return fields
+}
+
+// structureFields returns the fields of a named data structure, including
+// the ones of the structure it is based on
+func (i *BlueprintImporter) structureFields(name string, depth int) map[string]string {
+	structure, ok := i.structures[name]
+	if !ok || depth > 10 {
+		return nil
+	}
+	return blueprintMerge(i.structureFields(structure.base, depth+1), i.members(structure.members, depth+1))
+}
+
+// member parses an MSON member or parameter such as
+// "email: jane@example.com (string, required) - Contact address". Members
+// are optional and parameters required unless stated otherwise.
+func (i *BlueprintImporter) member(item *blueprintItem, parameter bool) (string, string, bool) {
+	match := blueprintMemberPattern.FindStringSubmatch(strings.TrimSpace(item.text))
+	if match == nil {
+		return "", "", false
+	}
+	name := strings.Trim(strings.TrimSpace(match[1]), "`*")
+	sample := strings.Trim(strings.TrimSpace(match[2]), "`")
+	if name == "" {
+		return "", "", false
+	}
+
+	required := parameter
+	fieldType := ""
+	for _, attribute := range strings.Split(match[3], ",") {
+		attribute = strings.TrimSpace(attribute)
+		switch {
+		case attribute == "":
+		case attribute == "required":
+			required = true
+		case attribute == "optional":
+			required = false
+		case strings.HasPrefix(attribute, "`"), attribute == "fixed", attribute == "fixed-type",
+			attribute == "nullable", attribute == "sample", attribute == "default":
+		case fieldType == "":
+			fieldType = attribute
+		}
+	}
+
+	def := i.fieldType(fieldType, sample, len(item.children) > 0)
+	rule := ""
+	if def == "string" && (&PostmanImporter{}).looksLikeEmail(sample) {
+		rule = ", email"
+	}
+	if required {
+		return name, def + ", required" + rule, true
+	}
+	return name, def + ", optional" + rule, true
+}
+
+// fieldType maps an MSON type to one of our field types, using the sample
+// value to tell integers, UUIDs and timestamps apart
+func (i *BlueprintImporter) fieldType(mson, sample string, nested bool) string {
+	inferrer := &PostmanImporter{}
+	base := mson
+	if idx := strings.Index(base, "["); idx >= 0 {
+		base = base[:idx]
+	}
+
+	switch base {
+	case "string", "enum":
+		if inferrer.looksLikeUUID(sample) {
+			return "uuid"
+		}
+		if inferrer.looksLikeDateTime(sample) {
+			return "datetime"
+		}
+		return "string"
+	case "number":
+		if _, err := strconv.Atoi(sample); err == nil {
+			return "integer"
+		}
+		return "number"
+	case "boolean", "object", "array":
+		return base
+	case "":
+		if nested {
+			return "object"
+		}
+		if sample == "" {
+			return "string"
+		}
+		var value interface{}
+		if json.Unmarshal([]byte(sample), &value) == nil {
+			if _, ok := value.(float64); ok {
+				return i.fieldType("number", sample, false)
+			}
+			return inferrer.inferJSONFieldType(value)
+		}
+		return i.fieldType("string", sample, false)
+	}
+
+	// Named data structures based on a primitive type keep that type
+	for depth := 0; depth < 10; depth++ {
+		structure, ok := i.structures[base]
+		if !ok {
+			break
+		}
+		switch structure.base {
+		case "string", "number", "boolean", "array", "enum":
+			return i.fieldType(structure.base, sample, false)
+		}
+		base = structure.base
+	}
+	return "object"
+}
+
+// blueprintSection is a heading with the lines below it
+type blueprintSection struct {
+	level int
+	title string
+	body  []string
+}
+
+// description returns the first paragraph line of the section
+func (s blueprintSection) description() string {
+	for _, line := range s.body {
+		line = strings.TrimSpace(line)
+		if blueprintListPattern.MatchString(line) {
+			return ""
+		}
+		if line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// blueprintSections splits a document at its headings, ignoring "#" lines
+// in fenced and indented code blocks
+func blueprintSections(lines []string) []blueprintSection {
+	var sections []blueprintSection
+	fenced := false
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+		}
+		if match := blueprintHeadingPattern.FindStringSubmatch(line); match != nil && !fenced {
+			sections = append(sections, blueprintSection{level: len(match[1]), title: match[2]})
+			continue
+		}
+		if len(sections) > 0 {
+			sections[len(sections)-1].body = append(sections[len(sections)-1].body, line)
+		}
+	}
+	return sections
+}
+
+// blueprintOutline parses the nested list items of a section. A list line is
+// nested in the item above it when indented by up to four more spaces; lines
+// indented further, and fenced code, are the text of the item.
+func blueprintOutline(lines []string) []*blueprintItem {
+	var roots, stack []*blueprintItem
+	fenced := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			fenced = !fenced
+			continue
+		}
+
+		match := blueprintListPattern.FindStringSubmatch(line)
+		if match != nil && !fenced {
+			indent := len(match[1])
+			if len(stack) == 0 || indent <= stack[len(stack)-1].indent+5 {
+				item := &blueprintItem{text: strings.TrimSpace(match[2]), indent: indent}
+				for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+					stack = stack[:len(stack)-1]
+				}
+				if len(stack) == 0 {
+					roots = append(roots, item)
+				} else {
+					parent := stack[len(stack)-1]
+					parent.children = append(parent.children, item)
+				}
+				stack = append(stack, item)
+				continue
+			}
+		}
+
+		if len(stack) > 0 {
+			top := stack[len(stack)-1]
+			top.raw = append(top.raw, line)
+		}
+	}
+	return roots
+}
+
+// blueprintKeyword returns the lowercase keyword of a section item such as
+// "Response 200 (application/json)"
+func blueprintKeyword(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSuffix(fields[0], ":"))
+}
+
+// blueprintTypeName splits "User (object)" or "Attributes (User)" into the
+// name and the type in parentheses
+func blueprintTypeName(text string) (string, string) {
+	name, rest, found := strings.Cut(text, "(")
+	if !found {
+		return strings.TrimSpace(text), ""
+	}
+	base, _, _ := strings.Cut(rest, ")")
+	base, _, _ = strings.Cut(base, ",")
+	return strings.TrimSpace(name), strings.TrimSpace(base)
+}
+
+// blueprintBody returns the body of a request or response, given directly
+// below it or in a Body section
+func blueprintBody(item *blueprintItem) string {
+	for _, child := range item.children {
+		if blueprintKeyword(child.text) == "body" {
+			return blueprintText(child.raw)
+		}
+	}
+	return blueprintText(item.raw)
+}
+
+// blueprintHeaders reads the "Name: value" lines of a Headers section
+func blueprintHeaders(item *blueprintItem) map[string]string {
+	headers := make(map[string]string)
+	for _, child := range item.children {
+		if blueprintKeyword(child.text) != "headers" {
+			continue
+		}
+		for _, line := range child.raw {
+			if name, value, ok := strings.Cut(strings.TrimSpace(line), ":"); ok {
+				headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	return headers
+}
+
+// blueprintText removes the common indentation and surrounding blank lines
+// of the text below an item
+func blueprintText(lines []string) string {
+	indent := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		width := len(line) - len(strings.TrimLeft(line, " "))
+		if indent < 0 || width < indent {
+			indent = width
+		}
+	}
+
+	var result []string
+	for _, line := range lines {
+		if len(line) >= indent && indent > 0 {
+			line = line[indent:]
+		}
+		result = append(result, line)
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
+
+// blueprintPath converts a URI template such as /users/{id}{?page,limit} to
+// a path and the names of its query parameters
+func blueprintPath(template string) (string, []string) {
+	var query []string
+	path := blueprintTemplatePattern.ReplaceAllStringFunc(template, func(expression string) string {
+		match := blueprintTemplatePattern.FindStringSubmatch(expression)
+		var names []string
+		for _, name := range strings.Split(match[2], ",") {
+			name = strings.TrimSuffix(strings.TrimSpace(name), "*")
+			name, _, _ = strings.Cut(name, ":")
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+
+		switch match[1] {
+		case "?", "&":
+			query = append(query, names...)
+			return ""
+		case "#":
+			return ""
+		}
+		var segments []string
+		for _, name := range names {
+			segments = append(segments, "{"+name+"}")
+		}
+		// Path segment expansion, {/folder,name}, brings its own slashes
+		if match[1] == "/" {
+			return "/" + strings.Join(segments, "/")
+		}
+		return strings.Join(segments, "/")
+	})
+	return path, query
+}
+
+// blueprintBasePath extracts the path of the HOST metadata
+func blueprintBasePath(host string) string {
+	if idx := strings.Index(host, "://"); idx >= 0 {
+		host = host[idx+3:]
+		slash := strings.Index(host, "/")
+		if slash < 0 {
+			return ""
+		}
+		host = host[slash:]
+	}
+	return strings.TrimSuffix(host, "/")
+}
+
+// blueprintMerge adds the fields of extra to fields
+func blueprintMerge(fields, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return fields
+	}
+	if fields == nil {
+		fields = make(map[string]string)
+	}
+	for name, def := range extra {
+		fields[name] = def
+	}
+	return fields
+}
@@ -0,0 +1,91 @@
+package importers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestBlueprintImporterFixture(t *testing.T) {
+	api, err := (&BlueprintImporter{}).Import("testdata/shop.apib")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api.BaseURL != "/v1" || api.AuthType != "bearer" {
+		t.Errorf("base_url = %q, auth_type = %q", api.BaseURL, api.AuthType)
+	}
+
+	endpoints := endpointsByRoute(api)
+	want := []string{"DELETE /orders/{id}", "GET /orders", "GET /orders/{id}", "POST /orders"}
+	if got := routes(endpoints); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("endpoints = %v, want %v", got, want)
+	}
+
+	list := endpoints["GET /orders"]
+	if !list.Auth || list.Description != "List Orders" {
+		t.Errorf("list = %+v", list)
+	}
+	if !reflect.DeepEqual(list.Request.Query, map[string]string{"status": "string, optional", "page": "integer, optional"}) {
+		t.Errorf("query = %v", list.Request.Query)
+	}
+	// Transport headers such as Content-Type are not part of the contract
+	if !reflect.DeepEqual(list.Response.Headers, map[string]string{"X-Total-Count": "number, required"}) {
+		t.Errorf("headers = %v", list.Response.Headers)
+	}
+
+	// Attributes include the members of the base structure and the ones
+	// declared inline
+	order := map[string]string{
+		"id": "uuid, required", "created_at": "datetime, optional", "quantity": "integer, required",
+		"customer": "object, optional", "notes": "string, optional",
+	}
+	create := endpoints["POST /orders"]
+	wantBody := map[string]string{"sku": "string, required"}
+	for name, def := range order {
+		wantBody[name] = def
+	}
+	if !reflect.DeepEqual(create.Request.Body, wantBody) {
+		t.Errorf("request body = %v, want %v", create.Request.Body, wantBody)
+	}
+	if create.Response.Status != 201 || !reflect.DeepEqual(create.Response.Body, order) {
+		t.Errorf("response = %+v", create.Response)
+	}
+	if !reflect.DeepEqual(create.Errors, []models.ErrorResponse{{Status: 422, Code: "VALIDATION_ERROR", Message: "quantity must be positive"}}) {
+		t.Errorf("errors = %+v", create.Errors)
+	}
+
+	// GET responses without a body use the resource attributes
+	get := endpoints["GET /orders/{id}"]
+	if get.Auth || get.Request.Params["id"] != "uuid, required" || !reflect.DeepEqual(get.Response.Body, order) {
+		t.Errorf("get = %+v %+v", get.Request, get.Response)
+	}
+	if !reflect.DeepEqual(get.Errors, []models.ErrorResponse{{Status: 404, Code: "NOT_FOUND", Message: "Not Found"}}) {
+		t.Errorf("errors = %+v", get.Errors)
+	}
+
+	if cancel := endpoints["DELETE /orders/{id}"]; !cancel.Auth || cancel.Response.Status != 204 {
+		t.Errorf("api key request not detected as authenticated: %+v", cancel)
+	}
+}
+
+func TestBlueprintPath(t *testing.T) {
+	tests := []struct {
+		template string
+		path     string
+		query    []string
+	}{
+		{"/orders", "/orders", nil},
+		{"/orders/{id}", "/orders/{id}", nil},
+		{"/orders{?status,page}", "/orders", []string{"status", "page"}},
+		{"/orders/{id}{?expand*}{&fields}", "/orders/{id}", []string{"expand", "fields"}},
+		{"/files{/folder,name}{#section}", "/files/{folder}/{name}", nil},
+	}
+	for _, tt := range tests {
+		path, query := blueprintPath(tt.template)
+		if path != tt.path || !reflect.DeepEqual(query, tt.query) {
+			t.Errorf("blueprintPath(%q) = %q, %v, want %q, %v", tt.template, path, query, tt.path, tt.query)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package importers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// CurlImporter handles importing curl commands, one or more per file, as
+// pasted from tickets, docs or browser devtools ("Copy as cURL"). The
+// filename "-" reads the commands from stdin.
+type CurlImporter struct{}
+
+// CurlCommand is a parsed curl invocation
+type CurlCommand struct {
+	Method  string
+	URL     string
+	Headers http.Header
+	Body    []byte
+	// Form holds -F fields; file uploads (-F file=@photo.jpg) are recorded
+	// with the file name as value
+	Form map[string]string
+	// User holds -u credentials
+	User string
+}
+
+// Import parses curl commands and converts them to our internal API model.
+// Bodies, path parameters and auth are inferred like recorded traffic.
+func (i *CurlImporter) Import(filename string) (*models.API, error) {
+	var content []byte
+	var err error
+	if filename == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read curl commands: %w", err)
+	}
+
+	commands, err := ParseCurlCommands(string(content))
+	if err != nil {
+		return nil, err
+	}
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("no curl commands found")
+	}
+
+	learner := NewTrafficLearner()
+	for _, command := range commands {
+		record := TrafficRecord{
+			Method:         command.Method,
+			URL:            command.URL,
+			RequestHeaders: command.Headers,
+			RequestBody:    command.Body,
+		}
+		if command.User != "" && record.RequestHeaders.Get("Authorization") == "" {
+			record.RequestHeaders.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(command.User)))
+		}
+		if len(command.Form) > 0 {
+			record.RequestBody, _ = json.Marshal(command.Form)
+		}
+		if err := learner.Add(record); err != nil {
+			return nil, err
+		}
+	}
+
+	api := learner.API()
+
+	// Commands carry no responses; use the defaults of the other importers
+	for idx, endpoint := range api.Endpoints {
+		api.Endpoints[idx].Description = "Imported from curl"
+		if endpoint.Response != nil {
+			continue
+		}
+		status := 200
+		switch endpoint.Method {
+		case "POST":
+			status = 201
+		case "DELETE":
+			status = 204
+		}
+		api.Endpoints[idx].Response = &models.EndpointResponse{Status: status}
+	}
+
+	// Paths are kept absolute so that merging into an existing specification
+	// keeps its base URL
+	api.BaseURL = ""
+	return api, nil
+}
+
+// Validate checks if the imported API is valid
+func (i *CurlImporter) Validate(api *models.API) error {
+	if api == nil {
+		return fmt.Errorf("API cannot be nil")
+	}
+
+	for idx, endpoint := range api.Endpoints {
+		if endpoint.Path == "" {
+			return fmt.Errorf("endpoint %d: path is required", idx)
+		}
+		if endpoint.Method == "" {
+			return fmt.Errorf("endpoint %d: method is required", idx)
+		}
+	}
+
+	return nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (i *CurlImporter) GetSupportedExtensions() []string {
+	return []string{".sh", ".curl", ".txt"}
+}
+
+// curlFlagsWithValue lists curl options that take an argument which is not
+// relevant to the specification
+var curlFlagsWithValue = map[string]bool{
+	"-o": true, "--output": true, "-A": true, "--user-agent": true, "-e": true, "--referer": true,
+	"-b": true, "--cookie": true, "-c": true, "--cookie-jar": true, "-m": true, "--max-time": true,
+	"--connect-timeout": true, "-w": true, "--write-out": true, "--cacert": true, "--cert": true,
+	"--key": true, "-x": true, "--proxy": true, "--retry": true, "--resolve": true, "-T": true,
+	"--upload-file": true, "-r": true, "--range": true, "--max-redirs": true, "--limit-rate": true,
+}
+
+// ParseCurlCommands parses every curl command in a script. Commands may span
+// several lines joined with a trailing backslash, and lines that do not
+// start a curl command are ignored.
+func ParseCurlCommands(script string) ([]CurlCommand, error) {
+	// Join continuation lines (both shell "\" and Windows cmd "^")
+	script = strings.ReplaceAll(script, "\r\n", "\n")
+	script = strings.ReplaceAll(script, "\\\n", " ")
+	script = strings.ReplaceAll(script, "^\n", " ")
+
+	var commands []CurlCommand
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "$ ")
+		if !strings.HasPrefix(line, "curl ") {
+			continue
+		}
+
+		args, err := splitShellWords(line)
+		if err != nil {
+			return nil, fmt.Errorf("curl command %d: %w", len(commands)+1, err)
+		}
+		command, err := parseCurlArgs(args[1:])
+		if err != nil {
+			return nil, fmt.Errorf("curl command %d: %w", len(commands)+1, err)
+		}
+		commands = append(commands, command)
+	}
+	return commands, nil
+}
+
+// parseCurlArgs interprets the arguments of a single curl command
+func parseCurlArgs(args []string) (CurlCommand, error) {
+	command := CurlCommand{Headers: make(http.Header)}
+	var data []string
+	get := false
+
+	for idx := 0; idx < len(args); idx++ {
+		arg := args[idx]
+
+		// --flag=value form
+		name, inline, hasInline := strings.Cut(arg, "=")
+		if !strings.HasPrefix(arg, "--") || !hasInline {
+			name, inline = arg, ""
+		}
+		value := func() (string, error) {
+			if hasInline && strings.HasPrefix(arg, "--") {
+				return inline, nil
+			}
+			// Short flags may be glued to their value: -XPOST, -H'Accept: x'
+			if len(arg) > 2 && !strings.HasPrefix(arg, "--") {
+				return arg[2:], nil
+			}
+			if idx+1 >= len(args) {
+				return "", fmt.Errorf("missing value for %s", arg)
+			}
+			idx++
+			return args[idx], nil
+		}
+		if len(arg) > 2 && arg[0] == '-' && arg[1] != '-' {
+			name = arg[:2]
+		}
+
+		switch name {
+		case "-X", "--request":
+			v, err := value()
+			if err != nil {
+				return command, err
+			}
+			command.Method = strings.ToUpper(v)
+		case "-H", "--header":
+			v, err := value()
+			if err != nil {
+				return command, err
+			}
+			if key, val, ok := strings.Cut(v, ":"); ok {
+				command.Headers.Add(strings.TrimSpace(key), strings.TrimSpace(val))
+			}
+		case "-d", "--data", "--data-raw", "--data-binary", "--data-ascii", "--data-urlencode":
+			v, err := value()
+			if err != nil {
+				return command, err
+			}
+			data = append(data, v)
+		case "--json":
+			v, err := value()
+			if err != nil {
+				return command, err
+			}
+			data = append(data, v)
+			if command.Headers.Get("Content-Type") == "" {
+				command.Headers.Set("Content-Type", "application/json")
+			}
+		case "-F", "--form", "--form-string":
+			v, err := value()
+			if err != nil {
+				return command, err
+			}
+			if key, val, ok := strings.Cut(v, "="); ok {
+				if command.Form == nil {
+					command.Form = make(map[string]string)
+				}
+				command.Form[key] = strings.TrimPrefix(strings.TrimPrefix(val, "@"), "<")
+			}
+		case "-u", "--user":
+			v, err := value()
+			if err != nil {
+				return command, err
+			}
+			command.User = v
+		case "--oauth2-bearer":
+			v, err := value()
+			if err != nil {
+				return command, err
+			}
+			command.Headers.Set("Authorization", "Bearer "+v)
+		case "--url":
+			v, err := value()
+			if err != nil {
+				return command, err
+			}
+			command.URL = v
+		case "-G", "--get":
+			get = true
+		case "-I", "--head":
+			command.Method = "HEAD"
+		default:
+			if curlFlagsWithValue[name] {
+				if _, err := value(); err != nil {
+					return command, err
+				}
+				continue
+			}
+			if !strings.HasPrefix(arg, "-") && command.URL == "" {
+				command.URL = arg
+			}
+		}
+	}
+
+	if command.URL == "" {
+		return command, fmt.Errorf("curl command without URL")
+	}
+	if !strings.Contains(command.URL, "://") {
+		command.URL = "http://" + command.URL
+	}
+
+	body := strings.Join(data, "&")
+	switch {
+	case get:
+		// -G sends the data as query string
+		if body != "" {
+			separator := "?"
+			if strings.Contains(command.URL, "?") {
+				separator = "&"
+			}
+			command.URL += separator + body
+		}
+	case len(data) == 1 && json.Valid([]byte(strings.TrimSpace(data[0]))):
+		command.Body = []byte(data[0])
+	case body != "":
+		// Form encoded data is recorded as a JSON object of strings
+		if values, err := url.ParseQuery(body); err == nil {
+			form := make(map[string]string, len(values))
+			for key := range values {
+				form[key] = values.Get(key)
+			}
+			command.Body, _ = json.Marshal(form)
+		}
+	}
+
+	if command.Method == "" {
+		command.Method = "GET"
+		if !get && (len(data) > 0 || len(command.Form) > 0) {
+			command.Method = "POST"
+		}
+	}
+	return command, nil
+}
+
+// splitShellWords splits a command line into words following POSIX shell
+// quoting: single quotes, double quotes, $'...' strings and backslash escapes
+func splitShellWords(line string) ([]string, error) {
+	var words []string
+	var current strings.Builder
+	inWord := false
+
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == ' ' || c == '\t':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		case c == '\'':
+			end := strings.IndexByte(line[i+1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+			current.WriteString(line[i+1 : i+1+end])
+			i += end + 1
+			inWord = true
+		case c == '$' && i+1 < len(line) && line[i+1] == '\'':
+			// ANSI-C quoting, used by Chrome's "Copy as cURL"
+			i += 2
+			for ; i < len(line) && line[i] != '\''; i++ {
+				if line[i] == '\\' && i+1 < len(line) {
+					i++
+					switch line[i] {
+					case 'n':
+						current.WriteByte('\n')
+					case 't':
+						current.WriteByte('\t')
+					default:
+						current.WriteByte(line[i])
+					}
+					continue
+				}
+				current.WriteByte(line[i])
+			}
+			if i >= len(line) {
+				return nil, fmt.Errorf("unterminated $' quote")
+			}
+			inWord = true
+		case c == '"':
+			i++
+			for ; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) && strings.IndexByte("\"\\$`", line[i+1]) >= 0 {
+					i++
+				}
+				current.WriteByte(line[i])
+			}
+			if i >= len(line) {
+				return nil, fmt.Errorf("unterminated double quote")
+			}
+			inWord = true
+		case c == '\\' && i+1 < len(line):
+			i++
+			current.WriteByte(line[i])
+			inWord = true
+		default:
+			current.WriteByte(c)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words, nil
+}
@@ -0,0 +1,109 @@
+package importers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCurlImporterFixture(t *testing.T) {
+	api, err := (&CurlImporter{}).Import("testdata/orders.curl")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	endpoints := endpointsByRoute(api)
+	want := []string{
+		"GET /v1/orders/archived", "GET /v1/orders/export", "GET /v1/orders/pending",
+		"GET /v1/orders/summary", "GET /v1/orders/{id}", "POST /v1/orders",
+	}
+	if got := routes(endpoints); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("endpoints = %v, want %v", got, want)
+	}
+
+	create := endpoints["POST /v1/orders"]
+	if create.Request == nil || create.Request.Body["sku"] == "" || create.Request.Body["quantity"] == "" {
+		t.Errorf("request body not inferred: %+v", create.Request)
+	}
+	if create.Response == nil || create.Response.Status != 201 {
+		t.Errorf("response = %+v, want the default 201", create.Response)
+	}
+	if !create.Auth {
+		t.Error("bearer auth not detected")
+	}
+	if api.BaseURL != "" {
+		t.Errorf("base_url = %q, want paths kept absolute", api.BaseURL)
+	}
+}
+
+func TestParseCurlCommands(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   CurlCommand
+	}{
+		{
+			name:   "json body implies POST",
+			script: `curl https://api.example.com/users -H 'Content-Type: application/json' -d '{"name": "a"}'`,
+			want:   CurlCommand{Method: "POST", URL: "https://api.example.com/users", Body: []byte(`{"name": "a"}`)},
+		},
+		{
+			name:   "continuation lines and glued method",
+			script: "$ curl -XPUT \\\n  --url=https://api.example.com/users/1 \\\n  --json '{\"name\": \"b\"}'",
+			want:   CurlCommand{Method: "PUT", URL: "https://api.example.com/users/1", Body: []byte(`{"name": "b"}`)},
+		},
+		{
+			name:   "form encoded data becomes a JSON object",
+			script: `curl api.example.com/login --data-urlencode user=a -d pass=b`,
+			want:   CurlCommand{Method: "POST", URL: "http://api.example.com/login", Body: []byte(`{"pass":"b","user":"a"}`)},
+		},
+		{
+			name:   "get sends data as query",
+			script: `curl -G https://api.example.com/search?sort=asc -d q=go`,
+			want:   CurlCommand{Method: "GET", URL: "https://api.example.com/search?sort=asc&q=go"},
+		},
+		{
+			name:   "multipart fields and uploads",
+			script: `curl -F title=Photo -F file=@photo.jpg https://api.example.com/uploads`,
+			want:   CurlCommand{Method: "POST", URL: "https://api.example.com/uploads", Form: map[string]string{"title": "Photo", "file": "photo.jpg"}},
+		},
+		{
+			name:   "credentials and ignored options",
+			script: `curl -s -o out.json -u admin:secret -A agent https://api.example.com/admin`,
+			want:   CurlCommand{Method: "GET", URL: "https://api.example.com/admin", User: "admin:secret"},
+		},
+		{
+			name:   "chrome ansi-c quoting",
+			script: `curl 'https://api.example.com/notes' --data-raw $'{"text": "it\'s"}'`,
+			want:   CurlCommand{Method: "POST", URL: "https://api.example.com/notes", Body: []byte(`{"text": "it's"}`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands, err := ParseCurlCommands(tt.script)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(commands) != 1 {
+				t.Fatalf("parsed %d commands, want 1", len(commands))
+			}
+			got := commands[0]
+			got.Headers = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsed %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCurlCommandsErrors(t *testing.T) {
+	for _, script := range []string{
+		`curl -H 'Accept: application/json`,
+		`curl -X`,
+		`curl -H 'Accept: application/json'`,
+	} {
+		if _, err := ParseCurlCommands(script); err == nil {
+			t.Errorf("ParseCurlCommands(%q) succeeded, want an error", script)
+		}
+	}
+}
@@ -0,0 +1,678 @@
+package importers
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// GraphQLImporter handles importing GraphQL schemas written in the schema
+// definition language (SDL). Fields of the root types become operations and
+// the remaining named types are kept in GraphQL notation.
+type GraphQLImporter struct{}
+
+// Import parses a GraphQL schema file and converts it to our internal API model
+func (i *GraphQLImporter) Import(filename string) (*models.API, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	schema, err := ParseGraphQLSchema(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL schema: %w", err)
+	}
+	if len(schema.Operations) == 0 {
+		return nil, fmt.Errorf("no queries, mutations or subscriptions found")
+	}
+
+	return &models.API{
+		Endpoints: []models.Endpoint{},
+		GraphQL:   schema,
+	}, nil
+}
+
+// Validate checks if the imported API is valid
+func (i *GraphQLImporter) Validate(api *models.API) error {
+	if api == nil {
+		return fmt.Errorf("API cannot be nil")
+	}
+	if api.GraphQL == nil {
+		return fmt.Errorf("GraphQL schema is required")
+	}
+
+	for idx, operation := range api.GraphQL.Operations {
+		if operation.Name == "" {
+			return fmt.Errorf("operation %d: name is required", idx)
+		}
+		if operation.Output == "" {
+			return fmt.Errorf("operation %s: output type is required", operation.Name)
+		}
+	}
+
+	return nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (i *GraphQLImporter) GetSupportedExtensions() []string {
+	return []string{".graphql", ".graphqls", ".gql"}
+}
+
+// graphqlField is a field of an object, interface or input type
+type graphqlField struct {
+	name        string
+	description string
+	args        []graphqlArgument
+	typ         string
+}
+
+type graphqlArgument struct {
+	name         string
+	typ          string
+	defaultValue string
+}
+
+// definition renders the argument type and default value: "Int = 10"
+func (a graphqlArgument) definition() string {
+	if a.defaultValue != "" {
+		return a.typ + " = " + a.defaultValue
+	}
+	return a.typ
+}
+
+// ParseGraphQLSchema parses a schema in SDL. Type extensions are merged into
+// the extended type and directives are ignored.
+func ParseGraphQLSchema(sdl string) (*models.GraphQL, error) {
+	tokens, err := lexGraphQL(sdl)
+	if err != nil {
+		return nil, err
+	}
+	p := &graphqlParser{tokens: tokens}
+
+	roots := map[string]string{"query": "Query", "mutation": "Mutation", "subscription": "Subscription"}
+	schemaDefined := false
+	types := make(map[string]*models.GraphQLType)
+	fields := make(map[string][]graphqlField)
+	var order []string
+
+	named := func(name, kind string) *models.GraphQLType {
+		if types[name] == nil {
+			types[name] = &models.GraphQLType{Name: name}
+			order = append(order, name)
+		}
+		if kind != "" {
+			types[name].Kind = kind
+		}
+		return types[name]
+	}
+
+	for p.peek().kind != graphqlEOF {
+		description := p.description()
+		extend := p.isName("extend")
+		if extend {
+			p.next()
+		}
+		keyword, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		switch keyword {
+		case "schema":
+			// A schema definition names every root type; extensions add to it
+			if !extend && !schemaDefined {
+				roots = make(map[string]string)
+			}
+			schemaDefined = true
+			p.skipDirectives()
+			if err := p.expect("{"); err != nil {
+				return nil, err
+			}
+			for !p.is("}") {
+				operation, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				if roots[operation], err = p.name(); err != nil {
+					return nil, err
+				}
+			}
+			p.next()
+
+		case "type", "interface", "input":
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			definition := named(name, keyword)
+			if description != "" {
+				definition.Description = description
+			}
+			if p.isName("implements") {
+				p.next()
+				if p.is("&") {
+					p.next()
+				}
+				for {
+					iface, err := p.name()
+					if err != nil {
+						return nil, err
+					}
+					definition.Implements = append(definition.Implements, iface)
+					if !p.is("&") {
+						break
+					}
+					p.next()
+				}
+			}
+			p.skipDirectives()
+			if p.is("{") {
+				parsed, err := p.fields()
+				if err != nil {
+					return nil, err
+				}
+				fields[name] = append(fields[name], parsed...)
+			}
+
+		case "enum":
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			definition := named(name, keyword)
+			if description != "" {
+				definition.Description = description
+			}
+			p.skipDirectives()
+			if p.is("{") {
+				p.next()
+				for !p.is("}") {
+					p.description()
+					value, err := p.name()
+					if err != nil {
+						return nil, err
+					}
+					p.skipDirectives()
+					definition.Values = append(definition.Values, value)
+				}
+				p.next()
+			}
+
+		case "union":
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			definition := named(name, keyword)
+			if description != "" {
+				definition.Description = description
+			}
+			p.skipDirectives()
+			if p.is("=") {
+				p.next()
+				if p.is("|") {
+					p.next()
+				}
+				for {
+					member, err := p.name()
+					if err != nil {
+						return nil, err
+					}
+					definition.Values = append(definition.Values, member)
+					if !p.is("|") {
+						break
+					}
+					p.next()
+				}
+			}
+
+		case "scalar":
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			definition := named(name, keyword)
+			if description != "" {
+				definition.Description = description
+			}
+			p.skipDirectives()
+
+		case "directive":
+			// Directive definitions say nothing about the operations
+			if err := p.expect("@"); err != nil {
+				return nil, err
+			}
+			if _, err := p.name(); err != nil {
+				return nil, err
+			}
+			if p.is("(") {
+				if _, err := p.arguments(); err != nil {
+					return nil, err
+				}
+			}
+			if p.isName("repeatable") {
+				p.next()
+			}
+			if !p.isName("on") {
+				return nil, p.unexpected("on")
+			}
+			p.next()
+			if p.is("|") {
+				p.next()
+			}
+			for {
+				if _, err := p.name(); err != nil {
+					return nil, err
+				}
+				if !p.is("|") {
+					break
+				}
+				p.next()
+			}
+
+		default:
+			return nil, fmt.Errorf("line %d: unexpected %q", p.tokens[p.pos-1].line, keyword)
+		}
+	}
+
+	schema := &models.GraphQL{Path: "/graphql", Operations: []models.GraphQLOperation{}}
+	rootTypes := make(map[string]string)
+	for _, operation := range []string{"query", "mutation", "subscription"} {
+		rootTypes[roots[operation]] = operation
+		for _, field := range fields[roots[operation]] {
+			op := models.GraphQLOperation{
+				Name:        field.name,
+				Type:        operation,
+				Description: field.description,
+				Output:      field.typ,
+			}
+			for _, arg := range field.args {
+				if op.Input == nil {
+					op.Input = make(map[string]string)
+				}
+				op.Input[arg.name] = arg.definition()
+			}
+			schema.Operations = append(schema.Operations, op)
+		}
+	}
+
+	for _, name := range order {
+		if _, root := rootTypes[name]; root {
+			continue
+		}
+		definition := *types[name]
+		for _, field := range fields[name] {
+			if definition.Fields == nil {
+				definition.Fields = make(map[string]string)
+			}
+			definition.Fields[field.name] = field.typ
+			for _, arg := range field.args {
+				if definition.Arguments == nil {
+					definition.Arguments = make(map[string]map[string]string)
+				}
+				if definition.Arguments[field.name] == nil {
+					definition.Arguments[field.name] = make(map[string]string)
+				}
+				definition.Arguments[field.name][arg.name] = arg.definition()
+			}
+		}
+		schema.Types = append(schema.Types, definition)
+	}
+
+	return schema, nil
+}
+
+const (
+	graphqlEOF = iota
+	graphqlName
+	graphqlString
+	graphqlNumber
+	graphqlPunctuator
+)
+
+type graphqlToken struct {
+	kind  int
+	value string
+	line  int
+}
+
+// lexGraphQL splits a schema into tokens, dropping comments and commas
+func lexGraphQL(src string) ([]graphqlToken, error) {
+	var tokens []graphqlToken
+	line := 1
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], `"""`):
+			end := strings.Index(src[i+3:], `"""`)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated block string", line)
+			}
+			text := src[i+3 : i+3+end]
+			tokens = append(tokens, graphqlToken{kind: graphqlString, value: dedentBlockString(text), line: line})
+			line += strings.Count(text, "\n")
+			i += end + 6
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) || src[j] != '"' {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			value, err := strconv.Unquote(src[i : j+1])
+			if err != nil {
+				value = src[i+1 : j]
+			}
+			tokens = append(tokens, graphqlToken{kind: graphqlString, value: value, line: line})
+			i = j + 1
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			j := i + 1
+			for j < len(src) && (src[j] == '_' || src[j] >= 'a' && src[j] <= 'z' || src[j] >= 'A' && src[j] <= 'Z' || src[j] >= '0' && src[j] <= '9') {
+				j++
+			}
+			tokens = append(tokens, graphqlToken{kind: graphqlName, value: src[i:j], line: line})
+			i = j
+		case c == '-' || c >= '0' && c <= '9':
+			j := i + 1
+			for j < len(src) && strings.IndexByte("0123456789.eE+-", src[j]) >= 0 {
+				j++
+			}
+			tokens = append(tokens, graphqlToken{kind: graphqlNumber, value: src[i:j], line: line})
+			i = j
+		case strings.HasPrefix(src[i:], "..."):
+			tokens = append(tokens, graphqlToken{kind: graphqlPunctuator, value: "...", line: line})
+			i += 3
+		case strings.IndexByte("!$&()/:=@[]{|}", c) >= 0:
+			tokens = append(tokens, graphqlToken{kind: graphqlPunctuator, value: string(c), line: line})
+			i++
+		case c == 0xEF && strings.HasPrefix(src[i:], "\uFEFF"):
+			i += 3
+		default:
+			return nil, fmt.Errorf("line %d: unexpected character %q", line, c)
+		}
+	}
+
+	return append(tokens, graphqlToken{kind: graphqlEOF, line: line}), nil
+}
+
+// dedentBlockString removes the common indentation and surrounding blank
+// lines of a block string
+func dedentBlockString(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	indent := -1
+	for idx, l := range lines {
+		trimmed := strings.TrimLeft(l, " \t")
+		if idx == 0 || trimmed == "" {
+			continue
+		}
+		if width := len(l) - len(trimmed); indent < 0 || width < indent {
+			indent = width
+		}
+	}
+	for idx := range lines {
+		if idx > 0 && indent > 0 && len(lines[idx]) >= indent {
+			lines[idx] = lines[idx][indent:]
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+type graphqlParser struct {
+	tokens []graphqlToken
+	pos    int
+}
+
+func (p *graphqlParser) peek() graphqlToken {
+	return p.tokens[p.pos]
+}
+
+func (p *graphqlParser) next() graphqlToken {
+	token := p.tokens[p.pos]
+	if token.kind != graphqlEOF {
+		p.pos++
+	}
+	return token
+}
+
+// is reports whether the next token is the given punctuator
+func (p *graphqlParser) is(punctuator string) bool {
+	token := p.peek()
+	return token.kind == graphqlPunctuator && token.value == punctuator
+}
+
+// isName reports whether the next token is the given keyword
+func (p *graphqlParser) isName(keyword string) bool {
+	token := p.peek()
+	return token.kind == graphqlName && token.value == keyword
+}
+
+func (p *graphqlParser) unexpected(expected string) error {
+	token := p.peek()
+	if token.kind == graphqlEOF {
+		return fmt.Errorf("line %d: expected %s, found end of schema", token.line, expected)
+	}
+	return fmt.Errorf("line %d: expected %s, found %q", token.line, expected, token.value)
+}
+
+func (p *graphqlParser) expect(punctuator string) error {
+	if !p.is(punctuator) {
+		return p.unexpected(strconv.Quote(punctuator))
+	}
+	p.next()
+	return nil
+}
+
+func (p *graphqlParser) name() (string, error) {
+	if p.peek().kind != graphqlName {
+		return "", p.unexpected("a name")
+	}
+	return p.next().value, nil
+}
+
+// description consumes an optional description string
+func (p *graphqlParser) description() string {
+	if p.peek().kind == graphqlString {
+		return p.next().value
+	}
+	return ""
+}
+
+// skipDirectives consumes directives such as @deprecated(reason: "...")
+func (p *graphqlParser) skipDirectives() {
+	for p.is("@") {
+		p.next()
+		p.next()
+		if !p.is("(") {
+			continue
+		}
+		depth := 0
+		for {
+			token := p.next()
+			if token.kind == graphqlEOF {
+				return
+			}
+			if token.kind == graphqlPunctuator && token.value == "(" {
+				depth++
+			}
+			if token.kind == graphqlPunctuator && token.value == ")" {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+	}
+}
+
+// fields parses a field list enclosed in braces
+func (p *graphqlParser) fields() ([]graphqlField, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	var fields []graphqlField
+	for !p.is("}") {
+		field := graphqlField{description: p.description()}
+		var err error
+		if field.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if p.is("(") {
+			if field.args, err = p.arguments(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if field.typ, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		// Input fields may have a default value
+		if p.is("=") {
+			p.next()
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			field.typ += " = " + value
+		}
+		p.skipDirectives()
+		fields = append(fields, field)
+	}
+	p.next()
+
+	return fields, nil
+}
+
+// arguments parses an argument list enclosed in parentheses
+func (p *graphqlParser) arguments() ([]graphqlArgument, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var args []graphqlArgument
+	for !p.is(")") {
+		p.description()
+		arg := graphqlArgument{}
+		var err error
+		if arg.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if arg.typ, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		if p.is("=") {
+			p.next()
+			if arg.defaultValue, err = p.value(); err != nil {
+				return nil, err
+			}
+		}
+		p.skipDirectives()
+		args = append(args, arg)
+	}
+	p.next()
+
+	return args, nil
+}
+
+// typeRef parses a type reference such as [Post!]!
+func (p *graphqlParser) typeRef() (string, error) {
+	var typ string
+	if p.is("[") {
+		p.next()
+		inner, err := p.typeRef()
+		if err != nil {
+			return "", err
+		}
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + inner + "]"
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return "", err
+		}
+		typ = name
+	}
+
+	if p.is("!") {
+		p.next()
+		typ += "!"
+	}
+	return typ, nil
+}
+
+// value parses a default value and renders it back in GraphQL notation
+func (p *graphqlParser) value() (string, error) {
+	token := p.peek()
+	switch {
+	case token.kind == graphqlString:
+		p.next()
+		return strconv.Quote(token.value), nil
+	case token.kind == graphqlName || token.kind == graphqlNumber:
+		p.next()
+		return token.value, nil
+	case p.is("$"):
+		p.next()
+		name, err := p.name()
+		return "$" + name, err
+	case p.is("["):
+		p.next()
+		var items []string
+		for !p.is("]") {
+			item, err := p.value()
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		p.next()
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case p.is("{"):
+		p.next()
+		var entries []string
+		for !p.is("}") {
+			name, err := p.name()
+			if err != nil {
+				return "", err
+			}
+			if err := p.expect(":"); err != nil {
+				return "", err
+			}
+			item, err := p.value()
+			if err != nil {
+				return "", err
+			}
+			entries = append(entries, name+": "+item)
+		}
+		p.next()
+		return "{" + strings.Join(entries, ", ") + "}", nil
+	default:
+		return "", p.unexpected("a value")
+	}
+}
@@ -0,0 +1,88 @@
+package importers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestGraphQLImporterFixture(t *testing.T) {
+	api, err := (&GraphQLImporter{}).Import("testdata/blog.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := api.GraphQL
+	if schema == nil || schema.Path != "/graphql" {
+		t.Fatalf("graphql = %+v", schema)
+	}
+
+	wantOperations := []models.GraphQLOperation{
+		{Name: "user", Type: "query", Description: "Look up a user", Input: map[string]string{"id": "ID!"}, Output: "User"},
+		{Name: "search", Type: "query", Input: map[string]string{"term": "String!", "filter": `PostInput = {title: "x"}`}, Output: "[SearchResult!]!"},
+		{Name: "createPost", Type: "mutation", Input: map[string]string{"input": "PostInput!"}, Output: "Post!"},
+	}
+	if !reflect.DeepEqual(schema.Operations, wantOperations) {
+		t.Errorf("operations = %+v, want %+v", schema.Operations, wantOperations)
+	}
+
+	types := make(map[string]models.GraphQLType)
+	var names []string
+	for _, definition := range schema.Types {
+		types[definition.Name] = definition
+		names = append(names, definition.Name)
+	}
+	// Subscription is not a root type once the schema block names the roots
+	want := "User, Node, Timestamped, Post, Status, SearchResult, PostInput, DateTime, Subscription"
+	if got := strings.Join(names, ", "); got != want {
+		t.Errorf("types = %s, want %s", got, want)
+	}
+
+	user := types["User"]
+	if user.Kind != "type" || user.Description != "A registered author" || !reflect.DeepEqual(user.Implements, []string{"Node", "Timestamped"}) {
+		t.Errorf("User = %+v", user)
+	}
+	wantFields := map[string]string{"id": "ID!", "name": "String", "posts": "[Post!]!", "email": "String!"}
+	if !reflect.DeepEqual(user.Fields, wantFields) {
+		t.Errorf("User fields = %v, want %v", user.Fields, wantFields)
+	}
+	wantArguments := map[string]map[string]string{"posts": {"first": "Int = 10", "after": "String"}}
+	if !reflect.DeepEqual(user.Arguments, wantArguments) {
+		t.Errorf("User arguments = %v, want %v", user.Arguments, wantArguments)
+	}
+
+	if status := types["Status"]; status.Kind != "enum" || !reflect.DeepEqual(status.Values, []string{"DRAFT", "PUBLISHED"}) {
+		t.Errorf("Status = %+v", status)
+	}
+	if result := types["SearchResult"]; result.Kind != "union" || !reflect.DeepEqual(result.Values, []string{"User", "Post"}) {
+		t.Errorf("SearchResult = %+v", result)
+	}
+	if input := types["PostInput"]; input.Kind != "input" || input.Fields["tags"] != `[String!] = ["news", "blog"]` {
+		t.Errorf("PostInput = %+v", input)
+	}
+	if scalar := types["DateTime"]; scalar.Kind != "scalar" {
+		t.Errorf("DateTime = %+v", scalar)
+	}
+}
+
+func TestParseGraphQLSchemaErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		sdl  string
+		want string
+	}{
+		{"unterminated block string", `"""never closed`, "line 1: unterminated block string"},
+		{"missing field type", "type Query {\n  user(id: ID!)\n}", `line 3: expected ":"`},
+		{"unknown definition", "type Query { a: Int }\nfragment F on Query { a }", `line 2: unexpected "fragment"`},
+		{"unexpected character", "type Query { a: Int% }", "unexpected character"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGraphQLSchema(tt.sdl)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
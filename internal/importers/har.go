@@ -0,0 +1,181 @@
+package importers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// HARImporter handles importing HTTP Archive (HAR) files exported by browser
+// devtools and proxies. The recorded traffic is turned into endpoints by
+// TrafficLearner.
+type HARImporter struct{}
+
+// HAR represents the parts of an HTTP Archive used for inference
+type HAR struct {
+	Log HARLog `json:"log"`
+}
+
+type HARLog struct {
+	Version string     `json:"version"`
+	Entries []HAREntry `json:"entries"`
+}
+
+type HAREntry struct {
+	Request  HARRequest  `json:"request"`
+	Response HARResponse `json:"response"`
+}
+
+type HARRequest struct {
+	Method   string         `json:"method"`
+	URL      string         `json:"url"`
+	Headers  []HARNameValue `json:"headers"`
+	PostData *HARPostData   `json:"postData,omitempty"`
+}
+
+type HARResponse struct {
+	Status  int            `json:"status"`
+	Headers []HARNameValue `json:"headers"`
+	Content HARContent     `json:"content"`
+}
+
+type HARNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type HARPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type HARContent struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// Import parses a HAR file and infers an API model from its entries
+func (i *HARImporter) Import(filename string) (*models.API, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	var har HAR
+	if err := json.Unmarshal(content, &har); err != nil {
+		return nil, fmt.Errorf("failed to parse HAR JSON: %w", err)
+	}
+	if len(har.Log.Entries) == 0 {
+		return nil, fmt.Errorf("invalid HAR file: no entries")
+	}
+
+	// Pages recorded in a browser also call analytics and CDN hosts; only the
+	// origin receiving the most API calls is imported
+	origin := i.primaryOrigin(har.Log.Entries)
+
+	learner := NewTrafficLearner()
+	for _, entry := range har.Log.Entries {
+		if i.origin(entry) != origin {
+			continue
+		}
+
+		record := TrafficRecord{
+			Method:          entry.Request.Method,
+			URL:             entry.Request.URL,
+			RequestHeaders:  i.headers(entry.Request.Headers),
+			Status:          entry.Response.Status,
+			ResponseHeaders: i.headers(entry.Response.Headers),
+			ResponseBody:    i.responseBody(entry.Response.Content),
+		}
+		if entry.Request.PostData != nil {
+			record.RequestBody = []byte(entry.Request.PostData.Text)
+		}
+		if record.ResponseHeaders.Get("Content-Type") == "" && entry.Response.Content.MimeType != "" {
+			record.ResponseHeaders.Set("Content-Type", entry.Response.Content.MimeType)
+		}
+
+		if err := learner.Add(record); err != nil {
+			return nil, err
+		}
+	}
+
+	api := learner.API()
+	if len(api.Endpoints) == 0 {
+		return nil, fmt.Errorf("no API requests found in HAR file")
+	}
+	return api, nil
+}
+
+// Validate checks if the imported API is valid
+func (i *HARImporter) Validate(api *models.API) error {
+	if api == nil {
+		return fmt.Errorf("API cannot be nil")
+	}
+
+	for idx, endpoint := range api.Endpoints {
+		if endpoint.Path == "" {
+			return fmt.Errorf("endpoint %d: path is required", idx)
+		}
+		if endpoint.Method == "" {
+			return fmt.Errorf("endpoint %d: method is required", idx)
+		}
+	}
+
+	return nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (i *HARImporter) GetSupportedExtensions() []string {
+	return []string{".har", ".json"}
+}
+
+// primaryOrigin returns the origin with the most non-asset requests
+func (i *HARImporter) primaryOrigin(entries []HAREntry) string {
+	counts := make(map[string]int)
+	best := ""
+	for _, entry := range entries {
+		parsed, err := url.Parse(entry.Request.URL)
+		if err != nil || isStaticAsset(parsed.Path, entry.Response.Content.MimeType) {
+			continue
+		}
+		origin := i.origin(entry)
+		counts[origin]++
+		if best == "" || counts[origin] > counts[best] {
+			best = origin
+		}
+	}
+	return best
+}
+
+func (i *HARImporter) origin(entry HAREntry) string {
+	parsed, err := url.Parse(entry.Request.URL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
+
+func (i *HARImporter) headers(values []HARNameValue) http.Header {
+	headers := make(http.Header)
+	for _, header := range values {
+		headers.Add(header.Name, header.Value)
+	}
+	return headers
+}
+
+// responseBody returns the recorded response content, which HAR stores as
+// base64 for binary or compressed bodies
+func (i *HARImporter) responseBody(content HARContent) []byte {
+	if content.Encoding == "base64" {
+		if decoded, err := base64.StdEncoding.DecodeString(content.Text); err == nil {
+			return decoded
+		}
+	}
+	return []byte(content.Text)
+}
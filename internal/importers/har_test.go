@@ -0,0 +1,63 @@
+package importers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHARImporterFixture(t *testing.T) {
+	api, err := (&HARImporter{}).Import("testdata/shop.har")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api.BaseURL != "https://shop.example.com" {
+		t.Errorf("base_url = %q", api.BaseURL)
+	}
+	if api.AuthType != "bearer" {
+		t.Errorf("auth_type = %q, want bearer", api.AuthType)
+	}
+
+	endpoints := endpointsByRoute(api)
+	for _, route := range []string{"GET /api/users/me", "GET /api/users/search", "GET /api/users/export", "GET /api/users/stats", "GET /api/users/{id}"} {
+		if _, ok := endpoints[route]; !ok {
+			t.Errorf("missing endpoint %s in %v", route, routes(endpoints))
+		}
+	}
+	if len(endpoints) != 5 {
+		t.Errorf("expected 5 endpoints, got %v", routes(endpoints))
+	}
+
+	user := endpoints["GET /api/users/{id}"]
+	if user.Response == nil || user.Response.Body["email"] == "" {
+		t.Errorf("response fields not inferred: %+v", user.Response)
+	}
+	if len(user.Errors) != 1 || user.Errors[0].Status != 404 || user.Errors[0].Code != "USER_NOT_FOUND" {
+		t.Errorf("errors = %+v, want 404 USER_NOT_FOUND", user.Errors)
+	}
+	if user.Request == nil || user.Request.Params["id"] == "" {
+		t.Errorf("path parameter not declared: %+v", user.Request)
+	}
+	if search := endpoints["GET /api/users/search"]; search.Request == nil || search.Request.Query["q"] == "" {
+		t.Errorf("query parameter not inferred: %+v", search.Request)
+	}
+}
+
+func TestDetectHARFormat(t *testing.T) {
+	dir := t.TempDir()
+	content, err := os.ReadFile("testdata/shop.har")
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := filepath.Join(dir, "session.json")
+	if err := os.WriteFile(saved, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	factory := &ImporterFactory{}
+	for _, filename := range []string{"testdata/shop.har", saved} {
+		if format, err := factory.DetectFormat(filename); err != nil || format != "har" {
+			t.Errorf("DetectFormat(%s) = %q, %v, want har", filename, format, err)
+		}
+	}
+}
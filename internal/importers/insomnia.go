@@ -0,0 +1,444 @@
+package importers
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// InsomniaImporter handles importing Insomnia v4 export files (JSON or YAML)
+type InsomniaImporter struct {
+	// body parsing and type inference are shared with the Postman importer
+	postman PostmanImporter
+}
+
+// InsomniaExport represents an Insomnia v4 export: a flat list of resources
+// linked into a tree by parentId
+type InsomniaExport struct {
+	Type         string             `json:"_type" yaml:"_type"`
+	ExportFormat int                `json:"__export_format" yaml:"__export_format"`
+	Resources    []InsomniaResource `json:"resources" yaml:"resources"`
+}
+
+// InsomniaResource is a workspace, request group, request or environment.
+// Request groups carry their own environment and headers, which apply to
+// every request below them.
+type InsomniaResource struct {
+	ID             string                 `json:"_id" yaml:"_id"`
+	Type           string                 `json:"_type" yaml:"_type"`
+	ParentID       string                 `json:"parentId" yaml:"parentId"`
+	Name           string                 `json:"name" yaml:"name"`
+	Description    string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Method         string                 `json:"method,omitempty" yaml:"method,omitempty"`
+	URL            string                 `json:"url,omitempty" yaml:"url,omitempty"`
+	Body           *InsomniaBody          `json:"body,omitempty" yaml:"body,omitempty"`
+	Parameters     []InsomniaParameter    `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Headers        []InsomniaParameter    `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Authentication map[string]interface{} `json:"authentication,omitempty" yaml:"authentication,omitempty"`
+	Data           map[string]interface{} `json:"data,omitempty" yaml:"data,omitempty"`
+	Environment    map[string]interface{} `json:"environment,omitempty" yaml:"environment,omitempty"`
+}
+
+type InsomniaBody struct {
+	MimeType string              `json:"mimeType,omitempty" yaml:"mimeType,omitempty"`
+	Text     string              `json:"text,omitempty" yaml:"text,omitempty"`
+	Params   []InsomniaParameter `json:"params,omitempty" yaml:"params,omitempty"`
+}
+
+type InsomniaParameter struct {
+	Name     string `json:"name" yaml:"name"`
+	Value    string `json:"value" yaml:"value"`
+	Disabled bool   `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+}
+
+// Import parses an Insomnia export file and converts it to our internal API model
+func (i *InsomniaImporter) Import(filename string) (*models.API, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	// YAML is a superset of JSON, so both export flavours parse the same way
+	var export InsomniaExport
+	if err := yaml.Unmarshal(content, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse Insomnia export: %w", err)
+	}
+	if export.ExportFormat != 4 {
+		return nil, fmt.Errorf("unsupported Insomnia export format %d, expected 4", export.ExportFormat)
+	}
+
+	byID := make(map[string]InsomniaResource, len(export.Resources))
+	for _, resource := range export.Resources {
+		byID[resource.ID] = resource
+	}
+	environment := i.environment(export.Resources, byID)
+
+	api := &models.API{Endpoints: []models.Endpoint{}}
+	authCounts := make(map[string]int)
+	baseCounts := make(map[string]int)
+
+	for _, resource := range export.Resources {
+		if resource.Type != "request" {
+			continue
+		}
+
+		endpoint, base := i.convertRequest(resource, i.requestVariables(resource, byID, environment))
+		if auth := i.authentication(resource, byID); auth != "" {
+			endpoint.Auth = true
+			authCounts[auth]++
+		}
+		if base != "" {
+			baseCounts[base]++
+		}
+		api.Endpoints = append(api.Endpoints, endpoint)
+	}
+
+	api.BaseURL = i.basePath(mostCommon(baseCounts))
+
+	// Requests written with absolute URLs include the base path
+	if api.BaseURL != "/" {
+		for idx, endpoint := range api.Endpoints {
+			if strings.HasPrefix(endpoint.Path, api.BaseURL+"/") {
+				api.Endpoints[idx].Path = strings.TrimPrefix(endpoint.Path, api.BaseURL)
+			}
+		}
+	}
+	api.AuthType = mostCommon(authCounts)
+	return api, nil
+}
+
+// Validate checks if the imported API is valid
+func (i *InsomniaImporter) Validate(api *models.API) error {
+	if api == nil {
+		return fmt.Errorf("API cannot be nil")
+	}
+
+	for idx, endpoint := range api.Endpoints {
+		if endpoint.Path == "" {
+			return fmt.Errorf("endpoint %d: path is required", idx)
+		}
+		if endpoint.Method == "" {
+			return fmt.Errorf("endpoint %d: method is required", idx)
+		}
+	}
+
+	return nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (i *InsomniaImporter) GetSupportedExtensions() []string {
+	return []string{".json", ".yaml", ".yml"}
+}
+
+var insomniaVariable = regexp.MustCompile(`\{\{\s*(?:_\.)?([\w.-]+)\s*\}\}`)
+
+// convertRequest converts an Insomnia request to our endpoint format and
+// returns the base URL its URL starts with, if any
+func (i *InsomniaImporter) convertRequest(resource InsomniaResource, variables map[string]string) (models.Endpoint, string) {
+	method := strings.ToUpper(resource.Method)
+	if method == "" {
+		method = "GET"
+	}
+	endpoint := models.Endpoint{
+		Method:      method,
+		Description: resource.Name,
+	}
+	if resource.Description != "" {
+		endpoint.Description = resource.Description
+	}
+
+	request := &models.EndpointRequest{
+		Params: make(map[string]string),
+		Query:  make(map[string]string),
+		Body:   make(map[string]string),
+	}
+
+	base, path := i.splitURL(resource.URL, variables)
+
+	// Path parameters: {{ _.userId }} and :userId segments. Variables set to
+	// a word ({{ _.resource }} = orders) are part of the route instead.
+	segments := strings.Split(path, "/")
+	for idx, segment := range segments {
+		var name string
+		if match := insomniaVariable.FindStringSubmatch(segment); match != nil && match[0] == segment {
+			if value, ok := variables[match[1]]; ok && value != "" && !looksLikeIdentifier(value) && !insomniaVariable.MatchString(value) {
+				segments[idx] = strings.Trim(value, "/")
+				continue
+			}
+			name = match[1]
+		} else if strings.HasPrefix(segment, ":") && len(segment) > 1 {
+			name = segment[1:]
+		} else {
+			segments[idx] = i.resolve(segment, variables)
+			continue
+		}
+		segments[idx] = "{" + name + "}"
+		request.Params[name] = "string, required"
+	}
+	endpoint.Path = strings.Join(segments, "/")
+	if endpoint.Path == "" {
+		endpoint.Path = "/"
+	}
+
+	// Query parameters, from the URL and from the parameters table
+	if parsed, err := url.Parse(endpoint.Path); err == nil && parsed.RawQuery != "" {
+		endpoint.Path = parsed.Path
+		for name, values := range parsed.Query() {
+			request.Query[name] = "string, required"
+			if len(values) == 0 || values[0] == "" {
+				request.Query[name] = "string, optional"
+			}
+		}
+	}
+	for _, parameter := range resource.Parameters {
+		if parameter.Name == "" {
+			continue
+		}
+		request.Query[parameter.Name] = "string, optional"
+		if !parameter.Disabled && parameter.Value != "" {
+			request.Query[parameter.Name] = "string, required"
+		}
+	}
+
+	// Request body
+	if resource.Body != nil && method != "GET" && method != "DELETE" {
+		switch {
+		case strings.Contains(resource.Body.MimeType, "json"):
+			if text := strings.TrimSpace(resource.Body.Text); text != "" {
+				for name, def := range i.postman.parseJSONBody(i.resolveJSON(text, variables)) {
+					request.Body[name] = def
+				}
+			}
+		case len(resource.Body.Params) > 0:
+			for _, parameter := range resource.Body.Params {
+				if !parameter.Disabled && parameter.Name != "" {
+					request.Body[parameter.Name] = "string, required"
+				}
+			}
+		}
+	}
+
+	endpoint.Request = request
+
+	// Insomnia exports do not include responses; use the usual defaults
+	endpoint.Response = &models.EndpointResponse{Status: 200, Body: make(map[string]string)}
+	if method == "POST" {
+		endpoint.Response.Status = 201
+	} else if method == "DELETE" {
+		endpoint.Response.Status = 204
+	}
+
+	return endpoint, base
+}
+
+// splitURL separates a request URL into its base URL and path. A URL starting
+// with a variable ({{ _.base_url }}/users) uses the variable's value as the
+// base; otherwise the scheme and host are the base.
+func (i *InsomniaImporter) splitURL(raw string, variables map[string]string) (string, string) {
+	raw = strings.TrimSpace(raw)
+	if loc := insomniaVariable.FindStringIndex(raw); loc != nil && loc[0] == 0 {
+		base := strings.TrimRight(i.resolve(raw[:loc[1]], variables), "/")
+		return base, raw[loc[1]:]
+	}
+
+	resolved := i.resolve(raw, variables)
+	if parsed, err := url.Parse(resolved); err == nil && parsed.Host != "" {
+		rest := strings.TrimPrefix(resolved, parsed.Scheme+"://"+parsed.Host)
+		return parsed.Scheme + "://" + parsed.Host, rest
+	}
+	return "", raw
+}
+
+// basePath returns the path of a base URL, like the OpenAPI and Postman
+// importers do (https://api.example.com/v1 becomes /v1). Variables that could
+// not be resolved are dropped, keeping the literal path around them
+// ({{ _.host }}/api becomes /api).
+func (i *InsomniaImporter) basePath(base string) string {
+	base = insomniaVariable.ReplaceAllString(base, "")
+	if parsed, err := url.Parse(base); err == nil && parsed.Host != "" {
+		base = parsed.Path
+	}
+	base = strings.TrimRight(base, "/")
+	if base == "" {
+		return "/"
+	}
+	if !strings.HasPrefix(base, "/") {
+		base = "/" + base
+	}
+	return base
+}
+
+// resolve substitutes environment variables in a template string. Unknown
+// variables are left in place.
+func (i *InsomniaImporter) resolve(template string, variables map[string]string) string {
+	return insomniaVariable.ReplaceAllStringFunc(template, func(match string) string {
+		name := insomniaVariable.FindStringSubmatch(match)[1]
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// resolveJSON substitutes variables in a JSON body template. Variables used
+// as bare values ("age": {{ _.age }}) become null when unknown so that the
+// body still parses.
+func (i *InsomniaImporter) resolveJSON(template string, variables map[string]string) string {
+	return insomniaVariable.ReplaceAllStringFunc(template, func(match string) string {
+		name := insomniaVariable.FindStringSubmatch(match)[1]
+		if value, ok := variables[name]; ok {
+			return value
+		}
+		return "null"
+	})
+}
+
+// environment merges the base environment with its sub-environments. A
+// variable defined by several sub-environments takes the value of the first
+// one. Environment data may be nested ({"api": {"url": ...}}), which
+// templates address as _.api.url.
+func (i *InsomniaImporter) environment(resources []InsomniaResource, byID map[string]InsomniaResource) map[string]string {
+	variables := make(map[string]string)
+
+	var base *InsomniaResource
+	for idx, resource := range resources {
+		if resource.Type == "environment" && byID[resource.ParentID].Type == "workspace" {
+			base = &resources[idx]
+			flattenEnvironment("", resource.Data, variables)
+			break
+		}
+	}
+	if base == nil {
+		return variables
+	}
+
+	sub := make(map[string]string)
+	for _, resource := range resources {
+		if resource.Type != "environment" || resource.ParentID != base.ID {
+			continue
+		}
+		values := make(map[string]string)
+		flattenEnvironment("", resource.Data, values)
+		for name, value := range values {
+			if _, ok := sub[name]; !ok {
+				sub[name] = value
+			}
+		}
+	}
+	for name, value := range sub {
+		variables[name] = value
+	}
+	return variables
+}
+
+// maxResolveDepth bounds how deeply variables may refer to each other, so
+// that cyclic definitions terminate
+const maxResolveDepth = 10
+
+// requestVariables returns the variables of a request: the environment,
+// overridden by the environments of the enclosing request groups from the
+// outermost to the innermost. Variables referring to other variables
+// ({{ _.host }}/api) are resolved until nothing changes.
+func (i *InsomniaImporter) requestVariables(resource InsomniaResource, byID map[string]InsomniaResource, environment map[string]string) map[string]string {
+	var groups []InsomniaResource
+	for group, ok := byID[resource.ParentID]; ok && group.Type == "request_group"; group, ok = byID[group.ParentID] {
+		groups = append([]InsomniaResource{group}, groups...)
+	}
+
+	variables := make(map[string]string, len(environment))
+	for name, value := range environment {
+		variables[name] = value
+	}
+	for _, group := range groups {
+		flattenEnvironment("", group.Environment, variables)
+	}
+
+	for depth := 0; depth < maxResolveDepth; depth++ {
+		changed := false
+		for name, value := range variables {
+			if resolved := i.resolve(value, variables); resolved != value {
+				variables[name] = resolved
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+	return variables
+}
+
+func flattenEnvironment(prefix string, data map[string]interface{}, variables map[string]string) {
+	for key, value := range data {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenEnvironment(name, nested, variables)
+			continue
+		}
+		variables[name] = fmt.Sprint(value)
+	}
+}
+
+// authentication returns the auth type of a request, inheriting it from the
+// enclosing request groups when the request does not set its own
+func (i *InsomniaImporter) authentication(resource InsomniaResource, byID map[string]InsomniaResource) string {
+	for current, ok := resource, true; ok; current, ok = byID[current.ParentID] {
+		auth := current.Authentication
+		if len(auth) == 0 {
+			continue
+		}
+		if disabled, _ := auth["disabled"].(bool); disabled {
+			return ""
+		}
+		authType, _ := auth["type"].(string)
+		switch authType {
+		case "", "none":
+			return ""
+		case "bearer", "oauth2", "jwt":
+			return "bearer"
+		case "basic", "digest", "ntlm":
+			return "basic"
+		case "apikey":
+			return "apikey"
+		default:
+			return authType
+		}
+	}
+
+	// Credentials passed as a plain header, on the request or a group
+	for current, ok := resource, true; ok; current, ok = byID[current.ParentID] {
+		for _, header := range current.Headers {
+			if header.Disabled {
+				continue
+			}
+			switch strings.ToLower(header.Name) {
+			case "authorization":
+				if strings.HasPrefix(strings.ToLower(header.Value), "basic ") {
+					return "basic"
+				}
+				return "bearer"
+			case "x-api-key", "api-key":
+				return "apikey"
+			}
+		}
+	}
+	return ""
+}
+
+func mostCommon(counts map[string]int) string {
+	best, bestCount := "", 0
+	for value, count := range counts {
+		if count > bestCount || (count == bestCount && value < best) {
+			best, bestCount = value, count
+		}
+	}
+	return best
+}
@@ -0,0 +1,78 @@
+package importers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInsomniaImporterGroups(t *testing.T) {
+	api, err := (&InsomniaImporter{}).Import("testdata/insomnia-groups.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// base_url refers to other variables, with prefix from the first
+	// sub-environment
+	if api.BaseURL != "/api" {
+		t.Errorf("base_url = %q, want /api", api.BaseURL)
+	}
+	if api.AuthType != "bearer" {
+		t.Errorf("auth_type = %q, want bearer from the group headers", api.AuthType)
+	}
+
+	endpoints := endpointsByRoute(api)
+	want := []string{"GET /health", "GET /orders", "GET /orders/{orderId}", "GET /orders/{orderId}/items"}
+	if got := routes(endpoints); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("endpoints = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		route string
+		auth  bool
+	}{
+		{"GET /health", false},
+		{"GET /orders", true},
+		{"GET /orders/{orderId}", true},
+		{"GET /orders/{orderId}/items", true},
+	}
+	for _, tt := range tests {
+		if endpoints[tt.route].Auth != tt.auth {
+			t.Errorf("%s auth = %v, want %v", tt.route, endpoints[tt.route].Auth, tt.auth)
+		}
+	}
+
+	items := endpoints["GET /orders/{orderId}/items"]
+	if items.Request.Params["orderId"] == "" || items.Request.Query["region"] == "" {
+		t.Errorf("request = %+v, want the orderId param and region query", items.Request)
+	}
+}
+
+func TestInsomniaRequestVariables(t *testing.T) {
+	importer := &InsomniaImporter{}
+	byID := map[string]InsomniaResource{
+		"outer": {ID: "outer", Type: "request_group", Environment: map[string]interface{}{"version": "v1", "name": "outer"}},
+		"inner": {ID: "inner", Type: "request_group", ParentID: "outer", Environment: map[string]interface{}{"name": "inner"}},
+	}
+	environment := map[string]string{
+		"url":    "{{ _.host }}/{{ _.version }}",
+		"host":   "{{ _.scheme }}://example.com",
+		"scheme": "https",
+		"name":   "base",
+		"loop":   "{{ _.loop }}x",
+	}
+
+	variables := importer.requestVariables(InsomniaResource{ParentID: "inner"}, byID, environment)
+
+	tests := map[string]string{
+		"url":  "https://example.com/v1",
+		"name": "inner",
+	}
+	for name, want := range tests {
+		if got := variables[name]; got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if environment["url"] != "{{ _.host }}/{{ _.version }}" {
+		t.Error("the shared environment was modified")
+	}
+}
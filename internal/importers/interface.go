@@ -1,12 +1,15 @@
 package importers
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/plugins"
+	"gopkg.in/yaml.v3"
 )
 
 // Importer defines the interface for importing API specifications from different formats
@@ -33,13 +36,46 @@ func (f *ImporterFactory) CreateImporter(format string) (Importer, error) {
 		return &PostmanImporter{}, nil
 	case "architect":
 		return &ArchitectImporter{}, nil
+	case "har":
+		return &HARImporter{}, nil
+	case "insomnia":
+		return &InsomniaImporter{}, nil
+	case "curl":
+		return &CurlImporter{}, nil
+	case "graphql":
+		return &GraphQLImporter{}, nil
+	case "proto":
+		return &ProtoImporter{}, nil
+	case "asyncapi":
+		return &AsyncAPIImporter{}, nil
+	case "raml":
+		return &RAMLImporter{}, nil
+	case "blueprint", "apib":
+		return &BlueprintImporter{}, nil
 	default:
+		// Formats of architect-<name> plugins on PATH
+		if importer, ok := plugins.FindImporter(format); ok {
+			return importer, nil
+		}
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
 }
 
 // DetectFormat attempts to detect the format from file extension and content
 func (f *ImporterFactory) DetectFormat(filename string) (string, error) {
+	// Commands piped on stdin are curl snippets
+	if filename == "-" {
+		return "curl", nil
+	}
+
+	// RAML and API Blueprint documents announce themselves in their first
+	// lines, whatever their extension
+	if content, err := os.ReadFile(filename); err == nil {
+		if format := detectHeaderFormat(content); format != "" {
+			return format, nil
+		}
+	}
+
 	ext := filepath.Ext(filename)
 
 	switch ext {
@@ -50,6 +86,17 @@ func (f *ImporterFactory) DetectFormat(filename string) (string, error) {
 			return "", fmt.Errorf("failed to read file: %w", err)
 		}
 
+		if strings.Contains(string(content), "__export_format") && strings.Contains(string(content), "_type") {
+			return "insomnia", nil
+		}
+		// HAR files exported with a .json extension have a single top-level "log"
+		var topLevel map[string]json.RawMessage
+		if json.Unmarshal(content, &topLevel) == nil && len(topLevel) == 1 && topLevel["log"] != nil {
+			return "har", nil
+		}
+		if topLevel["asyncapi"] != nil {
+			return "asyncapi", nil
+		}
 		if strings.Contains(string(content), "openapi") || strings.Contains(string(content), "swagger") {
 			return "openapi", nil
 		}
@@ -59,9 +106,69 @@ func (f *ImporterFactory) DetectFormat(filename string) (string, error) {
 		return "openapi", nil // Default to OpenAPI for JSON
 
 	case ".yaml", ".yml":
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %w", err)
+		}
+		if strings.Contains(string(content), "__export_format") {
+			return "insomnia", nil
+		}
+		var topLevel map[string]interface{}
+		if yaml.Unmarshal(content, &topLevel) == nil && topLevel["asyncapi"] != nil {
+			return "asyncapi", nil
+		}
 		return "openapi", nil
 
+	case ".har":
+		return "har", nil
+
+	case ".graphql", ".graphqls", ".gql":
+		return "graphql", nil
+
+	case ".proto":
+		return "proto", nil
+
+	case ".raml":
+		return "raml", nil
+
+	case ".apib":
+		return "blueprint", nil
+
+	case ".sh", ".curl", ".txt":
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return "", fmt.Errorf("failed to read file: %w", err)
+		}
+		if strings.Contains(string(content), "curl ") {
+			return "curl", nil
+		}
+		return "", fmt.Errorf("no curl commands found in %s", filename)
+
 	default:
+		if importer, ok := plugins.DetectImporter(filename); ok {
+			return importer.Capability.Format, nil
+		}
 		return "", fmt.Errorf("unable to detect format from extension: %s", ext)
 	}
 }
+
+// detectHeaderFormat recognizes the "#%RAML 1.0" header line and the
+// "FORMAT: 1A" metadata of API Blueprint
+func detectHeaderFormat(content []byte) string {
+	text := strings.TrimSpace(strings.TrimPrefix(string(content), "\uFEFF"))
+	if strings.HasPrefix(text, "#%RAML") {
+		return "raml"
+	}
+
+	// Blueprint metadata precedes the first blank line
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok && strings.EqualFold(strings.TrimSpace(key), "FORMAT") && strings.TrimSpace(value) == "1A" {
+			return "blueprint"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package importers
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDetectHeaderFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"raml", "#%RAML 1.0\ntitle: Shop\n", "raml"},
+		{"raml 0.8 after a byte order mark and blank lines", "\uFEFF\n\n#%RAML 0.8\n", "raml"},
+		{"blueprint", "FORMAT: 1A\nHOST: https://api.example.com\n\n# Shop\n", "blueprint"},
+		{"blueprint metadata in any order and case", "HOST: https://api.example.com\nformat : 1A\n", "blueprint"},
+		{"format after the metadata block", "# Shop\n\nFORMAT: 1A\n", ""},
+		{"other format version", "FORMAT: 2\n", ""},
+		{"yaml", "openapi: 3.0.0\n", ""},
+		{"markdown", "# Notes\n\nFORMAT: 1A is the blueprint header\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectHeaderFormat([]byte(tt.content)); got != tt.want {
+				t.Errorf("detectHeaderFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectFormatByHeader(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"api.yaml":   "#%RAML 1.0\ntitle: Shop\n/users:\n  get:\n",
+		"api.md":     "FORMAT: 1A\n\n# Shop\n",
+		"shop.raml":  "#%RAML 1.0\n",
+		"shop.apib":  "# Shop\n",
+		"other.yaml": "openapi: 3.0.0\n",
+	}
+	want := map[string]string{"api.yaml": "raml", "api.md": "blueprint", "shop.raml": "raml", "shop.apib": "blueprint", "other.yaml": "openapi"}
+
+	factory := &ImporterFactory{}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if format, err := factory.DetectFormat(path); err != nil || format != want[name] {
+			t.Errorf("DetectFormat(%s) = %q, %v, want %s", name, format, err, want[name])
+		}
+	}
+}
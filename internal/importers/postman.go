@@ -3,16 +3,38 @@ package importers
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/faisalahmedsifat/architect/internal/models"
 )
 
-// PostmanImporter handles importing Postman collections
-type PostmanImporter struct{}
+// PostmanImporter handles importing Postman collections. Variables are
+// resolved from the environment files first and from the collection second,
+// the same precedence Postman uses.
+type PostmanImporter struct {
+	// EnvironmentFiles are exported Postman environments; each one with a
+	// base URL variable becomes a server
+	EnvironmentFiles []string
+
+	variables []PostmanVariable
+}
+
+// PostmanEnvironment represents an exported Postman environment
+type PostmanEnvironment struct {
+	Name   string                    `json:"name"`
+	Values []PostmanEnvironmentValue `json:"values"`
+}
+
+type PostmanEnvironmentValue struct {
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
 
 // PostmanCollection represents a simplified Postman collection structure
 type PostmanCollection struct {
@@ -34,7 +56,16 @@ type PostmanItem struct {
 	Description *PostmanDescription `json:"description,omitempty"`
 	Request     *PostmanRequest     `json:"request,omitempty"`
 	Item        []PostmanItem       `json:"item,omitempty"` // For folders
-	Response    []interface{}       `json:"response,omitempty"`
+	Response    []PostmanResponse   `json:"response,omitempty"`
+}
+
+// PostmanResponse is a saved example response
+type PostmanResponse struct {
+	Name   string          `json:"name"`
+	Status string          `json:"status"`
+	Code   int             `json:"code"`
+	Header []PostmanHeader `json:"header,omitempty"`
+	Body   string          `json:"body,omitempty"`
 }
 
 type PostmanRequest struct {
@@ -142,20 +173,109 @@ func (i *PostmanImporter) Import(filename string) (*models.API, error) {
 		return nil, fmt.Errorf("invalid Postman collection: missing schema")
 	}
 
+	environments, err := i.loadEnvironments()
+	if err != nil {
+		return nil, err
+	}
+	i.variables = i.mergeVariables(collection.Variable, environments)
+
 	// Convert to our internal format
 	api := &models.API{
 		BaseURL:   i.extractBaseURL(&collection),
 		AuthType:  i.determineAuthType(&collection),
+		Servers:   i.extractServers(environments),
 		Endpoints: []models.Endpoint{},
 	}
 
 	// Process all items (including nested folders)
 	endpoints := i.processItems(collection.Item, &collection)
+
+	// Paths are relative to the base URL
+	basePath := strings.TrimRight(api.BaseURL, "/")
+	for idx, endpoint := range endpoints {
+		if basePath != "" && strings.HasPrefix(endpoint.Path, basePath+"/") {
+			endpoints[idx].Path = strings.TrimPrefix(endpoint.Path, basePath)
+		}
+	}
 	api.Endpoints = endpoints
 
 	return api, nil
 }
 
+// loadEnvironments reads the environment files
+func (i *PostmanImporter) loadEnvironments() ([]PostmanEnvironment, error) {
+	var environments []PostmanEnvironment
+	for _, filename := range i.EnvironmentFiles {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read environment %s: %w", filename, err)
+		}
+
+		var environment PostmanEnvironment
+		if err := json.Unmarshal(content, &environment); err != nil {
+			return nil, fmt.Errorf("failed to parse Postman environment %s: %w", filename, err)
+		}
+		if environment.Values == nil {
+			return nil, fmt.Errorf("invalid Postman environment %s: missing values", filename)
+		}
+		if environment.Name == "" {
+			environment.Name = strings.TrimSuffix(filepath.Base(filename), ".json")
+		}
+		environments = append(environments, environment)
+	}
+	return environments, nil
+}
+
+// mergeVariables combines collection variables with the values of the first
+// environment, which override them
+func (i *PostmanImporter) mergeVariables(collection []PostmanVariable, environments []PostmanEnvironment) []PostmanVariable {
+	variables := append([]PostmanVariable{}, collection...)
+	if len(environments) == 0 {
+		return variables
+	}
+
+	for _, value := range environments[0].Values {
+		if value.Enabled != nil && !*value.Enabled {
+			continue
+		}
+		found := false
+		for idx := range variables {
+			if variables[idx].Key == value.Key {
+				variables[idx].Value = value.Value
+				found = true
+			}
+		}
+		if !found {
+			variables = append(variables, PostmanVariable{Key: value.Key, Value: value.Value})
+		}
+	}
+	return variables
+}
+
+// extractServers maps each environment with a base URL variable to a server
+func (i *PostmanImporter) extractServers(environments []PostmanEnvironment) []models.Server {
+	var servers []models.Server
+	for _, environment := range environments {
+		for _, value := range environment.Values {
+			if value.Enabled != nil && !*value.Enabled {
+				continue
+			}
+			if i.isBaseURLVariable(value.Key) && strings.Contains(value.Value, "://") {
+				servers = append(servers, models.Server{Name: environment.Name, URL: strings.TrimRight(value.Value, "/")})
+				break
+			}
+		}
+	}
+	return servers
+}
+
+// isBaseURLVariable reports whether a variable name looks like it holds the
+// base URL
+func (i *PostmanImporter) isBaseURLVariable(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "url") || strings.Contains(key, "host") || strings.Contains(key, "base")
+}
+
 // Validate checks if the imported API is valid
 func (i *PostmanImporter) Validate(api *models.API) error {
 	if api == nil {
@@ -219,7 +339,7 @@ func (i *PostmanImporter) convertRequest(item PostmanItem, collection *PostmanCo
 
 	// Parse URL and path
 	if request.URL != nil {
-		endpoint.Path = i.extractPath(request.URL, collection)
+		endpoint.Path = i.extractPath(request.URL)
 
 		// Handle path parameters
 		endpoint.Request = &models.EndpointRequest{
@@ -228,10 +348,15 @@ func (i *PostmanImporter) convertRequest(item PostmanItem, collection *PostmanCo
 			Body:   make(map[string]string),
 		}
 
-		// Extract path variables
+		// Extract path variables, declared or left unresolved in the path
 		for _, variable := range request.URL.Variable {
 			endpoint.Request.Params[variable.Key] = "string, required"
 		}
+		for _, match := range pathParamPattern.FindAllStringSubmatch(endpoint.Path, -1) {
+			if _, declared := endpoint.Request.Params[match[1]]; !declared {
+				endpoint.Request.Params[match[1]] = "string, required"
+			}
+		}
 
 		// Extract query parameters
 		for _, query := range request.URL.Query {
@@ -274,19 +399,101 @@ func (i *PostmanImporter) convertRequest(item PostmanItem, collection *PostmanCo
 		endpoint.Response.Status = 204
 	}
 
+	// Saved examples describe the real responses
+	i.applyExamples(&endpoint, item.Response)
+
 	return endpoint
 }
 
+// applyExamples uses saved example responses for the endpoint response and
+// errors. The first successful example sets the status; bodies and headers
+// of every example with that status are merged. Each 4xx/5xx status becomes
+// a declared error.
+func (i *PostmanImporter) applyExamples(endpoint *models.Endpoint, examples []PostmanResponse) {
+	success := 0
+	for _, example := range examples {
+		if example.Code >= 200 && example.Code < 300 {
+			success = example.Code
+			break
+		}
+	}
+
+	if success != 0 {
+		response := &models.EndpointResponse{Status: success, Body: make(map[string]string)}
+		for _, example := range examples {
+			if example.Code != success {
+				continue
+			}
+			for name, def := range i.parseExampleBody(example.Body) {
+				response.Body[name] = def
+			}
+			for _, header := range example.Header {
+				if header.Disabled || ignoredResponseHeaders[strings.ToLower(header.Key)] {
+					continue
+				}
+				if response.Headers == nil {
+					response.Headers = make(map[string]string)
+				}
+				response.Headers[header.Key] = i.inferJSONFieldType(header.Value) + ", required"
+			}
+		}
+		endpoint.Response = response
+	}
+
+	seen := make(map[int]bool)
+	for _, declared := range endpoint.Errors {
+		seen[declared.Status] = true
+	}
+	for _, example := range examples {
+		if example.Code < 400 || seen[example.Code] {
+			continue
+		}
+		seen[example.Code] = true
+
+		var body interface{}
+		json.Unmarshal([]byte(example.Body), &body)
+		code, message := trafficError(body)
+		if code == "" {
+			code = strings.ToUpper(strings.ReplaceAll(http.StatusText(example.Code), " ", "_"))
+		}
+		if message == "" {
+			message = example.Name
+		}
+		if message == "" {
+			message = example.Status
+		}
+		endpoint.Errors = append(endpoint.Errors, models.ErrorResponse{Status: example.Code, Code: code, Message: message})
+	}
+}
+
+// parseExampleBody extracts field types from a JSON example body. Unlike
+// request bodies, non-JSON examples (HTML error pages, plain text) yield no
+// fields.
+func (i *PostmanImporter) parseExampleBody(body string) map[string]string {
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &object); err != nil {
+		return nil
+	}
+	return i.parseJSONBody(body)
+}
+
+// ignoredResponseHeaders are transport headers that say nothing about the API
+var ignoredResponseHeaders = map[string]bool{
+	"date": true, "content-type": true, "content-length": true, "connection": true, "keep-alive": true,
+	"server": true, "transfer-encoding": true, "vary": true, "etag": true,
+	"content-encoding": true, "x-powered-by": true, "access-control-allow-origin": true,
+	"access-control-allow-credentials": true, "access-control-allow-headers": true,
+	"access-control-allow-methods": true, "access-control-expose-headers": true,
+}
+
 // extractBaseURL extracts base URL from Postman collection
 func (i *PostmanImporter) extractBaseURL(collection *PostmanCollection) string {
 	// Look for common base URL in variables
-	for _, variable := range collection.Variable {
-		if strings.Contains(strings.ToLower(variable.Key), "url") ||
-			strings.Contains(strings.ToLower(variable.Key), "host") ||
-			strings.Contains(strings.ToLower(variable.Key), "base") {
+	for _, variable := range i.variables {
+		if i.isBaseURLVariable(variable.Key) {
 
 			// Parse URL to extract path
-			if parsedURL, err := url.Parse(variable.Value); err == nil {
+			if parsedURL, err := url.Parse(i.substituteVariables(variable.Value)); err == nil {
 				if parsedURL.Path != "" && parsedURL.Path != "/" {
 					return parsedURL.Path
 				}
@@ -303,7 +510,7 @@ func (i *PostmanImporter) extractBaseURL(collection *PostmanCollection) string {
 			break
 		}
 		if item.Request != nil && item.Request.URL != nil {
-			path := i.extractPath(item.Request.URL, collection)
+			path := i.extractPath(item.Request.URL)
 			if path != "" {
 				paths = append(paths, path)
 			}
@@ -394,50 +601,82 @@ func (i *PostmanImporter) findCommonPathPrefix(paths []string) string {
 }
 
 // extractPath extracts the API path from Postman URL
-func (i *PostmanImporter) extractPath(postmanURL *PostmanURL, collection *PostmanCollection) string {
-	if postmanURL.Raw != "" {
-		// Parse raw URL
-		if parsedURL, err := url.Parse(postmanURL.Raw); err == nil {
-			path := parsedURL.Path
+func (i *PostmanImporter) extractPath(postmanURL *PostmanURL) string {
+	raw := postmanURL.Raw
+	if raw == "" && len(postmanURL.Path) > 0 {
+		// Fallback: construct from path segments
+		raw = "/" + strings.Join(postmanURL.Path, "/")
+	}
+	if raw == "" {
+		return ""
+	}
 
-			// Replace Postman variables with path parameters
-			path = i.replacePostmanVariables(path, collection)
+	// Resolve variables before parsing so that {{baseUrl}} expands to a
+	// scheme and host instead of ending up in the path
+	raw = i.substituteVariables(raw)
+	raw, _, _ = strings.Cut(raw, "#")
+	raw, _, _ = strings.Cut(raw, "?")
 
-			return path
+	// Drop the scheme and host; a host without scheme or an unresolved host
+	// variable ends at the first slash
+	if _, rest, ok := strings.Cut(raw, "://"); ok {
+		raw = rest
+	}
+	if !strings.HasPrefix(raw, "/") {
+		slash := strings.Index(raw, "/")
+		if slash < 0 {
+			return "/"
 		}
+		raw = raw[slash:]
 	}
 
-	// Fallback: construct from path segments
-	if len(postmanURL.Path) > 0 {
-		path := "/" + strings.Join(postmanURL.Path, "/")
-		path = i.replacePostmanVariables(path, collection)
-		return path
-	}
+	return i.replacePostmanVariables(raw)
+}
 
-	return ""
+// substituteVariables replaces {{variable}} with its value, leaving
+// variables that are unknown or hold sample path parameters in place
+func (i *PostmanImporter) substituteVariables(value string) string {
+	// Values may reference other variables
+	for depth := 0; depth < 3 && strings.Contains(value, "{{"); depth++ {
+		replaced := postmanVariablePattern.ReplaceAllStringFunc(value, func(match string) string {
+			key := strings.TrimSpace(match[2 : len(match)-2])
+			for _, variable := range i.variables {
+				if variable.Key == key && variable.Value != "" && !i.isPathParameter(variable.Value) {
+					return variable.Value
+				}
+			}
+			return match
+		})
+		if replaced == value {
+			break
+		}
+		value = replaced
+	}
+	return value
 }
 
-// replacePostmanVariables converts Postman variables to OpenAPI-style path parameters
-func (i *PostmanImporter) replacePostmanVariables(path string, collection *PostmanCollection) string {
+var (
+	postmanVariablePattern = regexp.MustCompile(`\{\{([^}]+)\}\}`)
+	pathParamPattern       = regexp.MustCompile(`\{(\w+)\}`)
+)
+
+// replacePostmanVariables converts the remaining Postman variables and
+// :param segments to OpenAPI-style path parameters
+func (i *PostmanImporter) replacePostmanVariables(path string) string {
 	// Replace {{variable}} with {variable}
-	re := regexp.MustCompile(`\{\{([^}]+)\}\}`)
-	path = re.ReplaceAllString(path, "{$1}")
-
-	// Replace collection variables with their default values if available
-	for _, variable := range collection.Variable {
-		placeholder := "{" + variable.Key + "}"
-		if strings.Contains(path, placeholder) && variable.Value != "" {
-			// If it's a URL component, keep as parameter
-			// Otherwise, replace with actual value
-			if i.isPathParameter(variable.Value) {
-				continue
-			} else {
-				path = strings.ReplaceAll(path, placeholder, variable.Value)
-			}
+	path = postmanVariablePattern.ReplaceAllStringFunc(path, func(match string) string {
+		return "{" + strings.TrimSpace(match[2:len(match)-2]) + "}"
+	})
+
+	// Replace :variable with {variable}
+	segments := strings.Split(path, "/")
+	for idx, segment := range segments {
+		if len(segment) > 1 && segment[0] == ':' {
+			segments[idx] = "{" + segment[1:] + "}"
 		}
 	}
 
-	return path
+	return strings.Join(segments, "/")
 }
 
 // isPathParameter determines if a value looks like a path parameter
@@ -0,0 +1,96 @@
+package importers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestPostmanImporterEnvironments(t *testing.T) {
+	importer := &PostmanImporter{EnvironmentFiles: []string{"testdata/postman-staging.json", "testdata/postman-production.json"}}
+	api, err := importer.Import("testdata/postman-collection.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(api.Servers) != 2 {
+		t.Fatalf("servers = %+v, want one per environment", api.Servers)
+	}
+	if api.Servers[0].Name != "Staging" || api.Servers[0].URL != "https://staging.example.com/api/v1" {
+		t.Errorf("staging server = %+v", api.Servers[0])
+	}
+	if api.Servers[1].Name != "Production" || api.Servers[1].URL != "https://api.example.com/api/v1" {
+		t.Errorf("production server = %+v", api.Servers[1])
+	}
+
+	endpoints := endpointsByRoute(api)
+	want := []string{"GET /orders/{id}", "GET /users", "POST /users"}
+	if got := routes(endpoints); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("endpoints = %v, want %v (base_url %s)", got, want, api.BaseURL)
+	}
+}
+
+func TestFindCommonPathPrefix(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{"shared base", []string{"/api/v1/users", "/api/v1/orders"}, "/api/v1"},
+		{"single resource", []string{"/users", "/users"}, "/users"},
+		{"nothing shared", []string{"/users", "/orders"}, ""},
+		{"variables skipped", []string{"/{{version}}/users", "/{{version}}/orders"}, ""},
+		{"no paths", nil, ""},
+	}
+	importer := &PostmanImporter{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := importer.findCommonPathPrefix(tt.paths); got != tt.want {
+				t.Errorf("findCommonPathPrefix(%v) = %q, want %q", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostmanImporterExamples(t *testing.T) {
+	api, err := (&PostmanImporter{}).Import("testdata/postman-examples.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	endpoints := endpointsByRoute(api)
+
+	user := endpoints["GET /users/{id}"]
+	if user.Response == nil || user.Response.Status != 200 {
+		t.Fatalf("response = %+v, want 200", user.Response)
+	}
+	for _, field := range []string{"id", "email", "avatar"} {
+		if user.Response.Body[field] == "" {
+			t.Errorf("response field %s not merged from the examples: %v", field, user.Response.Body)
+		}
+	}
+	if !strings.HasPrefix(user.Response.Body["id"], "uuid") {
+		t.Errorf("id = %q, want a uuid", user.Response.Body["id"])
+	}
+	if len(user.Response.Headers) != 1 || user.Response.Headers["X-RateLimit-Remaining"] == "" {
+		t.Errorf("headers = %v, want only X-RateLimit-Remaining", user.Response.Headers)
+	}
+
+	wantErrors := []models.ErrorResponse{
+		{Status: 404, Code: "USER_NOT_FOUND", Message: "no such user"},
+		{Status: 502, Code: "BAD_GATEWAY", Message: "Gateway error"},
+	}
+	if !reflect.DeepEqual(user.Errors, wantErrors) {
+		t.Errorf("errors = %+v, want %+v", user.Errors, wantErrors)
+	}
+
+	// Without a successful example the default status is kept
+	create := endpoints["POST /users"]
+	if create.Response == nil || create.Response.Status != 201 {
+		t.Errorf("response = %+v, want the default 201", create.Response)
+	}
+	if len(create.Errors) != 1 || create.Errors[0].Code != "VALIDATION_ERROR" || create.Errors[0].Message != "Invalid email" {
+		t.Errorf("errors = %+v, want 422 VALIDATION_ERROR named after the example", create.Errors)
+	}
+}
@@ -0,0 +1,736 @@
+package importers
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// ProtoImporter handles importing Protocol Buffers files. Each rpc becomes an
+// endpoint: rpcs with a google.api.http annotation use its HTTP binding and
+// the others the gRPC path, POST /package.Service/Method.
+type ProtoImporter struct{}
+
+// protoFile holds the parts of a .proto file used for the import
+type protoFile struct {
+	pkg      string
+	messages map[string]*protoMessage
+	enums    map[string]bool
+	services []protoService
+}
+
+type protoMessage struct {
+	name   string
+	fields []protoField
+}
+
+type protoField struct {
+	name     string
+	typ      string
+	repeated bool
+	isMap    bool
+	optional bool
+	required bool
+}
+
+type protoService struct {
+	name string
+	rpcs []protoRPC
+}
+
+type protoRPC struct {
+	name            string
+	comment         string
+	input           string
+	output          string
+	clientStreaming bool
+	serverStreaming bool
+	// http holds the google.api.http rule: the HTTP method (get, post, ...)
+	// mapped to the path, plus body and response_body
+	http map[string]string
+}
+
+// Import parses a .proto file and converts it to our internal API model
+func (i *ProtoImporter) Import(filename string) (*models.API, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	file, err := parseProto(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse proto file: %w", err)
+	}
+
+	api := &models.API{
+		AuthType:  "none",
+		Endpoints: []models.Endpoint{},
+	}
+	for _, service := range file.services {
+		for _, rpc := range service.rpcs {
+			api.Endpoints = append(api.Endpoints, i.convertRPC(file, service, rpc))
+		}
+	}
+	if len(api.Endpoints) == 0 {
+		return nil, fmt.Errorf("no services found in %s", filename)
+	}
+
+	// HTTP bindings are absolute paths
+	api.BaseURL = ""
+	return api, nil
+}
+
+// Validate checks if the imported API is valid
+func (i *ProtoImporter) Validate(api *models.API) error {
+	if api == nil {
+		return fmt.Errorf("API cannot be nil")
+	}
+
+	for idx, endpoint := range api.Endpoints {
+		if endpoint.Path == "" {
+			return fmt.Errorf("endpoint %d: path is required", idx)
+		}
+		if endpoint.Method == "" {
+			return fmt.Errorf("endpoint %d: method is required", idx)
+		}
+	}
+
+	return nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (i *ProtoImporter) GetSupportedExtensions() []string {
+	return []string{".proto"}
+}
+
+var protoPathParam = regexp.MustCompile(`\{([\w.]+)(=[^}]*)?\}`)
+
+// convertRPC maps an rpc to an endpoint following the HTTP/JSON transcoding
+// rules: path variables come from the request message, the body selector
+// picks the body fields and the remaining fields are query parameters
+func (i *ProtoImporter) convertRPC(file *protoFile, service protoService, rpc protoRPC) models.Endpoint {
+	fullService := service.name
+	if file.pkg != "" {
+		fullService = file.pkg + "." + service.name
+	}
+
+	endpoint := models.Endpoint{
+		Method:      "POST",
+		Path:        "/" + fullService + "/" + rpc.name,
+		Description: rpc.comment,
+		RPC:         fullService + "/" + rpc.name,
+		Request: &models.EndpointRequest{
+			Params: make(map[string]string),
+			Query:  make(map[string]string),
+			Body:   make(map[string]string),
+		},
+		Response: &models.EndpointResponse{Status: 200, Body: make(map[string]string)},
+	}
+	if endpoint.Description == "" {
+		endpoint.Description = rpc.name
+	}
+	if rpc.clientStreaming || rpc.serverStreaming {
+		endpoint.Description += " (streaming)"
+	}
+
+	body := "*"
+	for _, method := range []string{"get", "post", "put", "patch", "delete", "head", "options"} {
+		if path, ok := rpc.http[method]; ok {
+			endpoint.Method = strings.ToUpper(method)
+			endpoint.Path = path
+			body = rpc.http["body"]
+		}
+	}
+
+	// {user.id} becomes {user_id} and {name=users/*} becomes users/{name}
+	pathFields := make(map[string]bool)
+	endpoint.Path = protoPathParam.ReplaceAllStringFunc(endpoint.Path, func(match string) string {
+		groups := protoPathParam.FindStringSubmatch(match)
+		pathFields[groups[1]] = true
+		param := strings.ReplaceAll(groups[1], ".", "_")
+		endpoint.Request.Params[param] = "string, required"
+		return protoPathTemplate(param, strings.TrimPrefix(groups[2], "="), endpoint.Request.Params)
+	})
+
+	if input := file.messages[protoShortName(rpc.input)]; input != nil {
+		for _, field := range input.fields {
+			switch {
+			case body == field.name:
+				// Path variables such as {user.id} are taken out of the body
+				if nested := file.messages[protoShortName(field.typ)]; nested != nil {
+					for _, nestedField := range nested.fields {
+						if !pathFields[field.name+"."+nestedField.name] {
+							endpoint.Request.Body[nestedField.name] = i.fieldDefinition(file, nestedField)
+						}
+					}
+				}
+			case pathFields[field.name]:
+				endpoint.Request.Params[field.name] = i.fieldType(file, field) + ", required"
+			case body == "*":
+				endpoint.Request.Body[field.name] = i.fieldDefinition(file, field)
+			default:
+				endpoint.Request.Query[field.name] = i.fieldDefinition(file, field)
+			}
+		}
+	}
+
+	output := file.messages[protoShortName(rpc.output)]
+	if responseBody := rpc.http["response_body"]; output != nil && responseBody != "" {
+		for _, field := range output.fields {
+			if field.name == responseBody {
+				output = file.messages[protoShortName(field.typ)]
+			}
+		}
+	}
+	if output != nil {
+		for _, field := range output.fields {
+			endpoint.Response.Body[field.name] = i.fieldDefinition(file, field)
+		}
+	}
+
+	return endpoint
+}
+
+// protoPathTemplate expands a path variable template such as users/* into
+// users/{name}. The last wildcard is the variable itself and earlier ones,
+// as in projects/*/users/*, become parameters named after their collection.
+func protoPathTemplate(param, template string, params map[string]string) string {
+	if template == "" {
+		return "{" + param + "}"
+	}
+
+	segments := strings.Split(template, "/")
+	last := -1
+	for idx, segment := range segments {
+		if segment == "*" || segment == "**" {
+			last = idx
+		}
+	}
+	for idx, segment := range segments {
+		if segment != "*" && segment != "**" {
+			continue
+		}
+		if idx == last {
+			segments[idx] = "{" + param + "}"
+			continue
+		}
+		name := "id"
+		if idx > 0 {
+			name = strings.TrimSuffix(segments[idx-1], "s") + "_id"
+		}
+		params[name] = "string, required"
+		segments[idx] = "{" + name + "}"
+	}
+	return strings.Join(segments, "/")
+}
+
+// fieldType maps a protobuf field type to an api.yaml type
+func (i *ProtoImporter) fieldType(file *protoFile, field protoField) string {
+	switch {
+	case field.isMap:
+		return "object"
+	case field.repeated:
+		return "array"
+	}
+
+	switch field.typ {
+	case "int32", "int64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64":
+		return "integer"
+	case "float", "double":
+		return "number"
+	case "bool":
+		return "boolean"
+	case "string", "bytes":
+		return "string"
+	case "google.protobuf.Timestamp":
+		return "datetime"
+	case "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return "string"
+	case "google.protobuf.Int32Value", "google.protobuf.Int64Value", "google.protobuf.UInt32Value", "google.protobuf.UInt64Value":
+		return "integer"
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue":
+		return "number"
+	case "google.protobuf.BoolValue":
+		return "boolean"
+	}
+	if file.enums[protoShortName(field.typ)] {
+		return "string"
+	}
+	return "object"
+}
+
+// fieldDefinition returns the api.yaml definition of a field. Fields are
+// optional unless marked with (google.api.field_behavior) = REQUIRED or the
+// proto2 required label.
+func (i *ProtoImporter) fieldDefinition(file *protoFile, field protoField) string {
+	if field.required {
+		return i.fieldType(file, field) + ", required"
+	}
+	return i.fieldType(file, field) + ", optional"
+}
+
+// protoShortName strips the package from a message reference
+func protoShortName(name string) string {
+	name = strings.TrimPrefix(name, ".")
+	if idx := strings.LastIndex(name, "."); idx >= 0 && !strings.HasPrefix(name, "google.protobuf.") {
+		return name[idx+1:]
+	}
+	return name
+}
+
+type protoToken struct {
+	value   string
+	str     bool
+	line    int
+	comment string
+}
+
+// lexProto splits a .proto file into tokens. Line comments directly in
+// front of a token are attached to it as its documentation.
+func lexProto(src string) ([]protoToken, error) {
+	var tokens []protoToken
+	var comment []string
+	line := 1
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+			// Trailing comments belong to the declaration on their line
+			if len(tokens) == 0 || tokens[len(tokens)-1].line != line {
+				comment = append(comment, strings.TrimSpace(strings.TrimLeft(src[i:i+end], "/")))
+			}
+			i += end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(src[i:i+2+end], "\n")
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for j < len(src) && src[j] != c && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(src) || src[j] != c {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			value, err := strconv.Unquote(`"` + strings.ReplaceAll(src[i+1:j], `"`, `\"`) + `"`)
+			if err != nil {
+				value = src[i+1 : j]
+			}
+			tokens = append(tokens, protoToken{value: value, str: true, line: line})
+			i = j + 1
+		case strings.IndexByte("{}()<>[];=,:", c) >= 0:
+			tokens = append(tokens, protoToken{value: string(c), line: line, comment: strings.Join(comment, "\n")})
+			comment = nil
+			i++
+		default:
+			j := i
+			for j < len(src) && strings.IndexByte(" \t\r\n{}()<>[];=,:\"'/", src[j]) < 0 {
+				j++
+			}
+			if j == i {
+				return nil, fmt.Errorf("line %d: unexpected character %q", line, c)
+			}
+			tokens = append(tokens, protoToken{value: src[i:j], line: line, comment: strings.Join(comment, "\n")})
+			comment = nil
+			i = j
+		}
+		// A blank line separates a comment from the next declaration
+		if c == '\n' && i < len(src) && src[i] == '\n' {
+			comment = nil
+		}
+	}
+
+	return tokens, nil
+}
+
+type protoParser struct {
+	tokens []protoToken
+	pos    int
+}
+
+func (p *protoParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *protoParser) peek() protoToken {
+	if p.done() {
+		return protoToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *protoParser) next() protoToken {
+	token := p.peek()
+	if !p.done() {
+		p.pos++
+	}
+	return token
+}
+
+// is reports whether the next token is the given symbol or keyword
+func (p *protoParser) is(value string) bool {
+	token := p.peek()
+	return !token.str && !p.done() && token.value == value
+}
+
+func (p *protoParser) expect(value string) error {
+	if !p.is(value) {
+		return p.unexpected(strconv.Quote(value))
+	}
+	p.next()
+	return nil
+}
+
+func (p *protoParser) unexpected(expected string) error {
+	if p.done() {
+		return fmt.Errorf("expected %s, found end of file", expected)
+	}
+	token := p.peek()
+	return fmt.Errorf("line %d: expected %s, found %q", token.line, expected, token.value)
+}
+
+// skipStatement skips to the end of a statement, including a braced block
+func (p *protoParser) skipStatement() {
+	depth := 0
+	for !p.done() {
+		token := p.next()
+		if token.str {
+			continue
+		}
+		switch token.value {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				return
+			}
+		case ";":
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// parseProto parses the messages, enums and services of a .proto file
+func parseProto(src string) (*protoFile, error) {
+	tokens, err := lexProto(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &protoParser{tokens: tokens}
+	file := &protoFile{messages: make(map[string]*protoMessage), enums: make(map[string]bool)}
+
+	for !p.done() {
+		switch {
+		case p.is("package"):
+			p.next()
+			file.pkg = p.next().value
+			if err := p.expect(";"); err != nil {
+				return nil, err
+			}
+		case p.is("message"):
+			if err := p.message(file); err != nil {
+				return nil, err
+			}
+		case p.is("enum"):
+			p.next()
+			file.enums[p.next().value] = true
+			p.skipStatement()
+		case p.is("service"):
+			service, err := p.service()
+			if err != nil {
+				return nil, err
+			}
+			file.services = append(file.services, service)
+		case p.is(";"):
+			p.next()
+		default:
+			// syntax, edition, import, option and extend
+			p.skipStatement()
+		}
+	}
+
+	return file, nil
+}
+
+// message parses a message and the messages and enums nested in it
+func (p *protoParser) message(file *protoFile) error {
+	p.next()
+	message := &protoMessage{name: p.next().value}
+	file.messages[message.name] = message
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for !p.is("}") {
+		if p.done() {
+			return p.unexpected(`"}"`)
+		}
+		switch {
+		case p.is("message"):
+			if err := p.message(file); err != nil {
+				return err
+			}
+		case p.is("enum"):
+			p.next()
+			file.enums[p.next().value] = true
+			p.skipStatement()
+		case p.is("oneof"):
+			// Oneof members are plain optional fields of the message
+			p.next()
+			p.next()
+			if err := p.expect("{"); err != nil {
+				return err
+			}
+			for !p.is("}") {
+				if p.done() {
+					return p.unexpected(`"}"`)
+				}
+				if p.is("option") {
+					p.skipStatement()
+					continue
+				}
+				field, err := p.field()
+				if err != nil {
+					return err
+				}
+				message.fields = append(message.fields, field)
+			}
+			p.next()
+		case p.is("option"), p.is("reserved"), p.is("extensions"), p.is("extend"):
+			p.skipStatement()
+		case p.is(";"):
+			p.next()
+		default:
+			field, err := p.field()
+			if err != nil {
+				return err
+			}
+			message.fields = append(message.fields, field)
+		}
+	}
+	p.next()
+
+	return nil
+}
+
+// field parses a field declaration: [label] type name = number [options];
+func (p *protoParser) field() (protoField, error) {
+	field := protoField{}
+	switch {
+	case p.is("repeated"):
+		field.repeated = true
+		p.next()
+	case p.is("optional"):
+		field.optional = true
+		p.next()
+	case p.is("required"):
+		field.required = true
+		p.next()
+	}
+
+	if p.is("map") {
+		p.next()
+		field.isMap = true
+		// map<key, value>
+		for !p.is(">") {
+			if p.done() {
+				return field, p.unexpected(`">"`)
+			}
+			p.next()
+		}
+		p.next()
+		field.typ = "map"
+	} else {
+		field.typ = p.next().value
+	}
+
+	field.name = p.next().value
+	if err := p.expect("="); err != nil {
+		return field, err
+	}
+	p.next()
+
+	if p.is("[") {
+		var options []string
+		for !p.is("]") {
+			if p.done() {
+				return field, p.unexpected(`"]"`)
+			}
+			options = append(options, p.next().value)
+		}
+		p.next()
+		joined := strings.Join(options, " ")
+		if strings.Contains(joined, "field_behavior") && strings.Contains(joined, "REQUIRED") {
+			field.required = true
+		}
+	}
+
+	return field, p.expect(";")
+}
+
+// service parses a service and its rpcs
+func (p *protoParser) service() (protoService, error) {
+	p.next()
+	service := protoService{name: p.next().value}
+	if err := p.expect("{"); err != nil {
+		return service, err
+	}
+
+	for !p.is("}") {
+		if p.done() {
+			return service, p.unexpected(`"}"`)
+		}
+		if !p.is("rpc") {
+			p.skipStatement()
+			continue
+		}
+
+		rpc := protoRPC{comment: p.peek().comment, http: make(map[string]string)}
+		p.next()
+		rpc.name = p.next().value
+
+		if err := p.expect("("); err != nil {
+			return service, err
+		}
+		if p.is("stream") {
+			rpc.clientStreaming = true
+			p.next()
+		}
+		rpc.input = p.next().value
+		if err := p.expect(")"); err != nil {
+			return service, err
+		}
+		if !p.is("returns") {
+			return service, p.unexpected(`"returns"`)
+		}
+		p.next()
+		if err := p.expect("("); err != nil {
+			return service, err
+		}
+		if p.is("stream") {
+			rpc.serverStreaming = true
+			p.next()
+		}
+		rpc.output = p.next().value
+		if err := p.expect(")"); err != nil {
+			return service, err
+		}
+
+		if p.is("{") {
+			p.next()
+			for !p.is("}") {
+				if p.done() {
+					return service, p.unexpected(`"}"`)
+				}
+				if err := p.rpcOption(&rpc); err != nil {
+					return service, err
+				}
+			}
+			p.next()
+		}
+		if p.is(";") {
+			p.next()
+		}
+		service.rpcs = append(service.rpcs, rpc)
+	}
+	p.next()
+
+	return service, nil
+}
+
+// rpcOption parses an rpc option, keeping the google.api.http rule
+func (p *protoParser) rpcOption(rpc *protoRPC) error {
+	if !p.is("option") {
+		p.skipStatement()
+		return nil
+	}
+	p.next()
+
+	var name []string
+	for !p.is("=") {
+		if p.done() {
+			return p.unexpected(`"="`)
+		}
+		name = append(name, p.next().value)
+	}
+	p.next()
+
+	// option (google.api.http).get = "/v1/users";
+	if method, ok := strings.CutPrefix(strings.Join(name, ""), "(google.api.http)."); ok {
+		rpc.http[method] = p.next().value
+		if p.is(";") {
+			p.next()
+		}
+		return nil
+	}
+
+	if strings.Join(name, "") != "(google.api.http)" || !p.is("{") {
+		p.skipStatement()
+		return nil
+	}
+
+	// Text format message: get: "/v1/users/{id}" body: "*"; nested
+	// additional_bindings are skipped
+	p.next()
+	for !p.is("}") {
+		if p.done() {
+			return p.unexpected(`"}"`)
+		}
+		key := p.next().value
+		if p.is(":") {
+			p.next()
+		}
+		switch {
+		case p.is("{"):
+			if key == "custom" {
+				// custom { kind: "HEAD" path: "/v1/x" }
+				p.next()
+				custom := make(map[string]string)
+				for !p.is("}") && !p.done() {
+					k := p.next().value
+					if p.is(":") {
+						p.next()
+					}
+					custom[k] = p.next().value
+				}
+				p.next()
+				rpc.http[strings.ToLower(custom["kind"])] = custom["path"]
+				continue
+			}
+			p.skipStatement()
+		default:
+			rpc.http[key] = p.next().value
+		}
+		if p.is(",") || p.is(";") {
+			p.next()
+		}
+	}
+	p.next()
+	if p.is(";") {
+		p.next()
+	}
+	return nil
+}
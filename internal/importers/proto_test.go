@@ -0,0 +1,71 @@
+package importers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProtoImporterFixture(t *testing.T) {
+	api, err := (&ProtoImporter{}).Import("testdata/users.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	endpoints := endpointsByRoute(api)
+	want := []string{
+		"GET /v1/projects/{project_id}/users/{name}", "GET /v1/users",
+		"HEAD /v1/users/{name}", "PATCH /v1/users/{user_id}", "POST /shop.users.v1.UserService/WatchUsers",
+	}
+	if got := routes(endpoints); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Errorf("endpoints = %v, want %v", got, want)
+	}
+
+	get := endpoints["GET /v1/projects/{project_id}/users/{name}"]
+	if get.RPC != "shop.users.v1.UserService/GetUser" || get.Description != "Get a user by resource name" {
+		t.Errorf("rpc = %q, description = %q", get.RPC, get.Description)
+	}
+	if !reflect.DeepEqual(get.Request.Params, map[string]string{"project_id": "string, required", "name": "string, required"}) {
+		t.Errorf("params = %v", get.Request.Params)
+	}
+	wantUser := map[string]string{
+		"id": "string, optional", "email": "string, required", "role": "string, optional",
+		"labels": "object, optional", "created_at": "datetime, optional",
+		"phone": "string, optional", "address": "object, optional",
+	}
+	if !reflect.DeepEqual(get.Response.Body, wantUser) {
+		t.Errorf("response = %v, want %v", get.Response.Body, wantUser)
+	}
+
+	// The body selector picks the user fields, minus the path variable, and
+	// the other request fields are query parameters
+	update := endpoints["PATCH /v1/users/{user_id}"]
+	if _, ok := update.Request.Body["id"]; ok || update.Request.Body["email"] != "string, required" {
+		t.Errorf("body = %v", update.Request.Body)
+	}
+	if !reflect.DeepEqual(update.Request.Query, map[string]string{"validate_only": "boolean, optional"}) {
+		t.Errorf("query = %v", update.Request.Query)
+	}
+
+	list := endpoints["GET /v1/users"]
+	if list.Request.Query["page_size"] != "integer, optional" || list.Response.Body["users"] != "array, optional" {
+		t.Errorf("list = %+v %+v", list.Request, list.Response)
+	}
+
+	watch := endpoints["POST /shop.users.v1.UserService/WatchUsers"]
+	if watch.Description != "WatchUsers (streaming)" || watch.Request.Body["page_token"] == "" {
+		t.Errorf("streaming rpc = %+v", watch)
+	}
+}
+
+func TestParseProtoErrors(t *testing.T) {
+	for _, src := range []string{
+		"message User { string id = 1;",
+		"service Users { rpc Get(Req) (Res); }",
+		"message User { string id 1; }",
+	} {
+		if _, err := parseProto(src); err == nil {
+			t.Errorf("parseProto(%q) succeeded, want an error", src)
+		}
+	}
+}
@@ -0,0 +1,793 @@
+package importers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// RAMLImporter handles importing RAML 1.0 API definitions. Resource types
+// and traits are applied to the resources using them, and !include files are
+// resolved relative to the including document.
+type RAMLImporter struct {
+	types         map[string]interface{}
+	traits        map[string]interface{}
+	resourceTypes map[string]interface{}
+	schemes       map[string]interface{}
+	scheme        string
+}
+
+// ramlMethods are the methods a RAML resource can declare, in the order
+// endpoints are created
+var ramlMethods = []string{"get", "post", "put", "patch", "delete", "head", "options"}
+
+// Import parses a RAML document and converts it to our internal API model
+func (i *RAMLImporter) Import(filename string) (*models.API, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(string(content), "\uFEFF")), "#%RAML") {
+		return nil, fmt.Errorf("invalid RAML document: missing #%%RAML header")
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse RAML document: %w", err)
+	}
+	if err := i.resolveIncludes(&root, filepath.Dir(filename), 0); err != nil {
+		return nil, err
+	}
+	var raw interface{}
+	if err := root.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to parse RAML document: %w", err)
+	}
+	doc := ramlMap(raw)
+
+	// RAML 0.8 schemas are the predecessor of types
+	i.types = ramlDeclarations(doc["schemas"])
+	for name, decl := range ramlDeclarations(doc["types"]) {
+		i.types[name] = decl
+	}
+	i.traits = ramlDeclarations(doc["traits"])
+	i.resourceTypes = ramlDeclarations(doc["resourceTypes"])
+	i.schemes = ramlDeclarations(doc["securitySchemes"])
+
+	api := &models.API{
+		BaseURL:   i.basePath(doc),
+		Endpoints: []models.Endpoint{},
+	}
+	for _, key := range sortedKeys(doc) {
+		if strings.HasPrefix(key, "/") {
+			i.resource(api, key, ramlMap(doc[key]), nil, doc["securedBy"])
+		}
+	}
+	if len(api.Endpoints) == 0 {
+		return nil, fmt.Errorf("no resources found in %s", filename)
+	}
+	api.AuthType = i.authType()
+
+	return api, nil
+}
+
+// Validate checks if the imported API is valid
+func (i *RAMLImporter) Validate(api *models.API) error {
+	if api == nil {
+		return fmt.Errorf("API cannot be nil")
+	}
+
+	for idx, endpoint := range api.Endpoints {
+		if endpoint.Path == "" {
+			return fmt.Errorf("endpoint %d: path is required", idx)
+		}
+		if endpoint.Method == "" {
+			return fmt.Errorf("endpoint %d: method is required", idx)
+		}
+	}
+
+	return nil
+}
+
+// GetSupportedExtensions returns supported file extensions
+func (i *RAMLImporter) GetSupportedExtensions() []string {
+	return []string{".raml"}
+}
+
+// resolveIncludes replaces !include nodes with the included file. RAML, YAML
+// and JSON files are inlined as documents; other files, such as Markdown
+// descriptions, as strings.
+func (i *RAMLImporter) resolveIncludes(node *yaml.Node, dir string, depth int) error {
+	if depth > 10 {
+		return fmt.Errorf("!include nesting is too deep")
+	}
+
+	if node.Kind == yaml.ScalarNode && node.Tag == "!include" {
+		path := filepath.Join(dir, strings.TrimSpace(node.Value))
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to include %s: %w", node.Value, err)
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".raml", ".yaml", ".yml", ".json":
+			var included yaml.Node
+			if err := yaml.Unmarshal(content, &included); err != nil {
+				return fmt.Errorf("failed to parse included file %s: %w", node.Value, err)
+			}
+			if err := i.resolveIncludes(&included, filepath.Dir(path), depth+1); err != nil {
+				return err
+			}
+			if included.Kind == yaml.DocumentNode && len(included.Content) > 0 {
+				*node = *included.Content[0]
+			}
+		default:
+			node.Tag = "!!str"
+			node.Value = string(content)
+		}
+		return nil
+	}
+
+	for _, child := range node.Content {
+		if err := i.resolveIncludes(child, dir, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// basePath extracts the path of the baseUri, with the API version
+// substituted
+func (i *RAMLImporter) basePath(doc map[string]interface{}) string {
+	baseURI, _ := doc["baseUri"].(string)
+	if baseURI == "" {
+		// RAML 0.8 allows a value/description pair
+		baseURI, _ = ramlMap(doc["baseUri"])["value"].(string)
+	}
+	if version := doc["version"]; version != nil {
+		baseURI = strings.ReplaceAll(baseURI, "{version}", fmt.Sprint(version))
+	}
+
+	if idx := strings.Index(baseURI, "://"); idx >= 0 {
+		baseURI = baseURI[idx+3:]
+		slash := strings.Index(baseURI, "/")
+		if slash < 0 {
+			return ""
+		}
+		baseURI = baseURI[slash:]
+	}
+	return strings.TrimSuffix(baseURI, "/")
+}
+
+// resource converts the methods of a resource and its nested resources
+func (i *RAMLImporter) resource(api *models.API, path string, node map[string]interface{}, uriParams map[string]string, securedBy interface{}) {
+	path = strings.ReplaceAll(path, "{mediaTypeExtension}", "")
+	node = i.applyResourceType(node, path, 0)
+
+	params := make(map[string]string)
+	for name, def := range uriParams {
+		params[name] = def
+	}
+	for name, def := range i.properties(node["uriParameters"]) {
+		params[name] = def
+	}
+	if _, ok := node["securedBy"]; ok {
+		securedBy = node["securedBy"]
+	}
+
+	for _, method := range ramlMethods {
+		raw, ok := node[method]
+		if !ok {
+			continue
+		}
+		operation := i.applyTraits(ramlMap(raw), node["is"], path, method)
+		api.Endpoints = append(api.Endpoints, i.endpoint(path, method, operation, params, securedBy))
+	}
+
+	for _, key := range sortedKeys(node) {
+		if strings.HasPrefix(key, "/") {
+			i.resource(api, path+key, ramlMap(node[key]), params, securedBy)
+		}
+	}
+}
+
+// endpoint converts a RAML method to an endpoint
+func (i *RAMLImporter) endpoint(path, method string, operation map[string]interface{}, uriParams map[string]string, securedBy interface{}) models.Endpoint {
+	if _, ok := operation["securedBy"]; ok {
+		securedBy = operation["securedBy"]
+	}
+
+	endpoint := models.Endpoint{
+		Path:        path,
+		Method:      strings.ToUpper(method),
+		Description: strings.TrimSpace(firstString(operation["displayName"], operation["description"])),
+		Auth:        i.secured(securedBy),
+	}
+
+	request := &models.EndpointRequest{}
+	for _, match := range regexp.MustCompile(`\{([^}]+)\}`).FindAllStringSubmatch(path, -1) {
+		if request.Params == nil {
+			request.Params = make(map[string]string)
+		}
+		request.Params[match[1]] = "string, required"
+		if def, ok := uriParams[match[1]]; ok {
+			request.Params[match[1]] = def
+		}
+	}
+	if query := i.properties(operation["queryParameters"]); len(query) > 0 {
+		request.Query = query
+	} else if query := i.fields(operation["queryString"], 0); len(query) > 0 {
+		request.Query = query
+	}
+	if body := i.body(operation["body"]); len(body) > 0 {
+		request.Body = body
+	}
+	if request.Params != nil || request.Query != nil || request.Body != nil {
+		endpoint.Request = request
+	}
+
+	responses := ramlMap(operation["responses"])
+	codes := make([]int, 0, len(responses))
+	for key := range responses {
+		if code, err := strconv.Atoi(key); err == nil {
+			codes = append(codes, code)
+		}
+	}
+	sort.Ints(codes)
+
+	for _, code := range codes {
+		response := ramlMap(responses[strconv.Itoa(code)])
+		switch {
+		case code >= 200 && code < 300 && endpoint.Response == nil:
+			endpoint.Response = &models.EndpointResponse{Status: code}
+			if body := i.body(response["body"]); len(body) > 0 {
+				endpoint.Response.Body = body
+			}
+			if headers := i.properties(response["headers"]); len(headers) > 0 {
+				endpoint.Response.Headers = headers
+			}
+		case code >= 400:
+			errorCode, message := trafficError(i.example(response["body"]))
+			if errorCode == "" {
+				errorCode = strings.ToUpper(strings.ReplaceAll(http.StatusText(code), " ", "_"))
+			}
+			if description, _ := response["description"].(string); description != "" {
+				message = strings.TrimSpace(description)
+			}
+			if message == "" {
+				message = http.StatusText(code)
+			}
+			endpoint.Errors = append(endpoint.Errors, models.ErrorResponse{Status: code, Code: errorCode, Message: message})
+		}
+	}
+
+	return endpoint
+}
+
+// secured reports whether a securedBy list requires credentials. A null
+// entry allows anonymous access.
+func (i *RAMLImporter) secured(securedBy interface{}) bool {
+	list, ok := securedBy.([]interface{})
+	if !ok || len(list) == 0 {
+		return false
+	}
+	for _, entry := range list {
+		if entry == nil {
+			return false
+		}
+	}
+	if name, _ := ramlReference(list[0]); i.scheme == "" {
+		i.scheme = name
+	}
+	return true
+}
+
+// authType maps the first security scheme in use to our auth types
+func (i *RAMLImporter) authType() string {
+	if i.scheme == "" {
+		return "none"
+	}
+
+	scheme := ramlMap(i.schemes[i.scheme])
+	schemeType, _ := scheme["type"].(string)
+	switch {
+	case strings.HasPrefix(schemeType, "OAuth"):
+		return "bearer"
+	case schemeType == "Basic Authentication", schemeType == "Digest Authentication":
+		return "basic"
+	case schemeType == "Pass Through":
+		headers := ramlMap(ramlMap(scheme["describedBy"])["headers"])
+		for name := range headers {
+			if strings.EqualFold(name, "Authorization") {
+				return "bearer"
+			}
+		}
+		return "apikey"
+	case strings.Contains(strings.ToLower(schemeType+i.scheme), "key"):
+		return "apikey"
+	default:
+		return "bearer"
+	}
+}
+
+// body extracts the fields of a request or response body, preferring the
+// JSON media type when several are declared
+func (i *RAMLImporter) body(raw interface{}) map[string]string {
+	return i.fields(i.bodyDeclaration(raw), 0)
+}
+
+// example returns the example of a body declaration, if any
+func (i *RAMLImporter) example(raw interface{}) interface{} {
+	decl := ramlMap(i.bodyDeclaration(raw))
+	if example, ok := decl["example"]; ok {
+		// Examples may be given as JSON strings
+		if text, ok := example.(string); ok {
+			var parsed interface{}
+			if json.Unmarshal([]byte(text), &parsed) == nil {
+				return parsed
+			}
+		}
+		return example
+	}
+	return nil
+}
+
+// bodyDeclaration picks the type declaration of a body, which is either
+// given per media type or directly when the API declares a default media
+// type
+func (i *RAMLImporter) bodyDeclaration(raw interface{}) interface{} {
+	body, ok := raw.(map[string]interface{})
+	if !ok {
+		return raw
+	}
+
+	var mediaTypes []string
+	for _, key := range sortedKeys(body) {
+		if strings.Contains(key, "/") {
+			mediaTypes = append(mediaTypes, key)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		return body
+	}
+	for _, mediaType := range mediaTypes {
+		if strings.Contains(mediaType, "json") {
+			return body[mediaType]
+		}
+	}
+	return body[mediaTypes[0]]
+}
+
+// properties converts a map of RAML parameter or property declarations to
+// field definitions
+func (i *RAMLImporter) properties(raw interface{}) map[string]string {
+	declarations := ramlMap(raw)
+	if len(declarations) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]string)
+	for key, decl := range declarations {
+		// Pattern properties such as /^x-/ have no fixed name
+		if strings.HasPrefix(key, "/") && strings.HasSuffix(key, "/") {
+			continue
+		}
+		name, def := i.field(key, decl)
+		fields[name] = def
+	}
+	return fields
+}
+
+// fields returns the properties of a type declaration, including the ones
+// inherited from its parent types
+func (i *RAMLImporter) fields(decl interface{}, depth int) map[string]string {
+	if depth > 10 {
+		return nil
+	}
+
+	switch d := decl.(type) {
+	case string:
+		d = strings.TrimSpace(d)
+		if strings.HasPrefix(d, "{") {
+			var schema map[string]interface{}
+			if json.Unmarshal([]byte(d), &schema) != nil {
+				return nil
+			}
+			return (&OpenAPIImporter{}).parseSchemaProperties(schema)
+		}
+		if parent, ok := i.types[d]; ok {
+			return i.fields(parent, depth+1)
+		}
+		return nil
+
+	case map[string]interface{}:
+		if _, ok := d["$schema"]; ok {
+			return (&OpenAPIImporter{}).parseSchemaProperties(d)
+		}
+		if _, ok := d["required"].([]interface{}); ok {
+			return (&OpenAPIImporter{}).parseSchemaProperties(d)
+		}
+
+		fields := make(map[string]string)
+		parents := []interface{}{d["type"]}
+		if list, ok := d["type"].([]interface{}); ok {
+			parents = list
+		}
+		if d["type"] == nil {
+			parents = []interface{}{d["schema"]}
+		}
+		for _, parent := range parents {
+			for name, def := range i.fields(parent, depth+1) {
+				fields[name] = def
+			}
+		}
+		for name, def := range i.properties(d["properties"]) {
+			fields[name] = def
+		}
+
+		// Fall back to the example when no properties are declared
+		if len(fields) == 0 {
+			if example, ok := i.example(d).(map[string]interface{}); ok {
+				inferrer := &PostmanImporter{}
+				for name, value := range example {
+					fields[name] = inferrer.inferJSONFieldType(value) + ", required"
+				}
+			}
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		return fields
+	}
+
+	return nil
+}
+
+// field converts a property declaration to a field definition. Properties
+// are required unless their name ends with "?" or they set required: false.
+func (i *RAMLImporter) field(key string, decl interface{}) (string, string) {
+	name := key
+	required := true
+	if strings.HasSuffix(name, "?") {
+		name = strings.TrimSuffix(name, "?")
+		required = false
+	}
+
+	expr := ""
+	switch d := decl.(type) {
+	case string:
+		expr = d
+	case map[string]interface{}:
+		if value, ok := d["required"].(bool); ok {
+			required = value
+		}
+		switch t := d["type"].(type) {
+		case string:
+			expr = t
+		case []interface{}:
+			expr = "object"
+		case nil:
+			if d["properties"] != nil {
+				expr = "object"
+			} else if d["items"] != nil {
+				expr = "array"
+			}
+		}
+		if format, _ := d["format"].(string); strings.HasPrefix(format, "int") {
+			expr = "integer"
+		}
+	}
+
+	def := i.typeOf(expr, 0)
+	if required {
+		def += ", required"
+	} else {
+		def += ", optional"
+	}
+	switch strings.ToLower(strings.TrimSpace(expr)) {
+	case "email", "emailaddress":
+		def += ", email"
+	case "url", "uri":
+		def += ", url"
+	}
+	return name, def
+}
+
+// typeOf maps a RAML type expression to one of our field types
+func (i *RAMLImporter) typeOf(expr string, depth int) string {
+	expr = strings.TrimSpace(expr)
+	if strings.HasSuffix(expr, "[]") {
+		return "array"
+	}
+	if strings.Contains(expr, "|") {
+		expr = strings.Split(expr, "|")[0]
+	}
+	expr = strings.Trim(strings.TrimSpace(expr), "()")
+
+	switch expr {
+	case "", "string", "any", "file", "nil", "date-only", "time-only":
+		return "string"
+	case "integer", "number", "boolean", "array", "object":
+		return expr
+	case "datetime", "datetime-only":
+		return "datetime"
+	}
+	if strings.HasPrefix(expr, "{") {
+		return "object"
+	}
+	if strings.EqualFold(expr, "uuid") {
+		return "uuid"
+	}
+
+	if depth > 10 {
+		return "string"
+	}
+	switch decl := i.types[expr].(type) {
+	case string:
+		return i.typeOf(decl, depth+1)
+	case map[string]interface{}:
+		if decl["properties"] != nil {
+			return "object"
+		}
+		if parent, ok := decl["type"].(string); ok {
+			return i.typeOf(parent, depth+1)
+		}
+		if decl["type"] != nil {
+			return "object"
+		}
+	}
+	return "string"
+}
+
+// applyResourceType merges the resource type of a resource into it. Methods
+// marked optional in the resource type ("get?") only apply when the resource
+// declares them.
+func (i *RAMLImporter) applyResourceType(resource map[string]interface{}, path string, depth int) map[string]interface{} {
+	name, params := ramlReference(resource["type"])
+	template, ok := i.resourceTypes[name]
+	if !ok || depth > 10 {
+		return resource
+	}
+
+	params["resourcePath"] = path
+	params["resourcePathName"] = ramlResourcePathName(path)
+	resourceType := i.applyResourceType(ramlMap(ramlSubstitute(template, params)), path, depth+1)
+
+	result := make(map[string]interface{}, len(resource))
+	for key, value := range resource {
+		if key != "type" {
+			result[key] = value
+		}
+	}
+	for key, value := range resourceType {
+		optional := strings.HasSuffix(key, "?")
+		key = strings.TrimSuffix(key, "?")
+		if _, declared := resource[key]; optional && !declared {
+			continue
+		}
+		if key == "type" || key == "usage" {
+			continue
+		}
+		result[key] = ramlMerge(value, result[key])
+	}
+	return result
+}
+
+// applyTraits merges the traits of a resource and of one of its methods into
+// the method
+func (i *RAMLImporter) applyTraits(operation map[string]interface{}, resourceTraits interface{}, path, method string) map[string]interface{} {
+	var refs []interface{}
+	if list, ok := resourceTraits.([]interface{}); ok {
+		refs = append(refs, list...)
+	}
+	if list, ok := operation["is"].([]interface{}); ok {
+		refs = append(refs, list...)
+	}
+
+	for _, ref := range refs {
+		name, params := ramlReference(ref)
+		trait, ok := i.traits[name]
+		if !ok {
+			continue
+		}
+		params["resourcePath"] = path
+		params["resourcePathName"] = ramlResourcePathName(path)
+		params["methodName"] = method
+
+		merged := ramlMap(ramlMerge(ramlSubstitute(trait, params), operation))
+		delete(merged, "usage")
+		operation = merged
+	}
+	return operation
+}
+
+// ramlDeclarations reads a map of named declarations. RAML 0.8 lists them as
+// a sequence of single-entry maps.
+func ramlDeclarations(raw interface{}) map[string]interface{} {
+	declarations := make(map[string]interface{})
+	if list, ok := raw.([]interface{}); ok {
+		for _, item := range list {
+			for name, decl := range ramlMap(item) {
+				declarations[name] = decl
+			}
+		}
+		return declarations
+	}
+	for name, decl := range ramlMap(raw) {
+		declarations[name] = decl
+	}
+	return declarations
+}
+
+// ramlReference reads a reference to a trait or resource type, which is
+// either a name or a single-entry map from the name to its parameters
+func ramlReference(ref interface{}) (string, map[string]string) {
+	params := make(map[string]string)
+	switch r := ref.(type) {
+	case string:
+		return r, params
+	case map[string]interface{}:
+		for name, values := range r {
+			for key, value := range ramlMap(values) {
+				params[key] = fmt.Sprint(value)
+			}
+			return name, params
+		}
+	}
+	return "", params
+}
+
+// ramlResourcePathName is the rightmost segment of a resource path that is
+// not a URI parameter
+func ramlResourcePathName(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for idx := len(segments) - 1; idx >= 0; idx-- {
+		if !strings.HasPrefix(segments[idx], "{") {
+			return segments[idx]
+		}
+	}
+	return ""
+}
+
+var (
+	ramlParameterPattern = regexp.MustCompile(`<<\s*(\w+)((?:\s*\|\s*!\w+)*)\s*>>`)
+	ramlFunctionPattern  = regexp.MustCompile(`!(\w+)`)
+)
+
+// ramlSubstitute replaces <<parameter>> placeholders, optionally transformed
+// with functions such as !singularize, in keys and values of a declaration
+func ramlSubstitute(value interface{}, params map[string]string) interface{} {
+	replace := func(text string) string {
+		return ramlParameterPattern.ReplaceAllStringFunc(text, func(match string) string {
+			parts := ramlParameterPattern.FindStringSubmatch(match)
+			value := params[parts[1]]
+			for _, function := range ramlFunctionPattern.FindAllStringSubmatch(parts[2], -1) {
+				value = ramlTransform(value, function[1])
+			}
+			return value
+		})
+	}
+
+	switch v := value.(type) {
+	case string:
+		return replace(v)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			result[replace(key)] = ramlSubstitute(child, params)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for idx, child := range v {
+			result[idx] = ramlSubstitute(child, params)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// ramlTransform applies a RAML parameter function
+func ramlTransform(value, function string) string {
+	switch function {
+	case "singularize":
+		return singular(value)
+	case "pluralize":
+		switch {
+		case strings.HasSuffix(value, "y") && !strings.HasSuffix(value, "ey"):
+			return strings.TrimSuffix(value, "y") + "ies"
+		case strings.HasSuffix(value, "s"), strings.HasSuffix(value, "x"):
+			return value + "es"
+		default:
+			return value + "s"
+		}
+	case "uppercase":
+		return strings.ToUpper(value)
+	case "lowercase":
+		return strings.ToLower(value)
+	case "uppercamelcase":
+		camel := camelIdentifier(value)
+		if camel == "" {
+			return camel
+		}
+		return strings.ToUpper(camel[:1]) + camel[1:]
+	case "lowercamelcase":
+		return camelIdentifier(value)
+	default:
+		return value
+	}
+}
+
+// ramlMerge merges a trait or resource type into a declaration. Values of
+// the declaration win; maps are merged and lists concatenated.
+func ramlMerge(base, override interface{}) interface{} {
+	if override == nil {
+		return base
+	}
+
+	baseMap, baseIsMap := base.(map[string]interface{})
+	overrideMap, overrideIsMap := override.(map[string]interface{})
+	if baseIsMap && overrideIsMap {
+		result := make(map[string]interface{}, len(baseMap)+len(overrideMap))
+		for key, value := range baseMap {
+			result[key] = ramlMerge(value, overrideMap[key])
+		}
+		for key, value := range overrideMap {
+			if _, ok := result[key]; !ok {
+				result[key] = value
+			}
+		}
+		return result
+	}
+
+	baseList, baseIsList := base.([]interface{})
+	overrideList, overrideIsList := override.([]interface{})
+	if baseIsList && overrideIsList {
+		return append(append([]interface{}{}, baseList...), overrideList...)
+	}
+
+	return override
+}
+
+// ramlMap normalizes a decoded YAML value to a map with string keys, such as
+// the status codes of responses. Other values yield an empty map.
+func ramlMap(value interface{}) map[string]interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			result[key] = ramlNormalize(child)
+		}
+		return result
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			result[fmt.Sprint(key)] = ramlNormalize(child)
+		}
+		return result
+	}
+	return map[string]interface{}{}
+}
+
+func ramlNormalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}, map[interface{}]interface{}:
+		return ramlMap(v)
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for idx, child := range v {
+			result[idx] = ramlNormalize(child)
+		}
+		return result
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+package importers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestRAMLImporterFixture(t *testing.T) {
+	api, err := (&RAMLImporter{}).Import("testdata/shop.raml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if api.BaseURL != "/v2" || api.AuthType != "bearer" {
+		t.Errorf("base_url = %q, auth_type = %q", api.BaseURL, api.AuthType)
+	}
+
+	endpoints := endpointsByRoute(api)
+	want := []string{"GET /health", "GET /users", "GET /users/{userId}", "POST /users"}
+	if got := routes(endpoints); strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("endpoints = %v, want %v", got, want)
+	}
+
+	// The collection resource type brings the description and the pageable
+	// trait, and its optional post only applies because the resource has one
+	list := endpoints["GET /users"]
+	if list.Description != "List users" || !list.Auth {
+		t.Errorf("list = %+v", list)
+	}
+	if !reflect.DeepEqual(list.Request.Query, map[string]string{"page": "integer, optional", "size": "integer, optional"}) {
+		t.Errorf("query = %v", list.Request.Query)
+	}
+
+	// The included User type
+	user := map[string]string{
+		"id": "uuid, required", "email": "string, required", "nickname": "string, optional", "createdAt": "datetime, required",
+	}
+	create := endpoints["POST /users"]
+	if create.Description != "Create a user" || !reflect.DeepEqual(create.Request.Body, user) || create.Response.Status != 201 {
+		t.Errorf("create = %+v %+v", create.Request, create.Response)
+	}
+
+	get := endpoints["GET /users/{userId}"]
+	if get.Request.Params["userId"] != "uuid, required" {
+		t.Errorf("params = %v", get.Request.Params)
+	}
+	admin := map[string]string{"permissions": "array, required"}
+	for name, def := range user {
+		admin[name] = def
+	}
+	if !reflect.DeepEqual(get.Response.Body, admin) {
+		t.Errorf("response body = %v, want %v", get.Response.Body, admin)
+	}
+	if !reflect.DeepEqual(get.Response.Headers, map[string]string{"X-RateLimit-Remaining": "integer, required"}) {
+		t.Errorf("headers = %v", get.Response.Headers)
+	}
+	if !reflect.DeepEqual(get.Errors, []models.ErrorResponse{{Status: 404, Code: "USER_NOT_FOUND", Message: "no such user"}}) {
+		t.Errorf("errors = %+v", get.Errors)
+	}
+
+	// securedBy: [null] allows anonymous access; the example gives the fields
+	health := endpoints["GET /health"]
+	if health.Auth || !reflect.DeepEqual(health.Response.Body, map[string]string{"status": "string, required"}) {
+		t.Errorf("health = %+v %+v", health, health.Response)
+	}
+}
+
+func TestRAMLImporterRequiresHeader(t *testing.T) {
+	if _, err := (&RAMLImporter{}).Import("testdata/raml-user.raml"); err == nil || !strings.Contains(err.Error(), "no resources") {
+		t.Errorf("error = %v, want no resources", err)
+	}
+	if _, err := (&RAMLImporter{}).Import("testdata/insomnia-groups.yaml"); err == nil || !strings.Contains(err.Error(), "#%RAML") {
+		t.Errorf("error = %v, want a missing header error", err)
+	}
+}
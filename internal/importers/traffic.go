@@ -0,0 +1,622 @@
+package importers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// TrafficRecord is a single observed HTTP request and its response
+type TrafficRecord struct {
+	Method string
+	// URL is either absolute (https://api.example.com/users?page=2) or a
+	// path with an optional query string (/users?page=2)
+	URL             string
+	RequestHeaders  http.Header
+	RequestBody     []byte
+	Status          int
+	ResponseHeaders http.Header
+	ResponseBody    []byte
+}
+
+// TrafficLearner infers an API specification from recorded HTTP traffic.
+// Concrete paths are collapsed into path templates (/users/123 and
+// /users/456 become /users/{id}), and field types are inferred from the
+// observed query strings and JSON bodies.
+type TrafficLearner struct {
+	records []learnedRecord
+
+	// field type inference is shared with the Postman importer
+	postman PostmanImporter
+}
+
+// learnedRecord is a parsed TrafficRecord
+type learnedRecord struct {
+	method   string
+	origin   string
+	segments []string
+	query    url.Values
+	request  interface{}
+	status   int
+	response interface{}
+	authType string
+}
+
+// NewTrafficLearner creates an empty learner
+func NewTrafficLearner() *TrafficLearner {
+	return &TrafficLearner{}
+}
+
+// Add records an exchange. Requests for static assets such as scripts,
+// stylesheets and images are ignored.
+func (l *TrafficLearner) Add(record TrafficRecord) error {
+	parsed, err := url.Parse(record.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", record.URL, err)
+	}
+	if isStaticAsset(parsed.Path, record.ResponseHeaders.Get("Content-Type")) {
+		return nil
+	}
+
+	learned := learnedRecord{
+		method:   strings.ToUpper(record.Method),
+		segments: splitPath(parsed.Path),
+		query:    parsed.Query(),
+		request:  decodeTrafficJSON(record.RequestBody),
+		status:   record.Status,
+		response: decodeTrafficJSON(record.ResponseBody),
+		authType: trafficAuthType(record.RequestHeaders),
+	}
+	if parsed.Host != "" {
+		learned.origin = parsed.Scheme + "://" + parsed.Host
+	}
+
+	l.records = append(l.records, learned)
+	return nil
+}
+
+// Len returns the number of recorded exchanges
+func (l *TrafficLearner) Len() int {
+	return len(l.records)
+}
+
+// API returns the specification inferred from the recorded traffic
+func (l *TrafficLearner) API() *models.API {
+	api := &models.API{BaseURL: l.baseURL(), AuthType: l.authType(), Endpoints: []models.Endpoint{}}
+
+	templates := collapsePaths(l.records)
+
+	type group struct {
+		method   string
+		template []string
+		records  []learnedRecord
+	}
+	groups := make(map[string]*group)
+	var keys []string
+	for idx, record := range l.records {
+		template := templates[idx]
+		key := record.method + " " + "/" + strings.Join(template, "/")
+		g, ok := groups[key]
+		if !ok {
+			g = &group{method: record.method, template: template}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.records = append(g.records, record)
+	}
+
+	sort.Slice(keys, func(a, b int) bool {
+		ga, gb := groups[keys[a]], groups[keys[b]]
+		pa, pb := "/"+strings.Join(ga.template, "/"), "/"+strings.Join(gb.template, "/")
+		if pa != pb {
+			return pa < pb
+		}
+		return methodOrder(ga.method) < methodOrder(gb.method)
+	})
+
+	for _, key := range keys {
+		g := groups[key]
+		api.Endpoints = append(api.Endpoints, l.inferEndpoint(g.method, g.template, g.records))
+	}
+	return api
+}
+
+// inferEndpoint builds an endpoint from every exchange matching its template
+func (l *TrafficLearner) inferEndpoint(method string, template []string, records []learnedRecord) models.Endpoint {
+	endpoint := models.Endpoint{
+		Path:        "/" + strings.Join(template, "/"),
+		Method:      method,
+		Description: fmt.Sprintf("Inferred from %d recorded requests", len(records)),
+	}
+	if len(records) == 1 {
+		endpoint.Description = "Inferred from 1 recorded request"
+	}
+
+	request := &models.EndpointRequest{}
+
+	// Path parameters, typed from the concrete values observed
+	for i, segment := range template {
+		if !isTemplateParam(segment) {
+			continue
+		}
+		values := make([]string, 0, len(records))
+		for _, record := range records {
+			values = append(values, record.segments[i])
+		}
+		if request.Params == nil {
+			request.Params = make(map[string]string)
+		}
+		request.Params[strings.Trim(segment, "{}")] = l.inferStringType(values) + ", required"
+	}
+
+	// Query parameters, required when present in every request
+	queryValues := make(map[string][]string)
+	for _, record := range records {
+		for name := range record.query {
+			queryValues[name] = append(queryValues[name], record.query.Get(name))
+		}
+	}
+	for name, values := range queryValues {
+		if request.Query == nil {
+			request.Query = make(map[string]string)
+		}
+		request.Query[name] = l.inferStringType(values) + presence(len(values), len(records))
+	}
+
+	// Request body
+	var bodies []interface{}
+	for _, record := range records {
+		if record.request != nil {
+			bodies = append(bodies, record.request)
+		}
+	}
+	request.Body = l.inferFields(bodies)
+
+	if request.Params != nil || request.Query != nil || request.Body != nil {
+		endpoint.Request = request
+	}
+
+	// Success response: the most frequent 2xx status
+	statusCounts := make(map[int]int)
+	for _, record := range records {
+		if record.status >= 200 && record.status < 300 {
+			statusCounts[record.status]++
+		}
+	}
+	if status := mostFrequent(statusCounts); status != 0 {
+		var responses []interface{}
+		for _, record := range records {
+			if record.status == status && record.response != nil {
+				responses = append(responses, record.response)
+			}
+		}
+		endpoint.Response = &models.EndpointResponse{Status: status, Body: l.inferFields(responses)}
+	}
+
+	// Error responses, one per observed 4xx/5xx status
+	seen := make(map[int]bool)
+	for _, record := range records {
+		if record.status < 400 || seen[record.status] {
+			continue
+		}
+		seen[record.status] = true
+		code, message := trafficError(record.response)
+		if code == "" {
+			code = strings.ToUpper(strings.ReplaceAll(http.StatusText(record.status), " ", "_"))
+		}
+		if message == "" {
+			message = http.StatusText(record.status)
+		}
+		endpoint.Errors = append(endpoint.Errors, models.ErrorResponse{Status: record.status, Code: code, Message: message})
+	}
+	sort.Slice(endpoint.Errors, func(a, b int) bool { return endpoint.Errors[a].Status < endpoint.Errors[b].Status })
+
+	// Auth: every successful request carried credentials, or requests were
+	// rejected as unauthenticated
+	authenticated, successes := 0, 0
+	for _, record := range records {
+		if record.status == http.StatusUnauthorized {
+			endpoint.Auth = true
+		}
+		if record.status < 400 {
+			successes++
+			if record.authType != "" {
+				authenticated++
+			}
+		}
+	}
+	if successes > 0 && authenticated == successes {
+		endpoint.Auth = true
+	}
+
+	return endpoint
+}
+
+// inferFields merges the fields of sampled JSON objects. A field is
+// required when it is present and not null in every sample; integer and
+// floating point samples widen to number.
+func (l *TrafficLearner) inferFields(samples []interface{}) map[string]string {
+	types := make(map[string]string)
+	counts := make(map[string]int)
+	notEmails := make(map[string]bool)
+	objects := 0
+
+	for _, sample := range samples {
+		object, ok := sample.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		objects++
+		for name, value := range object {
+			if value == nil {
+				continue
+			}
+			counts[name]++
+
+			fieldType := l.inferValueType(value)
+			if s, ok := value.(string); !ok || !l.postman.looksLikeEmail(s) {
+				notEmails[name] = true
+			}
+
+			switch existing := types[name]; {
+			case existing == "":
+				types[name] = fieldType
+			case existing != fieldType && isNumeric(existing) && isNumeric(fieldType):
+				types[name] = "number"
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]string, len(types))
+	for name, fieldType := range types {
+		def := fieldType + presence(counts[name], objects)
+		if fieldType == "string" && !notEmails[name] {
+			def += ", email"
+		}
+		fields[name] = def
+	}
+	return fields
+}
+
+// inferValueType infers the type of a decoded JSON value, telling integers
+// apart from floating point numbers
+func (l *TrafficLearner) inferValueType(value interface{}) string {
+	if n, ok := value.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return l.postman.inferJSONFieldType(i)
+		}
+		f, _ := n.Float64()
+		return l.postman.inferJSONFieldType(f)
+	}
+	return l.postman.inferJSONFieldType(value)
+}
+
+// inferStringType infers the type of textual values such as path and query
+// parameters
+func (l *TrafficLearner) inferStringType(values []string) string {
+	result := ""
+	for _, value := range values {
+		var valueType string
+		if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+			valueType = "integer"
+		} else if _, err := strconv.ParseFloat(value, 64); err == nil {
+			valueType = "number"
+		} else if value == "true" || value == "false" {
+			valueType = "boolean"
+		} else {
+			valueType = l.postman.inferJSONFieldType(value)
+		}
+
+		switch {
+		case result == "":
+			result = valueType
+		case result == valueType:
+		case isNumeric(result) && isNumeric(valueType):
+			result = "number"
+		default:
+			return "string"
+		}
+	}
+	if result == "" {
+		return "string"
+	}
+	return result
+}
+
+// baseURL returns the origin shared by all absolute URLs, if there is one
+func (l *TrafficLearner) baseURL() string {
+	origin := ""
+	for _, record := range l.records {
+		if record.origin == "" {
+			continue
+		}
+		if origin != "" && record.origin != origin {
+			return ""
+		}
+		origin = record.origin
+	}
+	return origin
+}
+
+// authType returns the most frequent credential scheme observed
+func (l *TrafficLearner) authType() string {
+	counts := make(map[string]int)
+	for _, record := range l.records {
+		if record.authType != "" {
+			counts[record.authType]++
+		}
+	}
+	best, bestCount := "", 0
+	for _, authType := range []string{"bearer", "basic", "apikey"} {
+		if counts[authType] > bestCount {
+			best, bestCount = authType, counts[authType]
+		}
+	}
+	return best
+}
+
+// Identifier-like path segments: numbers, UUIDs, long hex strings and
+// opaque tokens that mix letters and several digits (usr_8f3k2a, cus_9x81b)
+var (
+	numericSegment = regexp.MustCompile(`^\d+$`)
+	uuidSegment    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexSegment     = regexp.MustCompile(`^[0-9a-fA-F]{12,}$`)
+	tokenSegment   = regexp.MustCompile(`^[A-Za-z0-9_-]*\d[A-Za-z0-9_-]*\d[A-Za-z0-9_-]*$`)
+	versionSegment = regexp.MustCompile(`^v\d+(\.\d+)*$`)
+	slugSegment    = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// minSiblingValues is the number of distinct slugs at the same position of
+// otherwise identical paths above which the position is treated as a
+// parameter (/posts/top-5-tips, /posts/release-notes-2, ...). Static words
+// such as /users/me or /admin/list-all-users never are.
+const minSiblingValues = 4
+
+// collapsePaths returns the path template for every record
+func collapsePaths(records []learnedRecord) [][]string {
+	templates := make([][]string, len(records))
+	for idx, record := range records {
+		template := make([]string, len(record.segments))
+		for i, segment := range record.segments {
+			if looksLikeIdentifier(segment) {
+				template[i] = "{}"
+			} else {
+				template[i] = segment
+			}
+		}
+		templates[idx] = template
+	}
+
+	// Collapse positions with many distinct slugs among otherwise equal paths
+	for changed := true; changed; {
+		changed = false
+		siblings := make(map[string]map[string]bool)
+		for _, template := range templates {
+			for i, segment := range template {
+				if segment == "{}" || !looksLikeSlug(segment) {
+					continue
+				}
+				key := siblingKey(template, i)
+				if siblings[key] == nil {
+					siblings[key] = make(map[string]bool)
+				}
+				siblings[key][segment] = true
+			}
+		}
+		for _, template := range templates {
+			for i, segment := range template {
+				if segment != "{}" && looksLikeSlug(segment) && len(siblings[siblingKey(template, i)]) >= minSiblingValues {
+					template[i] = "{}"
+					changed = true
+				}
+			}
+		}
+	}
+
+	for _, template := range templates {
+		nameParams(template)
+	}
+	return templates
+}
+
+// siblingKey identifies the paths that differ from template only at index i
+func siblingKey(template []string, i int) string {
+	parts := make([]string, len(template))
+	copy(parts, template)
+	parts[i] = "*"
+	return strconv.Itoa(i) + ":" + strings.Join(parts, "/")
+}
+
+// nameParams names the parameters of a template after the segment before
+// them: the last one is {id} and earlier ones {userId}, {orderId}, ...
+func nameParams(template []string) {
+	last := -1
+	for i, segment := range template {
+		if segment == "{}" {
+			last = i
+		}
+	}
+
+	used := make(map[string]bool)
+	for i, segment := range template {
+		if segment != "{}" {
+			continue
+		}
+		name := "id"
+		if i != last && i > 0 && !isTemplateParam(template[i-1]) {
+			name = camelIdentifier(singular(template[i-1])) + "Id"
+		}
+		for base, n := name, 2; used[name]; n++ {
+			name = base + strconv.Itoa(n)
+		}
+		used[name] = true
+		template[i] = "{" + name + "}"
+	}
+}
+
+func looksLikeIdentifier(segment string) bool {
+	if versionSegment.MatchString(segment) {
+		return false
+	}
+	return numericSegment.MatchString(segment) ||
+		uuidSegment.MatchString(segment) ||
+		hexSegment.MatchString(segment) ||
+		(len(segment) >= 8 && tokenSegment.MatchString(segment))
+}
+
+// looksLikeSlug reports whether a segment may be a human-readable key, such
+// as top-5-tips or release-notes-2, rather than a static word. Slugs
+// mix words and numbers; hyphenated words alone (list-all-users) are routes.
+func looksLikeSlug(segment string) bool {
+	return !versionSegment.MatchString(segment) &&
+		slugSegment.MatchString(segment) &&
+		strings.ContainsAny(segment, "0123456789") &&
+		strings.ContainsAny(segment, "abcdefghijklmnopqrstuvwxyz")
+}
+
+func isTemplateParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// camelIdentifier turns a path segment such as order-items into orderItems
+func camelIdentifier(segment string) string {
+	parts := strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' || r == '.' })
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	return strings.Join(parts, "")
+}
+
+func splitPath(p string) []string {
+	trimmed := strings.Trim(p, "/")
+	if trimmed == "" {
+		return []string{}
+	}
+	return strings.Split(trimmed, "/")
+}
+
+var staticExtensions = map[string]bool{
+	".js": true, ".mjs": true, ".css": true, ".map": true, ".html": true, ".htm": true,
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".ico": true, ".webp": true,
+	".woff": true, ".woff2": true, ".ttf": true, ".eot": true,
+}
+
+// isStaticAsset reports whether a request fetched a page or asset rather
+// than calling the API
+func isStaticAsset(p, contentType string) bool {
+	if staticExtensions[strings.ToLower(path.Ext(p))] {
+		return true
+	}
+	mediaType, _, _ := strings.Cut(strings.ToLower(contentType), ";")
+	mediaType = strings.TrimSpace(mediaType)
+	for _, prefix := range []string{"text/html", "text/css", "text/javascript", "application/javascript", "image/", "font/"} {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeTrafficJSON decodes a JSON body, returning nil for empty or non-JSON
+// bodies
+func decodeTrafficJSON(data []byte) interface{} {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil
+	}
+	return value
+}
+
+// trafficAuthType returns the credential scheme carried by request headers
+func trafficAuthType(headers http.Header) string {
+	if authorization := headers.Get("Authorization"); authorization != "" {
+		scheme, _, _ := strings.Cut(authorization, " ")
+		switch strings.ToLower(scheme) {
+		case "basic":
+			return "basic"
+		default:
+			return "bearer"
+		}
+	}
+	for _, name := range []string{"X-API-Key", "Api-Key", "X-Auth-Token"} {
+		if headers.Get(name) != "" {
+			return "apikey"
+		}
+	}
+	return ""
+}
+
+// trafficError extracts the code and message of an error response in the
+// {"error": {"code": ..., "message": ...}} format or with top-level fields
+func trafficError(body interface{}) (string, string) {
+	object, ok := body.(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	if nested, ok := object["error"].(map[string]interface{}); ok {
+		object = nested
+	}
+	code, _ := object["code"].(string)
+	message, _ := object["message"].(string)
+	return code, message
+}
+
+func presence(count, total int) string {
+	if count == total {
+		return ", required"
+	}
+	return ", optional"
+}
+
+func isNumeric(fieldType string) bool {
+	return fieldType == "integer" || fieldType == "number"
+}
+
+func mostFrequent(counts map[int]int) int {
+	best, bestCount := 0, 0
+	for value, count := range counts {
+		if count > bestCount || (count == bestCount && value < best) {
+			best, bestCount = value, count
+		}
+	}
+	return best
+}
+
+func methodOrder(method string) int {
+	for i, m := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+		if m == method {
+			return i
+		}
+	}
+	return 99
+}
@@ -0,0 +1,94 @@
+package importers
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestCollapsePaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			name:  "numeric ids",
+			paths: []string{"/users/1", "/users/2"},
+			want:  []string{"/users/{id}", "/users/{id}"},
+		},
+		{
+			name:  "uuids and nested ids",
+			paths: []string{"/users/7/orders/3f2b8c1e-9d4a-4e1b-8c2a-1b2c3d4e5f60"},
+			want:  []string{"/users/{userId}/orders/{id}"},
+		},
+		{
+			name:  "hex and opaque tokens",
+			paths: []string{"/objects/5f2b8c1e9d4a4e1b", "/customers/cus_9x81b2k"},
+			want:  []string{"/objects/{id}", "/customers/{id}"},
+		},
+		{
+			name:  "static words stay literal however many siblings",
+			paths: []string{"/users/me", "/users/search", "/users/export", "/users/stats", "/users/settings"},
+			want:  []string{"/users/me", "/users/search", "/users/export", "/users/stats", "/users/settings"},
+		},
+		{
+			name:  "static words next to ids",
+			paths: []string{"/users/me", "/users/search", "/users/export", "/users/stats", "/users/42"},
+			want:  []string{"/users/me", "/users/search", "/users/export", "/users/stats", "/users/{id}"},
+		},
+		{
+			name:  "versions stay literal",
+			paths: []string{"/v1/users", "/v2/users", "/v3/users", "/v4/users"},
+			want:  []string{"/v1/users", "/v2/users", "/v3/users", "/v4/users"},
+		},
+		{
+			name:  "many slugs collapse",
+			paths: []string{"/posts/top-5-tips", "/posts/release-notes-2", "/posts/part-3-of-the-series", "/posts/go1-in-production"},
+			want:  []string{"/posts/{id}", "/posts/{id}", "/posts/{id}", "/posts/{id}"},
+		},
+		{
+			name:  "hyphenated static words stay literal",
+			paths: []string{"/admin/list-all-users", "/admin/create-new-user", "/admin/delete-old-users", "/admin/export-all-data"},
+			want:  []string{"/admin/list-all-users", "/admin/create-new-user", "/admin/delete-old-users", "/admin/export-all-data"},
+		},
+		{
+			name:  "few slugs stay literal",
+			paths: []string{"/docs/getting-started-guide", "/docs/release-v2"},
+			want:  []string{"/docs/getting-started-guide", "/docs/release-v2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := make([]learnedRecord, len(tt.paths))
+			for i, path := range tt.paths {
+				records[i] = learnedRecord{method: "GET", segments: splitPath(path)}
+			}
+			for i, template := range collapsePaths(records) {
+				if got := "/" + strings.Join(template, "/"); got != tt.want[i] {
+					t.Errorf("%s collapsed to %s, want %s", tt.paths[i], got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// endpointsByRoute keys endpoints by "METHOD path"
+func endpointsByRoute(api *models.API) map[string]models.Endpoint {
+	endpoints := make(map[string]models.Endpoint)
+	for _, endpoint := range api.Endpoints {
+		endpoints[endpoint.Method+" "+endpoint.Path] = endpoint
+	}
+	return endpoints
+}
+
+func routes(endpoints map[string]models.Endpoint) []string {
+	var keys []string
+	for key := range endpoints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,320 @@
+// Package mock serves the endpoints declared in api.yaml with generated
+// responses, so that clients can be built and tested before the service
+// exists.
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/conformance"
+	"github.com/faisalahmedsifat/architect/internal/fakedata"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// ErrorHeader selects a declared error response instead of the success
+// response. Its value is either a status code (404) or an error code
+// (USER_NOT_FOUND).
+const ErrorHeader = "X-Architect-Error"
+
+// LogFunc is called once for every request served. message explains error
+// responses produced by the mock itself and is empty otherwise.
+type LogFunc func(method, path string, status int, message string)
+
+// Server is an http.Handler serving an API specification. The specification
+// can be replaced while the server is running.
+type Server struct {
+	mu   sync.RWMutex
+	api  *models.API
+	log  LogFunc
+	fake *fakedata.Generator
+
+	// fakeMu guards fake, which is not safe for concurrent use
+	fakeMu sync.Mutex
+}
+
+// NewServer creates a mock server for an API. Responses are generated from
+// seed, so a server created with the same seed produces the same data.
+func NewServer(api *models.API, seed int64, log LogFunc) *Server {
+	if log == nil {
+		log = func(string, string, int, string) {}
+	}
+	return &Server{api: api, log: log, fake: fakedata.New(seed)}
+}
+
+// SetAPI replaces the specification served by the server
+func (s *Server) SetAPI(api *models.API) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.api = api
+}
+
+// API returns the specification currently served
+func (s *Server) API() *models.API {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.api
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Mocks are mostly used by browser frontends served from another origin
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Headers", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Expose-Headers", "*")
+	if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+		w.WriteHeader(http.StatusNoContent)
+		s.log(r.Method, r.URL.Path, http.StatusNoContent, "")
+		return
+	}
+
+	api := s.API()
+	endpoint, params, ok := conformance.FindEndpoint(api, r.Method, r.URL.Path)
+	if !ok {
+		if allowed := allowedMethods(api, r.URL.Path); len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			s.writeError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+				fmt.Sprintf("%s is not declared for this path, use %s", r.Method, strings.Join(allowed, ", ")), nil)
+			return
+		}
+		s.writeError(w, r, http.StatusNotFound, "NOT_FOUND", "No endpoint in api.yaml matches this path", nil)
+		return
+	}
+
+	if selector := r.Header.Get(ErrorHeader); selector != "" {
+		declared, ok := findError(endpoint, selector)
+		if !ok {
+			s.writeError(w, r, http.StatusBadRequest, "MOCK_ERROR_NOT_DECLARED",
+				fmt.Sprintf("%s: %q is not declared for %s %s", ErrorHeader, selector, endpoint.Method, endpoint.Path),
+				map[string]interface{}{"declared": declaredErrors(endpoint)})
+			return
+		}
+		s.writeDeclaredError(w, r, declared, "")
+		return
+	}
+
+	if endpoint.Auth && r.Header.Get("Authorization") == "" && r.Header.Get("X-API-Key") == "" {
+		s.writeErrorFor(w, r, endpoint, []int{http.StatusUnauthorized}, "UNAUTHORIZED", "Authentication required")
+		return
+	}
+
+	body, violations := validateRequest(endpoint, params, r)
+	if len(violations) > 0 {
+		details := make(map[string]interface{}, len(violations))
+		for _, violation := range violations {
+			field := violation.Field
+			if field == "" {
+				field = "body"
+			}
+			details[field] = violation.Message
+		}
+		declared, ok := errorForStatus(endpoint, http.StatusBadRequest, http.StatusUnprocessableEntity)
+		if !ok {
+			declared = models.ErrorResponse{Status: http.StatusBadRequest, Code: "VALIDATION_ERROR"}
+		}
+		declared.Message = "Request validation failed"
+		s.writeJSON(w, r, declared.Status, errorBody(declared.Code, declared.Message, details), violations[0].String())
+		return
+	}
+
+	s.writeSuccess(w, r, endpoint, params, body)
+}
+
+// validateRequest checks the path parameters, query and body of a request
+// and returns the decoded body
+func validateRequest(endpoint models.Endpoint, params map[string]string, r *http.Request) (interface{}, []conformance.Violation) {
+	var violations []conformance.Violation
+	var body interface{}
+
+	raw, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, []conformance.Violation{{Message: fmt.Sprintf("failed to read request body: %v", err)}}
+	}
+	if len(bytes.TrimSpace(raw)) > 0 {
+		if body, err = conformance.DecodeJSON(raw); err != nil {
+			return nil, []conformance.Violation{{Message: "request body is not valid JSON"}}
+		}
+	}
+
+	if endpoint.Request == nil {
+		return body, nil
+	}
+
+	violations = append(violations, conformance.ValidatePathParams(endpoint.Request.Params, params)...)
+	violations = append(violations, conformance.ValidateQuery(endpoint.Request.Query, r.URL.Query())...)
+	if len(endpoint.Request.Body) > 0 {
+		if body == nil {
+			// An empty body is reported as missing fields rather than a null body
+			body = map[string]interface{}{}
+		}
+		violations = append(violations, conformance.ValidateBody(endpoint.Request.Body, body)...)
+	}
+	return body, violations
+}
+
+// writeSuccess writes the declared response with a generated body. Response
+// fields named like a path parameter or request body field echo the value
+// sent by the client, so that GET /users/42 returns a user with id 42.
+func (s *Server) writeSuccess(w http.ResponseWriter, r *http.Request, endpoint models.Endpoint, params map[string]string, body interface{}) {
+	status := http.StatusOK
+	if strings.EqualFold(endpoint.Method, http.MethodPost) {
+		status = http.StatusCreated
+	}
+	if endpoint.Response == nil || len(endpoint.Response.Body) == 0 {
+		if endpoint.Response != nil && endpoint.Response.Status != 0 {
+			status = endpoint.Response.Status
+		} else if endpoint.Response == nil {
+			status = http.StatusNoContent
+		}
+		w.WriteHeader(status)
+		s.log(r.Method, r.URL.Path, status, "")
+		return
+	}
+	if endpoint.Response.Status != 0 {
+		status = endpoint.Response.Status
+	}
+
+	s.fakeMu.Lock()
+	response := s.fake.Body(endpoint.Response.Body)
+	s.fakeMu.Unlock()
+
+	sent, _ := body.(map[string]interface{})
+	for name, def := range endpoint.Response.Body {
+		field := models.ParseField(def)
+		if value, ok := sent[name]; ok && conformance.ValidateValue(field, value) == "" {
+			response[name] = value
+			continue
+		}
+		if value, ok := params[name]; ok {
+			if converted := paramValue(field, value); conformance.ValidateValue(field, converted) == "" {
+				response[name] = converted
+			}
+		}
+	}
+
+	s.writeJSON(w, r, status, response, "")
+}
+
+// paramValue converts a path parameter to the JSON type of a response field
+func paramValue(field models.Field, value string) interface{} {
+	switch field.Type {
+	case "integer", "number":
+		return json.Number(value)
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// writeErrorFor writes the error declared for the first matching status, or
+// a generic error with the given code when none is declared
+func (s *Server) writeErrorFor(w http.ResponseWriter, r *http.Request, endpoint models.Endpoint, statuses []int, code, message string) {
+	if declared, ok := errorForStatus(endpoint, statuses...); ok {
+		s.writeDeclaredError(w, r, declared, message)
+		return
+	}
+	s.writeError(w, r, statuses[0], code, message, nil)
+}
+
+func (s *Server) writeDeclaredError(w http.ResponseWriter, r *http.Request, declared models.ErrorResponse, note string) {
+	message := declared.Message
+	if message == "" {
+		message = http.StatusText(declared.Status)
+	}
+	code := declared.Code
+	if code == "" {
+		code = strings.ToUpper(strings.ReplaceAll(http.StatusText(declared.Status), " ", "_"))
+	}
+	s.writeJSON(w, r, declared.Status, errorBody(code, message, nil), note)
+}
+
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, status int, code, message string, details map[string]interface{}) {
+	s.writeJSON(w, r, status, errorBody(code, message, details), message)
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, status int, body interface{}, note string) {
+	data, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		s.log(r.Method, r.URL.Path, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(append(data, '\n'))
+	s.log(r.Method, r.URL.Path, status, note)
+}
+
+// errorBody builds an error in the format documented in the generated rules
+func errorBody(code, message string, details map[string]interface{}) map[string]interface{} {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":      code,
+			"message":   message,
+			"details":   details,
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+}
+
+// findError returns the declared error selected by a status or error code
+func findError(endpoint models.Endpoint, selector string) (models.ErrorResponse, bool) {
+	if status, err := strconv.Atoi(selector); err == nil {
+		return errorForStatus(endpoint, status)
+	}
+	for _, declared := range endpoint.Errors {
+		if strings.EqualFold(declared.Code, selector) {
+			return declared, true
+		}
+	}
+	return models.ErrorResponse{}, false
+}
+
+// errorForStatus returns the first declared error with one of the statuses
+func errorForStatus(endpoint models.Endpoint, statuses ...int) (models.ErrorResponse, bool) {
+	for _, status := range statuses {
+		for _, declared := range endpoint.Errors {
+			if declared.Status == status {
+				return declared, true
+			}
+		}
+	}
+	return models.ErrorResponse{}, false
+}
+
+func declaredErrors(endpoint models.Endpoint) []string {
+	result := make([]string, 0, len(endpoint.Errors))
+	for _, declared := range endpoint.Errors {
+		result = append(result, fmt.Sprintf("%d %s", declared.Status, declared.Code))
+	}
+	return result
+}
+
+// allowedMethods returns the methods declared for a path
+func allowedMethods(api *models.API, path string) []string {
+	path = conformance.StripBaseURL(api.BaseURL, path)
+	seen := make(map[string]bool)
+	var methods []string
+	for _, endpoint := range api.Endpoints {
+		method := strings.ToUpper(endpoint.Method)
+		if _, ok := conformance.MatchPath(endpoint.Path, path); ok && !seen[method] {
+			seen[method] = true
+			methods = append(methods, method)
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
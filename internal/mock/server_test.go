@@ -0,0 +1,187 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func mockAPI() *models.API {
+	return &models.API{
+		BaseURL: "/api/v1",
+		Endpoints: []models.Endpoint{
+			{
+				Method: "GET",
+				Path:   "/users/{id}",
+				Request: &models.EndpointRequest{
+					Params: map[string]string{"id": "integer, required"},
+				},
+				Response: &models.EndpointResponse{Status: 200, Body: map[string]string{
+					"id":         "integer, required",
+					"email":      "string, required",
+					"created_at": "datetime, required",
+					"token":      "uuid, required",
+				}},
+				Errors: []models.ErrorResponse{
+					{Status: 404, Code: "USER_NOT_FOUND", Message: "User not found"},
+					{Status: 403, Code: "FORBIDDEN", Message: "Not allowed"},
+				},
+			},
+			{
+				Method: "POST",
+				Path:   "/users",
+				Request: &models.EndpointRequest{
+					Body: map[string]string{"email": "string, required, email", "name": "string, optional"},
+				},
+				Response: &models.EndpointResponse{Body: map[string]string{
+					"id":    "uuid, required",
+					"email": "string, required",
+				}},
+				Errors: []models.ErrorResponse{{Status: 422, Code: "INVALID_USER", Message: "Invalid user"}},
+			},
+			{
+				Method:   "DELETE",
+				Path:     "/users/{id}",
+				Auth:     true,
+				Response: &models.EndpointResponse{Status: 204},
+			},
+			{Method: "POST", Path: "/logout"},
+		},
+	}
+}
+
+type mockResponse struct {
+	status int
+	header http.Header
+	body   map[string]interface{}
+}
+
+func serve(t *testing.T, server *Server, method, path, body string, headers map[string]string) mockResponse {
+	t.Helper()
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, request)
+
+	response := mockResponse{status: recorder.Code, header: recorder.Header()}
+	if recorder.Body.Len() > 0 {
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response.body); err != nil {
+			t.Fatalf("%s %s: body is not JSON: %v\n%s", method, path, err, recorder.Body.String())
+		}
+	}
+	return response
+}
+
+func errorCode(body map[string]interface{}) string {
+	errorObject, _ := body["error"].(map[string]interface{})
+	code, _ := errorObject["code"].(string)
+	return code
+}
+
+func TestServerRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		headers map[string]string
+		status  int
+		code    string
+	}{
+		{name: "path parameter", method: "GET", path: "/api/v1/users/42", status: 200},
+		{name: "invalid path parameter", method: "GET", path: "/api/v1/users/abc", status: 400, code: "VALIDATION_ERROR"},
+		{name: "created by default for POST", method: "POST", path: "/api/v1/users", body: `{"email": "a@b.co"}`, status: 201},
+		{name: "declared validation error", method: "POST", path: "/api/v1/users", body: `{"name": "Ann"}`, status: 422, code: "INVALID_USER"},
+		{name: "invalid JSON", method: "POST", path: "/api/v1/users", body: `{`, status: 422, code: "INVALID_USER"},
+		{name: "declared status without body", method: "DELETE", path: "/api/v1/users/42", headers: map[string]string{"Authorization": "Bearer x"}, status: 204},
+		{name: "no content without a response", method: "POST", path: "/api/v1/logout", status: 204},
+		{name: "auth required", method: "DELETE", path: "/api/v1/users/42", status: 401, code: "UNAUTHORIZED"},
+		{name: "error selected by status", method: "GET", path: "/api/v1/users/42", headers: map[string]string{ErrorHeader: "404"}, status: 404, code: "USER_NOT_FOUND"},
+		{name: "error selected by code", method: "GET", path: "/api/v1/users/42", headers: map[string]string{ErrorHeader: "forbidden"}, status: 403, code: "FORBIDDEN"},
+		{name: "undeclared error", method: "GET", path: "/api/v1/users/42", headers: map[string]string{ErrorHeader: "500"}, status: 400, code: "MOCK_ERROR_NOT_DECLARED"},
+		{name: "undeclared route", method: "GET", path: "/api/v1/orders", status: 404, code: "NOT_FOUND"},
+		{name: "without the base path", method: "GET", path: "/users/42", status: 200},
+		{name: "undeclared method", method: "PATCH", path: "/api/v1/users/42", status: 405, code: "METHOD_NOT_ALLOWED"},
+	}
+
+	server := NewServer(mockAPI(), 1, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := serve(t, server, tt.method, tt.path, tt.body, tt.headers)
+			if response.status != tt.status {
+				t.Errorf("status = %d, want %d (%v)", response.status, tt.status, response.body)
+			}
+			if code := errorCode(response.body); code != tt.code {
+				t.Errorf("error code = %q, want %q", code, tt.code)
+			}
+		})
+	}
+
+	if allow := serve(t, server, "PATCH", "/api/v1/users/42", "", nil).header.Get("Allow"); allow != "DELETE, GET" {
+		t.Errorf("Allow = %q, want DELETE, GET", allow)
+	}
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestServerFakeData(t *testing.T) {
+	server := NewServer(mockAPI(), 1, nil)
+	response := serve(t, server, "GET", "/api/v1/users/42", "", nil)
+
+	// Fields named like a path parameter echo it with the declared type
+	if id, ok := response.body["id"].(float64); !ok || id != 42 {
+		t.Errorf("id = %#v, want 42", response.body["id"])
+	}
+	if _, err := time.Parse(time.RFC3339, response.body["created_at"].(string)); err != nil {
+		t.Errorf("created_at is not a datetime: %v", err)
+	}
+	if token, _ := response.body["token"].(string); !uuidPattern.MatchString(token) {
+		t.Errorf("token = %q, want a uuid", token)
+	}
+	if email, _ := response.body["email"].(string); !strings.Contains(email, "@") {
+		t.Errorf("email = %q, want an email address", email)
+	}
+
+	// Fields sent in the request body are echoed back
+	created := serve(t, server, "POST", "/api/v1/users", `{"email": "ann@example.com"}`, nil)
+	if created.body["email"] != "ann@example.com" {
+		t.Errorf("email = %#v, want the email sent", created.body["email"])
+	}
+
+	// The same seed produces the same data
+	again := serve(t, NewServer(mockAPI(), 1, nil), "GET", "/api/v1/users/42", "", nil)
+	if again.body["token"] != response.body["token"] {
+		t.Errorf("token %v differs from %v with the same seed", again.body["token"], response.body["token"])
+	}
+}
+
+func TestServerLogsAndReloads(t *testing.T) {
+	var logged []string
+	server := NewServer(mockAPI(), 1, func(method, path string, status int, message string) {
+		logged = append(logged, method+" "+path)
+	})
+
+	serve(t, server, "GET", "/api/v1/orders", "", nil)
+	server.SetAPI(&models.API{BaseURL: "/api/v1", Endpoints: []models.Endpoint{{Method: "GET", Path: "/orders"}}})
+	if status := serve(t, server, "GET", "/api/v1/orders", "", nil).status; status != 204 {
+		t.Errorf("status after reload = %d, want 204", status)
+	}
+	if len(logged) != 2 {
+		t.Errorf("logged = %v, want both requests", logged)
+	}
+}
+
+func TestServerCORSPreflight(t *testing.T) {
+	response := serve(t, NewServer(mockAPI(), 1, nil), "OPTIONS", "/api/v1/users", "", map[string]string{"Access-Control-Request-Method": "POST"})
+	if response.status != http.StatusNoContent || response.header.Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("preflight = %d %v", response.status, response.header)
+	}
+}
@@ -0,0 +1,153 @@
+package mock
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/fakedata"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// WebhookSample is a generated webhook delivery: a payload with every
+// declared field and the declared headers
+type WebhookSample struct {
+	Body   []byte
+	Header http.Header
+}
+
+// WebhookAttempt is the outcome of one delivery attempt. Status is zero when
+// the request failed.
+type WebhookAttempt struct {
+	Attempt  int
+	Status   int
+	Err      error
+	Duration time.Duration
+	// Retry is the delay before the next attempt, zero after the last one
+	Retry time.Duration
+}
+
+// eventHeaders are the declared headers that carry the event name rather
+// than a generated value. Other headers such as X-Event-Id keep their type.
+var eventHeaders = map[string]bool{
+	"X-Event-Type":    true,
+	"X-Event-Name":    true,
+	"X-Webhook-Event": true,
+}
+
+// NewWebhookSample generates a delivery for a webhook. With a secret, the
+// delivery carries the declared signature header.
+func NewWebhookSample(webhook models.Webhook, fake *fakedata.Generator, secret string) (WebhookSample, error) {
+	body, err := json.Marshal(fake.Body(webhook.Payload))
+	if err != nil {
+		return WebhookSample{}, err
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	header.Set("User-Agent", "architect-webhook-mock")
+	for name, def := range webhook.Headers {
+		if eventHeaders[http.CanonicalHeaderKey(name)] {
+			header.Set(name, webhook.Name)
+			continue
+		}
+		header.Set(name, fmt.Sprint(fake.Value(name, models.ParseField(def))))
+	}
+
+	if webhook.Signature != nil && secret != "" {
+		signature, err := SignWebhook(*webhook.Signature, secret, body)
+		if err != nil {
+			return WebhookSample{}, err
+		}
+		header.Set(webhook.Signature.Header, signature)
+	}
+
+	return WebhookSample{Body: body, Header: header}, nil
+}
+
+// SignWebhook computes the signature header value of a delivery body
+func SignWebhook(signature models.WebhookSignature, secret string, body []byte) (string, error) {
+	var algorithm func() hash.Hash
+	switch strings.ToLower(signature.Algorithm) {
+	case "hmac-sha256", "sha256", "":
+		algorithm = sha256.New
+	case "hmac-sha512", "sha512":
+		algorithm = sha512.New
+	case "hmac-sha1", "sha1":
+		algorithm = sha1.New
+	default:
+		return "", fmt.Errorf("unsupported signature algorithm: %s", signature.Algorithm)
+	}
+
+	mac := hmac.New(algorithm, []byte(secret))
+	mac.Write(body)
+	return signature.Prefix + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// DeliverWebhook posts a sample delivery to url. When retry is set, failed
+// attempts are retried as declared by the webhook. Every attempt is passed
+// to observe; the returned error reports a delivery that never succeeded.
+func DeliverWebhook(client *http.Client, url string, webhook models.Webhook, sample WebhookSample, retry bool, observe func(WebhookAttempt)) error {
+	attempts := 1
+	delay := time.Second
+	backoff := "fixed"
+	if retry && webhook.Retry != nil {
+		if webhook.Retry.MaxAttempts > 1 {
+			attempts = webhook.Retry.MaxAttempts
+		}
+		if webhook.Retry.Interval != "" {
+			parsed, err := time.ParseDuration(webhook.Retry.Interval)
+			if err != nil {
+				return fmt.Errorf("webhook %s: invalid retry interval %q", webhook.Name, webhook.Retry.Interval)
+			}
+			delay = parsed
+		}
+		backoff = webhook.Retry.Backoff
+	}
+
+	for attempt := 1; attempt <= attempts; attempt++ {
+		request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(sample.Body))
+		if err != nil {
+			return err
+		}
+		request.Header = sample.Header.Clone()
+
+		start := time.Now()
+		result := WebhookAttempt{Attempt: attempt}
+		response, err := client.Do(request)
+		result.Duration = time.Since(start)
+		if err != nil {
+			result.Err = err
+		} else {
+			result.Status = response.StatusCode
+			response.Body.Close()
+		}
+
+		succeeded := result.Err == nil && result.Status >= 200 && result.Status < 300
+		if !succeeded && attempt < attempts {
+			result.Retry = delay
+		}
+		observe(result)
+		if succeeded {
+			return nil
+		}
+
+		if attempt < attempts {
+			time.Sleep(delay)
+			if backoff == "exponential" {
+				delay *= 2
+			}
+		}
+	}
+
+	return fmt.Errorf("webhook %s was not acknowledged after %d attempt(s)", webhook.Name, attempts)
+}
@@ -0,0 +1,73 @@
+package mock
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/fakedata"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestNewWebhookSampleHeaders(t *testing.T) {
+	webhook := models.Webhook{
+		Name:    "order.paid",
+		Payload: map[string]string{"id": "uuid, required"},
+		Headers: map[string]string{
+			"X-Event-Type":      "string, required",
+			"X-Event-Id":        "uuid, required",
+			"X-Event-Timestamp": "integer, required",
+		},
+		Signature: &models.WebhookSignature{Header: "X-Signature", Algorithm: "hmac-sha256", Prefix: "sha256="},
+	}
+
+	sample, err := NewWebhookSample(webhook, fakedata.New(1), "whsec_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := sample.Header.Get("X-Event-Type"); got != "order.paid" {
+		t.Errorf("X-Event-Type = %q, want the event name", got)
+	}
+	for _, name := range []string{"X-Event-Id", "X-Event-Timestamp"} {
+		if got := sample.Header.Get(name); got == "" || got == "order.paid" {
+			t.Errorf("%s = %q, want a generated value", name, got)
+		}
+	}
+
+	signature, err := SignWebhook(*webhook.Signature, "whsec_test", sample.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sample.Header.Get("X-Signature"); got != signature || !strings.HasPrefix(got, "sha256=") {
+		t.Errorf("X-Signature = %q, want %q", got, signature)
+	}
+}
+
+func TestDeliverWebhookRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	webhook := models.Webhook{Name: "order.paid", Retry: &models.WebhookRetry{MaxAttempts: 3, Interval: "1ms"}}
+	sample := WebhookSample{Body: []byte(`{}`), Header: http.Header{}}
+
+	var attempts []WebhookAttempt
+	err := DeliverWebhook(server.Client(), server.URL, webhook, sample, true, func(attempt WebhookAttempt) {
+		attempts = append(attempts, attempt)
+	})
+	if err != nil {
+		t.Fatalf("delivery failed: %v", err)
+	}
+	if len(attempts) != 3 || attempts[2].Status != http.StatusNoContent {
+		t.Errorf("attempts = %+v, want success on the third", attempts)
+	}
+}
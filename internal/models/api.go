@@ -3,7 +3,18 @@ package models
 type API struct {
 	BaseURL   string     `yaml:"base_url"`
 	AuthType  string     `yaml:"auth_type"`
+	Servers   []Server   `yaml:"servers,omitempty"`
 	Endpoints []Endpoint `yaml:"endpoints"`
+	GraphQL   *GraphQL   `yaml:"graphql,omitempty"`
+	Channels  []Channel  `yaml:"channels,omitempty"`
+	Webhooks  []Webhook  `yaml:"webhooks,omitempty"`
+}
+
+// Server is a deployment of the API, such as staging or production. Paths
+// are relative to the server URL like they are to the base URL.
+type Server struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
 }
 
 type Endpoint struct {
@@ -11,6 +22,7 @@ type Endpoint struct {
 	Method      string            `yaml:"method"`
 	Description string            `yaml:"description"`
 	Auth        bool              `yaml:"auth"`
+	RPC         string            `yaml:"rpc,omitempty"` // gRPC method serving the endpoint, as package.Service/Method
 	Request     *EndpointRequest  `yaml:"request,omitempty"`
 	Response    *EndpointResponse `yaml:"response,omitempty"`
 	Errors      []ErrorResponse   `yaml:"errors,omitempty"`
@@ -23,8 +35,9 @@ type EndpointRequest struct {
 }
 
 type EndpointResponse struct {
-	Status int               `yaml:"status"`
-	Body   map[string]string `yaml:"body,omitempty"`
+	Status  int               `yaml:"status"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Body    map[string]string `yaml:"body,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -32,3 +45,79 @@ type ErrorResponse struct {
 	Code    string `yaml:"code"`
 	Message string `yaml:"message"`
 }
+
+// GraphQL describes a GraphQL API served next to the REST endpoints. Types
+// are written in GraphQL notation, such as "[Post!]!".
+type GraphQL struct {
+	Path       string             `yaml:"path"`
+	Operations []GraphQLOperation `yaml:"operations"`
+	Types      []GraphQLType      `yaml:"types,omitempty"`
+}
+
+// GraphQLOperation is a field of the Query, Mutation or Subscription type
+type GraphQLOperation struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"` // query, mutation or subscription
+	Description string `yaml:"description,omitempty"`
+	// Input maps argument names to their type and optional default value,
+	// such as "Int = 10"
+	Input  map[string]string `yaml:"input,omitempty"`
+	Output string            `yaml:"output"`
+}
+
+// GraphQLType is a named type of the schema. Fields map to their type, and
+// input fields to their type and optional default value.
+type GraphQLType struct {
+	Name        string            `yaml:"name"`
+	Kind        string            `yaml:"kind"` // type, input, interface, enum, union or scalar
+	Description string            `yaml:"description,omitempty"`
+	Implements  []string          `yaml:"implements,omitempty"`
+	Fields      map[string]string `yaml:"fields,omitempty"`
+	// Arguments maps the fields taking arguments to them, written like
+	// operation inputs: {"posts": {"first": "Int = 10"}}
+	Arguments map[string]map[string]string `yaml:"arguments,omitempty"`
+	// Values holds enum values and union member types
+	Values []string `yaml:"values,omitempty"`
+}
+
+// Channel is a message channel whose events are part of the contract, such
+// as a Kafka topic or NATS subject. A channel carrying several messages, or
+// both produced and consumed by the service, has one entry per message and
+// role.
+type Channel struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	// Role is producer when the service publishes the message and consumer
+	// when it receives it
+	Role    string            `yaml:"role"`
+	Message string            `yaml:"message,omitempty"`
+	Payload map[string]string `yaml:"payload,omitempty"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+}
+
+// Webhook is an outbound webhook the API delivers to its subscribers as a
+// JSON POST request
+type Webhook struct {
+	Name        string            `yaml:"name"` // event name, such as order.paid
+	Description string            `yaml:"description,omitempty"`
+	Payload     map[string]string `yaml:"payload,omitempty"`
+	Headers     map[string]string `yaml:"headers,omitempty"`
+	Signature   *WebhookSignature `yaml:"signature,omitempty"`
+	Retry       *WebhookRetry     `yaml:"retry,omitempty"`
+}
+
+// WebhookSignature describes how deliveries are signed: an HMAC of the raw
+// request body with the subscriber's secret, hex encoded in a header
+type WebhookSignature struct {
+	Header    string `yaml:"header"`           // such as X-Signature
+	Algorithm string `yaml:"algorithm"`        // hmac-sha256, hmac-sha512 or hmac-sha1
+	Prefix    string `yaml:"prefix,omitempty"` // prepended to the digest, such as "sha256="
+}
+
+// WebhookRetry describes how failed deliveries (non-2xx responses and
+// timeouts) are retried
+type WebhookRetry struct {
+	MaxAttempts int    `yaml:"max_attempts"`
+	Backoff     string `yaml:"backoff,omitempty"`  // fixed or exponential
+	Interval    string `yaml:"interval,omitempty"` // delay before the first retry, such as 30s
+}
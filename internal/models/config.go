@@ -0,0 +1,23 @@
+package models
+
+// Config holds optional tool settings from .architect/config.yaml
+type Config struct {
+	Validate ValidateConfig `yaml:"validate,omitempty"`
+
+	// Plugins are the names of the architect-<name> plugins whose import
+	// formats, export formats and rule targets are used. Other executables
+	// on PATH are never run except as "architect <name>" commands.
+	Plugins []string `yaml:"plugins,omitempty"`
+}
+
+// ValidateConfig configures the validate command
+type ValidateConfig struct {
+	// StubsDir is where `validate --fix --write` puts generated handler stubs
+	StubsDir string `yaml:"stubs_dir,omitempty"`
+
+	// Include restricts source scanning to files matching these globs
+	Include []string `yaml:"include,omitempty"`
+
+	// Exclude skips files and directories matching these globs
+	Exclude []string `yaml:"exclude,omitempty"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "strings"
+
+// Field is a parsed field definition such as "string, required, email"
+type Field struct {
+	Type     string
+	Required bool
+	Optional bool
+	Rules    []string
+}
+
+// ParseField parses a comma separated field definition from api.yaml.
+// Fields are optional unless explicitly marked as required.
+func ParseField(def string) Field {
+	parts := strings.Split(def, ",")
+	field := Field{Type: strings.TrimSpace(parts[0])}
+	if field.Type == "" {
+		field.Type = "string"
+	}
+
+	for _, part := range parts[1:] {
+		part = strings.TrimSpace(part)
+		switch part {
+		case "":
+		case "required":
+			field.Required = true
+		case "optional":
+			field.Optional = true
+		default:
+			field.Rules = append(field.Rules, part)
+		}
+	}
+
+	return field
+}
+
+// HasRule reports whether the field carries the given validation rule
+func (f Field) HasRule(rule string) bool {
+	for _, r := range f.Rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"os"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// ParseProjectMarkdown reads a project.md written by Project.ToMarkdown and
+// recovers the project name, description, tech stack and business logic
+func ParseProjectMarkdown(filepath string) (*models.Project, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	project := &models.Project{}
+	section := ""
+	logicTitle := ""
+	var description []string
+
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "### "):
+			logicTitle = strings.TrimSpace(strings.TrimPrefix(trimmed, "### "))
+			if section == "business logic" {
+				if project.BusinessLogic == nil {
+					project.BusinessLogic = make(map[string]string)
+				}
+				project.BusinessLogic[logicTitle] = ""
+			}
+			continue
+		case strings.HasPrefix(trimmed, "## "):
+			section = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(trimmed, "## ")))
+			logicTitle = ""
+			continue
+		case strings.HasPrefix(trimmed, "# "):
+			project.Name = strings.TrimSpace(strings.TrimPrefix(trimmed, "# "))
+			continue
+		}
+
+		switch section {
+		case "overview":
+			if trimmed != "" {
+				description = append(description, trimmed)
+			}
+		case "tech stack":
+			key, value, ok := strings.Cut(strings.TrimPrefix(trimmed, "- "), ":")
+			if !ok {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			switch strings.ToLower(strings.TrimSpace(key)) {
+			case "backend":
+				project.TechStack.Backend = value
+			case "database":
+				project.TechStack.Database = value
+			case "auth":
+				project.TechStack.Auth = value
+			}
+		case "business logic":
+			if logicTitle != "" && trimmed != "" {
+				content := project.BusinessLogic[logicTitle]
+				if content != "" {
+					content += "\n"
+				}
+				project.BusinessLogic[logicTitle] = content + trimmed
+			}
+		}
+	}
+
+	project.Description = strings.Join(description, "\n")
+	return project, nil
+}
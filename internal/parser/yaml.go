@@ -34,3 +34,63 @@ func ParseProjectYAML(filepath string) (*models.Project, error) {
 
 	return &project, nil
 }
+
+// EndpointLines maps each endpoint in an api.yaml file, keyed by
+// "METHOD path", to the line on which it is declared
+func EndpointLines(filepath string) (map[string]int, error) {
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	lines := make(map[string]int)
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return lines, nil
+	}
+
+	doc := root.Content[0]
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		if doc.Content[i].Value != "endpoints" || doc.Content[i+1].Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, item := range doc.Content[i+1].Content {
+			var method, path string
+			for j := 0; j+1 < len(item.Content); j += 2 {
+				switch item.Content[j].Value {
+				case "method":
+					method = item.Content[j+1].Value
+				case "path":
+					path = item.Content[j+1].Value
+				}
+			}
+			lines[method+" "+path] = item.Line
+		}
+	}
+
+	return lines, nil
+}
+
+// ParseConfigYAML reads .architect/config.yaml. A missing file yields an
+// empty configuration.
+func ParseConfigYAML(filepath string) (*models.Config, error) {
+	var config models.Config
+
+	data, err := os.ReadFile(filepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &config, nil
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
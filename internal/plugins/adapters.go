@@ -0,0 +1,263 @@
+package plugins
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/exporters"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// Importer imports a format through a plugin
+type Importer struct {
+	Plugin     *Plugin
+	Capability ImporterCapability
+}
+
+// Exporter exports a format through a plugin
+type Exporter struct {
+	Plugin     *Plugin
+	Capability ExporterCapability
+}
+
+// RuleTarget generates the rules of an AI assistant through a plugin
+type RuleTarget struct {
+	Plugin     *Plugin
+	Capability RuleTargetCapability
+}
+
+// enabled returns the enabled plugins, or none when the plugins list cannot
+// be read; commands reading .architect/config.yaml report that error
+func enabled() []*Plugin {
+	list, err := Enabled()
+	if err != nil {
+		return nil
+	}
+	return list
+}
+
+// FindImporter returns the plugin importer for a format
+func FindImporter(format string) (*Importer, bool) {
+	for _, plugin := range enabled() {
+		for _, capability := range plugin.Importers {
+			if capability.Format == format {
+				return &Importer{Plugin: plugin, Capability: capability}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// DetectImporter returns the plugin importer declaring the extension of a file
+func DetectImporter(filename string) (*Importer, bool) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext == "" {
+		return nil, false
+	}
+	for _, plugin := range enabled() {
+		for _, capability := range plugin.Importers {
+			for _, supported := range capability.Extensions {
+				if strings.ToLower(supported) == ext {
+					return &Importer{Plugin: plugin, Capability: capability}, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// FindExporter returns the plugin exporter for a format
+func FindExporter(format string) (*Exporter, bool) {
+	for _, plugin := range enabled() {
+		for _, capability := range plugin.Exporters {
+			if capability.Format == format {
+				return &Exporter{Plugin: plugin, Capability: capability}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// Exporters returns the exporters of every enabled plugin
+func Exporters() []*Exporter {
+	var list []*Exporter
+	for _, plugin := range enabled() {
+		for _, capability := range plugin.Exporters {
+			list = append(list, &Exporter{Plugin: plugin, Capability: capability})
+		}
+	}
+	return list
+}
+
+// RuleTargets returns the rule targets of every enabled plugin
+func RuleTargets() ([]*RuleTarget, error) {
+	plugins, err := Enabled()
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*RuleTarget
+	for _, plugin := range plugins {
+		for _, capability := range plugin.RuleTargets {
+			list = append(list, &RuleTarget{Plugin: plugin, Capability: capability})
+		}
+	}
+	return list, nil
+}
+
+// Import sends the file to the plugin and reads back the API
+func (i *Importer) Import(filename string) (*models.API, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+
+	response, err := call(i.Plugin.Path, Request{
+		Action:   "import",
+		Format:   i.Capability.Format,
+		Filename: filename,
+		Content:  string(content),
+	}, CallTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.API) == 0 {
+		return nil, fmt.Errorf("%s returned no api", filepath.Base(i.Plugin.Path))
+	}
+
+	api, err := fromProtocol(response.API)
+	if err != nil {
+		return nil, fmt.Errorf("%s returned an invalid api: %w", filepath.Base(i.Plugin.Path), err)
+	}
+	return api, nil
+}
+
+// Validate checks if the imported API is valid
+func (i *Importer) Validate(api *models.API) error {
+	if api == nil {
+		return fmt.Errorf("API cannot be nil")
+	}
+
+	for idx, endpoint := range api.Endpoints {
+		if endpoint.Path == "" {
+			return fmt.Errorf("endpoint %d: path is required", idx)
+		}
+		if endpoint.Method == "" {
+			return fmt.Errorf("endpoint %d: method is required", idx)
+		}
+	}
+
+	return nil
+}
+
+// GetSupportedExtensions returns the file extensions the plugin declares
+func (i *Importer) GetSupportedExtensions() []string {
+	return i.Capability.Extensions
+}
+
+// Name returns the format name used with --format
+func (e *Exporter) Name() string {
+	return e.Capability.Format
+}
+
+// Description returns the description the plugin declares
+func (e *Exporter) Description() string {
+	description := e.Capability.Description
+	if description == "" {
+		description = e.Plugin.Description
+	}
+	return fmt.Sprintf("%s (plugin %s)", description, e.Plugin.Name)
+}
+
+// GetSupportedExtensions returns the file extensions the plugin declares
+func (e *Exporter) GetSupportedExtensions() []string {
+	return e.Capability.Extensions
+}
+
+// DefaultFilename returns the declared file name, or one named after the
+// format
+func (e *Exporter) DefaultFilename() string {
+	if e.Capability.DefaultFilename != "" {
+		return e.Capability.DefaultFilename
+	}
+	if len(e.Capability.Extensions) > 0 {
+		return "api" + e.Capability.Extensions[0]
+	}
+	return "api." + e.Capability.Format
+}
+
+// Options returns the options the plugin declares
+func (e *Exporter) Options() []exporters.Option {
+	var options []exporters.Option
+	for _, option := range e.Capability.Options {
+		options = append(options, exporters.Option{
+			Name:        option.Name,
+			Description: option.Description,
+			Default:     option.Default,
+		})
+	}
+	return options
+}
+
+// Export sends the API to the plugin and reads back the exported files
+func (e *Exporter) Export(api *models.API, options map[string]string) (*exporters.Output, error) {
+	payload, err := toProtocol(api)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := call(e.Plugin.Path, Request{
+		Action:  "export",
+		Format:  e.Capability.Format,
+		API:     payload,
+		Options: options,
+	}, CallTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range response.Companions {
+		if filepath.Base(name) != name || name == "." || name == ".." {
+			return nil, fmt.Errorf("%s returned an invalid companion file name %q", filepath.Base(e.Plugin.Path), name)
+		}
+	}
+	return &exporters.Output{Content: response.Content, Companions: response.Companions}, nil
+}
+
+// Generate sends the specifications to the plugin and returns the files to
+// write, keyed by paths relative to the project root
+func (t *RuleTarget) Generate(api *models.API, project *models.Project, projectMarkdown string) (map[string]string, error) {
+	apiPayload, err := toProtocol(api)
+	if err != nil {
+		return nil, err
+	}
+	projectPayload, err := toProtocol(project)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := call(t.Plugin.Path, Request{
+		Action:          "rules",
+		Target:          t.Capability.Name,
+		API:             apiPayload,
+		Project:         projectPayload,
+		ProjectMarkdown: projectMarkdown,
+	}, CallTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plugins may only write inside the project
+	for path := range response.Files {
+		clean := filepath.Clean(filepath.FromSlash(path))
+		if path == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("%s returned a path outside the project: %q", filepath.Base(t.Plugin.Path), path)
+		}
+	}
+	return response.Files, nil
+}
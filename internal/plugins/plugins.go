@@ -0,0 +1,431 @@
+// Package plugins discovers architect-<name> executables on PATH and talks
+// to them over a JSON-over-stdio protocol.
+//
+// Any architect-<name> executable can be run as "architect <name> [args]",
+// like git runs git-<name>. Executables that also speak the protocol can
+// extend Architect with importers, exporters and rule targets once they are
+// enabled in the plugins list of .architect/config.yaml; other executables
+// are never run on their own. For every call, the plugin is started with
+// ARCHITECT_PLUGIN_PROTOCOL=1 in its environment, reads one JSON request
+// from stdin and writes one JSON response to stdout:
+//
+//	{"protocol": 1, "action": "describe"}
+//	→ {"name": "soap", "description": "...",
+//	   "importers": [{"format": "wsdl", "extensions": [".wsdl"]}],
+//	   "exporters": [{"format": "wsdl", "description": "...", "extensions": [".wsdl"],
+//	                  "default_filename": "service.wsdl",
+//	                  "options": [{"name": "...", "description": "...", "default": "..."}]}],
+//	   "rule_targets": [{"name": "windsurf", "description": "..."}]}
+//
+//	{"protocol": 1, "action": "import", "format": "wsdl", "filename": "/abs/path", "content": "..."}
+//	→ {"api": {...}}
+//
+//	{"protocol": 1, "action": "export", "format": "wsdl", "api": {...}, "options": {...}}
+//	→ {"content": "...", "companions": {"file name": "content"}}
+//
+//	{"protocol": 1, "action": "rules", "target": "windsurf", "api": {...},
+//	 "project": {...}, "project_markdown": "..."}
+//	→ {"files": {"relative/path": "content"}}
+//
+// The api and project objects have the same fields as api.yaml and the
+// project model. A plugin reports failures with {"error": "message"} or a
+// non-zero exit status.
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"github.com/faisalahmedsifat/architect/internal/parser"
+	"gopkg.in/yaml.v3"
+)
+
+// Prefix is the prefix of plugin executable names
+const Prefix = "architect-"
+
+// ProtocolVersion is the version of the JSON protocol sent in every request
+const ProtocolVersion = 1
+
+// Plugin is an architect-<name> executable found on PATH
+type Plugin struct {
+	Name string
+	Path string
+
+	// Enabled reports whether the plugin is in the plugins list of
+	// .architect/config.yaml. Only enabled plugins are described.
+	Enabled bool
+
+	// Description and the capabilities are empty until the plugin is
+	// described, and when the executable does not speak the protocol; it can
+	// still be run as a command
+	Description string
+	Importers   []ImporterCapability
+	Exporters   []ExporterCapability
+	RuleTargets []RuleTargetCapability
+
+	// Err is the reason the plugin could not be described
+	Err error
+}
+
+// ImporterCapability is a format a plugin imports
+type ImporterCapability struct {
+	Format     string   `json:"format"`
+	Extensions []string `json:"extensions,omitempty"`
+}
+
+// ExporterCapability is a format a plugin exports
+type ExporterCapability struct {
+	Format          string         `json:"format"`
+	Description     string         `json:"description,omitempty"`
+	Extensions      []string       `json:"extensions,omitempty"`
+	DefaultFilename string         `json:"default_filename,omitempty"`
+	Options         []OptionSchema `json:"options,omitempty"`
+}
+
+// OptionSchema is an option of a plugin exporter
+type OptionSchema struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+}
+
+// RuleTargetCapability is an AI assistant a plugin writes rules for
+type RuleTargetCapability struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// Request is a protocol request sent to a plugin
+type Request struct {
+	Protocol        int               `json:"protocol"`
+	Action          string            `json:"action"`
+	Format          string            `json:"format,omitempty"`
+	Target          string            `json:"target,omitempty"`
+	Filename        string            `json:"filename,omitempty"`
+	Content         string            `json:"content,omitempty"`
+	API             interface{}       `json:"api,omitempty"`
+	Project         interface{}       `json:"project,omitempty"`
+	ProjectMarkdown string            `json:"project_markdown,omitempty"`
+	Options         map[string]string `json:"options,omitempty"`
+}
+
+// Response is a protocol response read from a plugin
+type Response struct {
+	Error string `json:"error,omitempty"`
+
+	// describe
+	Name        string                 `json:"name,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Importers   []ImporterCapability   `json:"importers,omitempty"`
+	Exporters   []ExporterCapability   `json:"exporters,omitempty"`
+	RuleTargets []RuleTargetCapability `json:"rule_targets,omitempty"`
+
+	// import
+	API json.RawMessage `json:"api,omitempty"`
+
+	// export
+	Content    string            `json:"content,omitempty"`
+	Companions map[string]string `json:"companions,omitempty"`
+
+	// rules
+	Files map[string]string `json:"files,omitempty"`
+}
+
+// ConfigFile holds the plugins list enabling plugins
+const ConfigFile = ".architect/config.yaml"
+
+// Timeouts of protocol calls. Describing must be quick since it happens
+// whenever an enabled plugin changed since it was last described.
+var (
+	DescribeTimeout = 5 * time.Second
+	CallTimeout     = 2 * time.Minute
+)
+
+// Discover returns the plugins on PATH without running them, marking those
+// enabled in .architect/config.yaml. When several directories hold a plugin
+// with the same name, the first one on PATH wins, like for any command.
+func Discover() ([]*Plugin, error) {
+	enabled, err := enabledNames()
+	if err != nil {
+		return nil, err
+	}
+
+	var found []*Plugin
+	for _, path := range executables() {
+		name := pluginName(filepath.Base(path))
+		found = append(found, &Plugin{Name: name, Path: path, Enabled: enabled[name]})
+	}
+	return found, nil
+}
+
+// Enabled returns the enabled plugins found on PATH, described
+func Enabled() ([]*Plugin, error) {
+	found, err := Discover()
+	if err != nil {
+		return nil, err
+	}
+
+	var enabled []*Plugin
+	for _, plugin := range found {
+		if plugin.Enabled {
+			Describe(plugin)
+			enabled = append(enabled, plugin)
+		}
+	}
+	return enabled, nil
+}
+
+// enabledNames reads the plugins list of .architect/config.yaml
+func enabledNames() (map[string]bool, error) {
+	config, err := parser.ParseConfigYAML(ConfigFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ConfigFile, err)
+	}
+	names := make(map[string]bool, len(config.Plugins))
+	for _, name := range config.Plugins {
+		names[strings.TrimPrefix(strings.TrimSpace(name), Prefix)] = true
+	}
+	return names, nil
+}
+
+// Lookup returns the path of the architect-<name> executable on PATH
+func Lookup(name string) (string, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+	path, err := exec.LookPath(Prefix + name)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// RunCommand runs a plugin as a command with the terminal attached and
+// returns its exit status
+func RunCommand(path string, args []string) int {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode()
+		}
+		fmt.Fprintf(os.Stderr, "Error: failed to run %s: %v\n", path, err)
+		return 1
+	}
+	return 0
+}
+
+// executables lists the architect-<name> executables on PATH, first
+// occurrence of each name only
+func executables() []string {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			dir = "."
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := pluginName(entry.Name())
+			if name == "" || seen[name] || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			paths = append(paths, path)
+		}
+	}
+	sort.Slice(paths, func(a, b int) bool {
+		return pluginName(filepath.Base(paths[a])) < pluginName(filepath.Base(paths[b]))
+	})
+	return paths
+}
+
+// pluginName returns the name of a plugin executable, or "" for other files
+func pluginName(file string) string {
+	if !strings.HasPrefix(file, Prefix) {
+		return ""
+	}
+	name := strings.TrimPrefix(file, Prefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(strings.TrimSuffix(name, ".exe"), ".EXE")
+	}
+	return name
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return info.Mode()&0111 != 0
+}
+
+// Describe asks a plugin for its capabilities. Descriptions are cached until
+// the executable changes, so a plugin is normally run once per version.
+func Describe(plugin *Plugin) {
+	response, err := describe(plugin.Path)
+	if err != nil {
+		plugin.Err = err
+		return
+	}
+	plugin.Description = response.Description
+	plugin.Importers = response.Importers
+	plugin.Exporters = response.Exporters
+	plugin.RuleTargets = response.RuleTargets
+}
+
+// cachedDescription is a describe response of an executable as it was when
+// described
+type cachedDescription struct {
+	Path     string    `json:"path"`
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"mod_time"`
+	Response Response  `json:"response"`
+}
+
+var (
+	descriptionsMu sync.Mutex
+	descriptions   = make(map[string]cachedDescription)
+)
+
+// describe returns the describe response of an executable, from the cache in
+// memory or in the user cache directory when the executable is unchanged
+func describe(path string) (*Response, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	fresh := func(cached cachedDescription) bool {
+		return cached.Path == path && cached.Size == info.Size() && cached.ModTime.Equal(info.ModTime())
+	}
+
+	descriptionsMu.Lock()
+	defer descriptionsMu.Unlock()
+
+	if cached, ok := descriptions[path]; ok && fresh(cached) {
+		return &cached.Response, nil
+	}
+	cacheFile := descriptionCacheFile(path)
+	if data, err := os.ReadFile(cacheFile); err == nil {
+		var cached cachedDescription
+		if json.Unmarshal(data, &cached) == nil && fresh(cached) {
+			descriptions[path] = cached
+			return &cached.Response, nil
+		}
+	}
+
+	response, err := call(path, Request{Action: "describe"}, DescribeTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	cached := cachedDescription{Path: path, Size: info.Size(), ModTime: info.ModTime(), Response: *response}
+	descriptions[path] = cached
+	// The cache only saves time, so failing to write it is not an error
+	if cacheFile != "" {
+		if data, err := json.Marshal(cached); err == nil && os.MkdirAll(filepath.Dir(cacheFile), 0755) == nil {
+			os.WriteFile(cacheFile, data, 0644)
+		}
+	}
+	return response, nil
+}
+
+// descriptionCacheFile is where the description of an executable is cached,
+// or "" without a user cache directory
+func descriptionCacheFile(path string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(dir, "architect", "plugins", hex.EncodeToString(sum[:8])+".json")
+}
+
+// call sends one request to a plugin and reads its response
+func call(path string, request Request, timeout time.Duration) (*Response, error) {
+	request.Protocol = ProtocolVersion
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Env = append(os.Environ(), "ARCHITECT_PLUGIN_PROTOCOL=1")
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%s %s timed out after %s", filepath.Base(path), request.Action, timeout)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%s %s failed: %s", filepath.Base(path), request.Action, message)
+		}
+		return nil, fmt.Errorf("%s %s failed: %w", filepath.Base(path), request.Action, err)
+	}
+
+	var response Response
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("%s does not speak the plugin protocol: invalid %s response", filepath.Base(path), request.Action)
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("%s: %s", filepath.Base(path), response.Error)
+	}
+	return &response, nil
+}
+
+// toProtocol converts a model to the JSON sent to plugins, with the field
+// names of its YAML files
+func toProtocol(value interface{}) (interface{}, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+// fromProtocol reads an API returned by a plugin. JSON is valid YAML, so the
+// YAML field names of the model apply.
+func fromProtocol(data []byte) (*models.API, error) {
+	var api models.API
+	if err := yaml.Unmarshal(data, &api); err != nil {
+		return nil, err
+	}
+	return &api, nil
+}
@@ -0,0 +1,153 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakePlugin writes an architect-<name> shell script answering describe and
+// counting its runs in a file next to it
+func fakePlugin(t *testing.T, dir, name string) (path, runs string) {
+	t.Helper()
+	path = filepath.Join(dir, Prefix+name)
+	runs = filepath.Join(dir, name+".runs")
+	script := `#!/bin/sh
+echo run >> "` + runs + `"
+read -r request || true
+echo '{"name": "` + name + `", "description": "Fake", "importers": [{"format": "wsdl", "extensions": [".wsdl"]}], "exporters": [{"format": "wsdl"}], "rule_targets": [{"name": "windsurf"}]}'
+`
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path, runs
+}
+
+func runCount(t *testing.T, runs string) int {
+	t.Helper()
+	data, err := os.ReadFile(runs)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Count(string(data), "run")
+}
+
+// inProject runs fn in a project whose config enables the given plugins, with
+// an empty PATH apart from bin and a private cache directory
+func inProject(t *testing.T, bin string, enabled []string, fn func()) {
+	t.Helper()
+	project := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(project, ".architect"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "plugins:\n"
+	for _, name := range enabled {
+		config += "  - " + name + "\n"
+	}
+	if err := os.WriteFile(filepath.Join(project, ConfigFile), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", bin)
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(project, "cache"))
+	t.Setenv("HOME", project)
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(project); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(previous)
+	fn()
+}
+
+func TestDisabledPluginsAreNeverRun(t *testing.T) {
+	bin := t.TempDir()
+	_, runs := fakePlugin(t, bin, "soap")
+
+	inProject(t, bin, nil, func() {
+		found, err := Discover()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(found) != 1 || found[0].Name != "soap" || found[0].Enabled {
+			t.Fatalf("Discover = %+v, want soap, not enabled", found)
+		}
+
+		if _, ok := FindImporter("wsdl"); ok {
+			t.Error("found an importer of a plugin that is not enabled")
+		}
+		if _, ok := DetectImporter("service.wsdl"); ok {
+			t.Error("detected an importer of a plugin that is not enabled")
+		}
+		if _, ok := FindExporter("wsdl"); ok {
+			t.Error("found an exporter of a plugin that is not enabled")
+		}
+		targets, err := RuleTargets()
+		if err != nil || len(targets) != 0 {
+			t.Errorf("RuleTargets = %v, %v; want none", targets, err)
+		}
+	})
+
+	if count := runCount(t, runs); count != 0 {
+		t.Errorf("plugin that is not enabled ran %d times", count)
+	}
+}
+
+func TestEnabledPluginsAreDescribedOnce(t *testing.T) {
+	bin := t.TempDir()
+	path, runs := fakePlugin(t, bin, "soap")
+	fakePlugin(t, bin, "other")
+
+	inProject(t, bin, []string{"soap"}, func() {
+		importer, ok := FindImporter("wsdl")
+		if !ok || importer.Plugin.Name != "soap" {
+			t.Fatalf("FindImporter = %+v, %v", importer, ok)
+		}
+		if _, ok := FindExporter("wsdl"); !ok {
+			t.Error("exporter of the enabled plugin not found")
+		}
+		targets, err := RuleTargets()
+		if err != nil || len(targets) != 1 || targets[0].Capability.Name != "windsurf" {
+			t.Errorf("RuleTargets = %v, %v", targets, err)
+		}
+		if count := runCount(t, runs); count != 1 {
+			t.Errorf("plugin described %d times, want once", count)
+		}
+
+		// The cache on disk survives the process
+		descriptionsMu.Lock()
+		delete(descriptions, path)
+		descriptionsMu.Unlock()
+		if _, ok := FindExporter("wsdl"); !ok {
+			t.Error("exporter not found from the cache")
+		}
+		if count := runCount(t, runs); count != 1 {
+			t.Errorf("plugin described %d times after a restart, want once", count)
+		}
+
+		// A changed executable is described again
+		script, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, append(script, []byte("# v2\n")...), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := FindImporter("wsdl"); !ok {
+			t.Error("importer not found after an update")
+		}
+		if count := runCount(t, runs); count != 2 {
+			t.Errorf("updated plugin described %d times in total, want twice", count)
+		}
+	})
+
+	if count := runCount(t, filepath.Join(bin, "other.runs")); count != 0 {
+		t.Errorf("plugin that is not enabled ran %d times", count)
+	}
+}
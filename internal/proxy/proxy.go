@@ -0,0 +1,168 @@
+// Package proxy forwards requests to an upstream service and records every
+// request/response pair, so that live traffic can be checked against
+// api.yaml or used to infer a specification.
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/conformance"
+)
+
+// ExchangeFunc is called with every request/response pair that was proxied
+type ExchangeFunc func(exchange conformance.Exchange)
+
+// ErrorFunc is called when a request could not be forwarded upstream
+type ErrorFunc func(method, path string, err error)
+
+type requestBodyKey struct{}
+
+// New creates a reverse proxy to upstream. observe is called after each
+// response has been received, before it is returned to the client. Event
+// streams and binary bodies such as file downloads are forwarded untouched
+// and recorded as empty; multipart forms are recorded up to 10 MiB.
+func New(upstream *url.URL, observe ExchangeFunc, onError ErrorFunc) http.Handler {
+	reverse := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			r.SetURL(upstream)
+			r.SetXForwarded()
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			exchange := conformance.Exchange{
+				Method:          resp.Request.Method,
+				Path:            inboundPath(upstream, resp.Request),
+				Query:           resp.Request.URL.Query(),
+				RequestHeaders:  resp.Request.Header,
+				Status:          resp.StatusCode,
+				ResponseHeaders: resp.Header,
+			}
+			if body, ok := resp.Request.Context().Value(requestBodyKey{}).([]byte); ok {
+				exchange.RequestBody = body
+			}
+
+			if buffered(resp.Header.Get("Content-Type")) {
+				raw, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					return err
+				}
+				resp.Body = io.NopCloser(bytes.NewReader(raw))
+				exchange.ResponseBody = decompress(raw, resp.Header.Get("Content-Encoding"))
+			}
+
+			observe(exchange)
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			onError(r.Method, inboundPath(upstream, r), err)
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r, err := recordRequestBody(r)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		reverse.ServeHTTP(w, r)
+	})
+}
+
+// maxFormBody is the largest multipart form recorded; larger uploads are
+// forwarded untouched and recorded as not seen
+const maxFormBody = 10 << 20
+
+// recordRequestBody reads the request body into the request context so that
+// it can be checked once the response arrives
+func recordRequestBody(r *http.Request) (*http.Request, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return r, nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	limit := int64(-1)
+	switch {
+	case isMultipartForm(contentType):
+		limit = maxFormBody
+	case !buffered(contentType):
+		return r, nil
+	}
+
+	reader := io.Reader(r.Body)
+	if limit >= 0 {
+		reader = io.LimitReader(r.Body, limit+1)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		r.Body.Close()
+		return r, err
+	}
+	if limit >= 0 && int64(len(body)) > limit {
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+		return r, nil
+	}
+
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return r.WithContext(context.WithValue(r.Context(), requestBodyKey{}, body)), nil
+}
+
+func isMultipartForm(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	return strings.EqualFold(strings.TrimSpace(mediaType), "multipart/form-data")
+}
+
+// inboundPath returns the path requested by the client, without the path
+// prefix of the upstream URL added when forwarding
+func inboundPath(upstream *url.URL, r *http.Request) string {
+	path := strings.TrimPrefix(r.URL.Path, strings.TrimRight(upstream.Path, "/"))
+	if path == "" {
+		path = "/"
+	}
+	return path
+}
+
+// buffered reports whether a body with the given content type is read into
+// memory for checking. Content types are not trusted to identify JSON, since
+// many servers and clients send JSON as text/plain or form data.
+func buffered(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	switch {
+	case mediaType == "text/event-stream", mediaType == "application/octet-stream":
+		return false
+	case strings.HasPrefix(mediaType, "multipart/"), strings.HasPrefix(mediaType, "image/"),
+		strings.HasPrefix(mediaType, "audio/"), strings.HasPrefix(mediaType, "video/"):
+		return false
+	}
+	return true
+}
+
+// decompress returns the decoded body for checking; the client still receives
+// the original bytes
+func decompress(raw []byte, encoding string) []byte {
+	if !strings.EqualFold(encoding, "gzip") {
+		return raw
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return raw
+	}
+	defer reader.Close()
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return raw
+	}
+	return decoded
+}
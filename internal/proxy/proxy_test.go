@@ -0,0 +1,165 @@
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/conformance"
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// proxied starts an upstream and a proxy in front of it mounted at /v1, and
+// returns the proxy URL and the recorded exchanges
+func proxied(t *testing.T, upstream http.HandlerFunc) (string, *[]conformance.Exchange) {
+	t.Helper()
+	backend := httptest.NewServer(upstream)
+	t.Cleanup(backend.Close)
+
+	target, err := url.Parse(backend.URL + "/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exchanges []conformance.Exchange
+	front := httptest.NewServer(New(target, func(exchange conformance.Exchange) {
+		exchanges = append(exchanges, exchange)
+	}, func(method, path string, err error) {
+		t.Errorf("%s %s was not forwarded: %v", method, path, err)
+	}))
+	t.Cleanup(front.Close)
+	return front.URL, &exchanges
+}
+
+// echo drains the request and answers with the path the upstream received
+func echo(w http.ResponseWriter, r *http.Request) {
+	io.Copy(io.Discard, r.Body)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte(`{"path": "` + r.URL.Path + `"}`))
+}
+
+func TestProxyRecordsJSONExchange(t *testing.T) {
+	base, exchanges := proxied(t, echo)
+
+	resp, err := http.Post(base+"/users?invite=true", "application/json", strings.NewReader(`{"email": "a@b.co"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	received, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if len(*exchanges) != 1 {
+		t.Fatalf("recorded %d exchanges, want 1", len(*exchanges))
+	}
+	exchange := (*exchanges)[0]
+	if exchange.Method != "POST" || exchange.Path != "/users" || exchange.Query.Get("invite") != "true" {
+		t.Errorf("request = %s %s %v", exchange.Method, exchange.Path, exchange.Query)
+	}
+	if string(exchange.RequestBody) != `{"email": "a@b.co"}` {
+		t.Errorf("request body = %q", exchange.RequestBody)
+	}
+	if exchange.Status != http.StatusCreated || string(exchange.ResponseBody) != string(received) {
+		t.Errorf("response = %d %q, client received %q", exchange.Status, exchange.ResponseBody, received)
+	}
+	if !strings.Contains(string(received), `"path": "/v1/users"`) {
+		t.Errorf("upstream did not receive the request under its path: %s", received)
+	}
+}
+
+func TestProxyRecordsFormExchanges(t *testing.T) {
+	api := &models.API{Endpoints: []models.Endpoint{{
+		Method:   "POST",
+		Path:     "/users",
+		Request:  &models.EndpointRequest{Body: map[string]string{"email": "string, required", "age": "integer, optional"}},
+		Response: &models.EndpointResponse{Status: 201},
+	}}}
+
+	var multipartBody bytes.Buffer
+	writer := multipart.NewWriter(&multipartBody)
+	writer.WriteField("email", "a@b.co")
+	writer.WriteField("age", "42")
+	file, _ := writer.CreateFormFile("avatar", "me.png")
+	file.Write([]byte{0x89, 'P', 'N', 'G'})
+	writer.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"url-encoded form", "application/x-www-form-urlencoded", "email=a%40b.co&age=42"},
+		{"multipart form", writer.FormDataContentType(), multipartBody.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, exchanges := proxied(t, echo)
+			resp, err := http.Post(base+"/users", tt.contentType, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+
+			if len(*exchanges) != 1 {
+				t.Fatalf("recorded %d exchanges, want 1", len(*exchanges))
+			}
+			exchange := (*exchanges)[0]
+			if string(exchange.RequestBody) != tt.body {
+				t.Errorf("request body = %q, want %q", exchange.RequestBody, tt.body)
+			}
+			if _, _, findings := conformance.CheckExchange(api, exchange); len(findings) != 0 {
+				t.Errorf("findings = %+v, want none", findings)
+			}
+		})
+	}
+}
+
+func TestProxyDecompressesRecordedResponse(t *testing.T) {
+	base, exchanges := proxied(t, func(w http.ResponseWriter, r *http.Request) {
+		var compressed bytes.Buffer
+		gz := gzip.NewWriter(&compressed)
+		gz.Write([]byte(`{"ok": true}`))
+		gz.Close()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compressed.Bytes())
+	})
+
+	request, _ := http.NewRequest("GET", base+"/health", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if len(*exchanges) != 1 || string((*exchanges)[0].ResponseBody) != `{"ok": true}` {
+		t.Errorf("exchanges = %+v", *exchanges)
+	}
+}
+
+func TestProxySkipsStreams(t *testing.T) {
+	base, exchanges := proxied(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: hello\n\n"))
+	})
+
+	resp, err := http.Get(base + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	received, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(received) != "data: hello\n\n" {
+		t.Errorf("client received %q", received)
+	}
+	if len(*exchanges) != 1 || (*exchanges)[0].ResponseBody != nil {
+		t.Errorf("event stream was recorded: %+v", *exchanges)
+	}
+}
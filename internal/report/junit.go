@@ -0,0 +1,102 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JUnit XML structures understood by common CI dashboards
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func (r *Report) writeJUnit(w io.Writer) error {
+	suites := junitTestSuites{Name: r.Name}
+	suiteIndex := make(map[string]int)
+
+	for _, c := range r.Cases {
+		idx, ok := suiteIndex[c.Classname]
+		if !ok {
+			idx = len(suites.Suites)
+			suiteIndex[c.Classname] = idx
+			suites.Suites = append(suites.Suites, junitTestSuite{Name: c.Classname})
+		}
+		suite := &suites.Suites[idx]
+
+		testCase := junitTestCase{Name: c.Name, Classname: c.Classname}
+		var failures, output []string
+		for _, finding := range c.Findings {
+			line := fmt.Sprintf("[%s] %s", finding.RuleID, finding.Message)
+			if finding.File != "" {
+				line += fmt.Sprintf(" (%s:%d)", finding.File, finding.Line)
+			}
+			if finding.Severity == SeverityError {
+				failures = append(failures, line)
+			} else {
+				output = append(output, finding.Severity+": "+line)
+			}
+		}
+
+		if len(failures) > 0 {
+			first := firstError(c.Findings)
+			testCase.Failure = &junitFailure{
+				Message: first.Message,
+				Type:    first.RuleID,
+				Text:    strings.Join(failures, "\n"),
+			}
+			suite.Failures++
+			suites.Failures++
+		}
+		testCase.SystemOut = strings.Join(output, "\n")
+
+		suite.Cases = append(suite.Cases, testCase)
+		suite.Tests++
+		suites.Tests++
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func firstError(findings []Finding) Finding {
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			return finding
+		}
+	}
+	return Finding{}
+}
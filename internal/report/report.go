@@ -0,0 +1,172 @@
+// Package report renders validation and contract test results in
+// machine-readable formats (JSON, SARIF and JUnit XML) for CI systems.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Supported output formats
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+	FormatJUnit = "junit"
+)
+
+// Severity levels used by findings
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityNote    = "note"
+)
+
+// Finding is a single problem reported against a file location
+type Finding struct {
+	RuleID   string `json:"rule_id"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+
+	// Baselined findings are known and accepted; they are reported as notes
+	Baselined bool `json:"baselined,omitempty"`
+}
+
+// Case groups the findings for one checked item, such as an endpoint. A case
+// fails when it has at least one error finding.
+type Case struct {
+	Name      string    `json:"name"`
+	Classname string    `json:"classname"`
+	Findings  []Finding `json:"findings"`
+}
+
+// Failed reports whether the case has any error findings
+func (c Case) Failed() bool {
+	for _, finding := range c.Findings {
+		if finding.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Report is the complete result of a run
+type Report struct {
+	Name  string
+	Cases []Case
+
+	// Rules describes every rule ID that may appear in findings
+	Rules map[string]string
+}
+
+// Summary counts cases and findings in a report
+type Summary struct {
+	Cases    int `json:"cases"`
+	Passed   int `json:"passed"`
+	Failed   int `json:"failed"`
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+}
+
+// Summary computes the report summary
+func (r *Report) Summary() Summary {
+	summary := Summary{Cases: len(r.Cases)}
+	for _, c := range r.Cases {
+		if c.Failed() {
+			summary.Failed++
+		} else {
+			summary.Passed++
+		}
+		for _, finding := range c.Findings {
+			switch finding.Severity {
+			case SeverityError:
+				summary.Errors++
+			case SeverityWarning:
+				summary.Warnings++
+			}
+		}
+	}
+	return summary
+}
+
+// IsSupportedFormat reports whether the format can be written by Write
+func IsSupportedFormat(format string) bool {
+	switch format {
+	case FormatText, FormatJSON, FormatSARIF, FormatJUnit:
+		return true
+	}
+	return false
+}
+
+// Write renders the report in the given machine-readable format
+func (r *Report) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		return r.writeJSON(w)
+	case FormatSARIF:
+		return r.writeSARIF(w)
+	case FormatJUnit:
+		return r.writeJUnit(w)
+	default:
+		return fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+// WriteFile renders the report to the given file
+func (r *Report) WriteFile(filename, format string) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer file.Close()
+
+	if err := r.Write(file, format); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func (r *Report) writeJSON(w io.Writer) error {
+	cases := r.Cases
+	if cases == nil {
+		cases = []Case{}
+	}
+	for i := range cases {
+		if cases[i].Findings == nil {
+			cases[i].Findings = []Finding{}
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"name":    r.Name,
+		"summary": r.Summary(),
+		"cases":   cases,
+	})
+}
+
+// ruleIDs returns all rule IDs used by the report, sorted
+func (r *Report) ruleIDs() []string {
+	seen := make(map[string]bool)
+	for id := range r.Rules {
+		seen[id] = true
+	}
+	for _, c := range r.Cases {
+		for _, finding := range c.Findings {
+			seen[finding.RuleID] = true
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
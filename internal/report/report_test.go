@@ -0,0 +1,153 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func sampleReport() *Report {
+	return &Report{
+		Name: "validate",
+		Cases: []Case{
+			{
+				Name:      "GET /orders",
+				Classname: "endpoints",
+				Findings: []Finding{
+					{RuleID: "auth-missing", Severity: SeverityError, Message: "no auth", File: "routes.go", Line: 3},
+					{RuleID: "schema-undeclared-field", Severity: SeverityWarning, Message: "extra field"},
+				},
+			},
+			{Name: "POST /orders", Classname: "endpoints"},
+			{
+				Name:      "GET /health",
+				Classname: "routes",
+				Findings:  []Finding{{RuleID: "route-undocumented", Severity: SeverityWarning, Message: "undocumented"}},
+			},
+		},
+		Rules: map[string]string{"endpoint-not-implemented": "not implemented"},
+	}
+}
+
+func TestSummary(t *testing.T) {
+	want := Summary{Cases: 3, Passed: 2, Failed: 1, Errors: 1, Warnings: 2}
+	if got := sampleReport().Summary(); got != want {
+		t.Errorf("summary = %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport().Write(&buf, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Name    string  `json:"name"`
+		Summary Summary `json:"summary"`
+		Cases   []struct {
+			Name     string    `json:"name"`
+			Findings []Finding `json:"findings"`
+		} `json:"cases"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if decoded.Name != "validate" || decoded.Summary.Failed != 1 || len(decoded.Cases) != 3 {
+		t.Errorf("report = %+v", decoded)
+	}
+	// Cases without findings have an empty list rather than null
+	if !strings.Contains(buf.String(), `"findings": []`) {
+		t.Errorf("case without findings is not an empty list:\n%s", buf.String())
+	}
+	if finding := decoded.Cases[0].Findings[0]; finding.File != "routes.go" || finding.Line != 3 {
+		t.Errorf("finding location = %s:%d", finding.File, finding.Line)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport().Write(&buf, FormatJUnit); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JUnit XML: %v", err)
+	}
+	if decoded.Tests != 3 || decoded.Failures != 1 || len(decoded.Suites) != 2 {
+		t.Fatalf("suites = %+v", decoded)
+	}
+
+	endpoints := decoded.Suites[0]
+	if endpoints.Name != "endpoints" || endpoints.Tests != 2 || endpoints.Failures != 1 {
+		t.Errorf("endpoints suite = %+v", endpoints)
+	}
+	failed := endpoints.Cases[0]
+	if failed.Failure == nil || failed.Failure.Type != "auth-missing" || !strings.Contains(failed.Failure.Text, "(routes.go:3)") {
+		t.Errorf("failure = %+v", failed.Failure)
+	}
+	if failed.SystemOut != "warning: [schema-undeclared-field] extra field" {
+		t.Errorf("system-out = %q", failed.SystemOut)
+	}
+	if endpoints.Cases[1].Failure != nil {
+		t.Errorf("passing case has a failure: %+v", endpoints.Cases[1].Failure)
+	}
+}
+
+func TestWriteSARIFRules(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport().Write(&buf, FormatSARIF); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID string `json:"ruleId"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid SARIF: %v", err)
+	}
+	if decoded.Version != "2.1.0" {
+		t.Errorf("version = %q", decoded.Version)
+	}
+
+	var rules []string
+	for _, rule := range decoded.Runs[0].Tool.Driver.Rules {
+		rules = append(rules, rule.ID)
+	}
+	want := "auth-missing endpoint-not-implemented route-undocumented schema-undeclared-field"
+	if strings.Join(rules, " ") != want {
+		t.Errorf("rules = %v, want %s", rules, want)
+	}
+	if len(decoded.Runs[0].Results) != 3 {
+		t.Errorf("results = %+v", decoded.Runs[0].Results)
+	}
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	if err := sampleReport().Write(&bytes.Buffer{}, FormatText); err == nil {
+		t.Error("expected an error for the text format")
+	}
+	for _, format := range []string{FormatText, FormatJSON, FormatSARIF, FormatJUnit} {
+		if !IsSupportedFormat(format) {
+			t.Errorf("%s is not supported", format)
+		}
+	}
+	if IsSupportedFormat("xml") {
+		t.Error("xml is supported")
+	}
+}
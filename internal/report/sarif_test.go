@@ -0,0 +1,46 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestWriteSARIFBaselineState(t *testing.T) {
+	r := &Report{Name: "validate", Cases: []Case{{
+		Name:      "GET /orders",
+		Classname: "endpoints",
+		Findings: []Finding{
+			{RuleID: "endpoint-not-implemented", Severity: SeverityNote, Message: "not implemented", Baselined: true},
+			{RuleID: "auth-missing", Severity: SeverityError, Message: "no auth", File: "routes.go", Line: 3},
+		},
+	}}}
+
+	var buf bytes.Buffer
+	if err := r.Write(&buf, FormatSARIF); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded struct {
+		Runs []struct {
+			Results []struct {
+				RuleID        string `json:"ruleId"`
+				Level         string `json:"level"`
+				BaselineState string `json:"baselineState"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid SARIF: %v", err)
+	}
+	results := decoded.Runs[0].Results
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	if results[0].BaselineState != "unchanged" || results[0].Level != SeverityNote {
+		t.Errorf("baselined result = %+v", results[0])
+	}
+	if results[1].BaselineState != "" {
+		t.Errorf("new finding has baselineState %q", results[1].BaselineState)
+	}
+}
@@ -0,0 +1,515 @@
+package specdiff
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// Conflict resolutions
+const (
+	Ours   = "ours"
+	Theirs = "theirs"
+)
+
+// Conflict is a field changed on both sides of a merge in different ways
+type Conflict struct {
+	// Endpoint is the method and path of the endpoint, "webhook <name>" for
+	// webhooks, or empty for the top-level settings
+	Endpoint string
+	// Field is the changed field, or empty when the endpoint or webhook
+	// itself was removed on one side and kept or changed on the other
+	Field string
+
+	Base   string
+	Ours   string
+	Theirs string
+
+	// Resolution is Ours or Theirs once resolved
+	Resolution string
+}
+
+func (c Conflict) String() string {
+	location := c.Field
+	switch {
+	case c.Endpoint == "":
+	case c.Field == "":
+		location = c.Endpoint
+	default:
+		location = c.Endpoint + " " + c.Field
+	}
+	return fmt.Sprintf("%s: ours %s, theirs %s", location, describeValue(c.Ours), describeValue(c.Theirs))
+}
+
+func describeValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return strconv.Quote(value)
+}
+
+// Resolver decides a conflict by setting its Resolution
+type Resolver func(conflict *Conflict) error
+
+// Strategy returns a resolver always picking one side
+func Strategy(resolution string) Resolver {
+	return func(conflict *Conflict) error {
+		conflict.Resolution = resolution
+		return nil
+	}
+}
+
+// Merge merges the endpoints and settings of an incoming specification
+// (theirs) into the local one (ours), field by field. Base is the incoming
+// specification as it was last merged, or nil when there is none.
+//
+// A field changed on one side only since base keeps that change, so local
+// descriptions, errors, field rules and endpoints survive re-imports. A field
+// changed on both sides is a conflict decided by resolve. Endpoints missing
+// from theirs are kept unless base shows they were removed upstream, which is
+// a conflict too, so that no endpoint disappears unreported. Webhooks merge
+// like endpoints, by name. The servers, GraphQL schema and channels merge as
+// a whole and are kept when missing from theirs.
+//
+// Without base, every value set on both sides and differing is a conflict,
+// so that nothing local is replaced unreported.
+func Merge(base, ours, theirs *models.API, resolve Resolver) (*models.API, []Conflict, error) {
+	if base == nil {
+		base = &models.API{}
+	}
+	m := &merger{resolve: resolve}
+
+	merged := &models.API{
+		Servers:  ours.Servers,
+		GraphQL:  ours.GraphQL,
+		Channels: ours.Channels,
+	}
+	takeTheirs, err := m.section("servers", base.Servers, ours.Servers, theirs.Servers)
+	if err != nil {
+		return nil, nil, err
+	}
+	if takeTheirs {
+		merged.Servers = theirs.Servers
+	}
+	if takeTheirs, err = m.section("graphql", base.GraphQL, ours.GraphQL, theirs.GraphQL); err != nil {
+		return nil, nil, err
+	}
+	if takeTheirs {
+		merged.GraphQL = theirs.GraphQL
+	}
+	if takeTheirs, err = m.section("channels", base.Channels, ours.Channels, theirs.Channels); err != nil {
+		return nil, nil, err
+	}
+	if takeTheirs {
+		merged.Channels = theirs.Channels
+	}
+
+	if merged.BaseURL, err = m.scalar("", "base_url", base.BaseURL, ours.BaseURL, theirs.BaseURL); err != nil {
+		return nil, nil, err
+	}
+	if merged.AuthType, err = m.scalar("", "auth_type", base.AuthType, ours.AuthType, theirs.AuthType); err != nil {
+		return nil, nil, err
+	}
+
+	baseEndpoints := endpointsByKey(base.Endpoints)
+	theirEndpoints := endpointsByKey(theirs.Endpoints)
+
+	// Local endpoints keep their order, new ones follow in incoming order
+	seen := make(map[string]bool)
+	for _, local := range ours.Endpoints {
+		key := EndpointKey(local)
+		seen[key] = true
+		baseEndpoint, inBase := baseEndpoints[key]
+		incoming, inTheirs := theirEndpoints[key]
+
+		switch {
+		case inTheirs:
+			var baseFields map[string]string
+			if inBase {
+				baseFields = EndpointFields(baseEndpoint)
+			}
+			endpoint, err := m.endpoint(key, local, incoming, baseFields)
+			if err != nil {
+				return nil, nil, err
+			}
+			merged.Endpoints = append(merged.Endpoints, endpoint)
+
+		case inBase:
+			// Removed upstream: theirs drops it, ours keeps it
+			ourSide := "kept"
+			if changes := compareFields(EndpointFields(baseEndpoint), EndpointFields(local)); len(changes) > 0 {
+				ourSide = "changed"
+			}
+			keep, err := m.endpointConflict(key, ourSide, "removed", Ours)
+			if err != nil {
+				return nil, nil, err
+			}
+			if keep {
+				merged.Endpoints = append(merged.Endpoints, local)
+			}
+
+		default:
+			merged.Endpoints = append(merged.Endpoints, local)
+		}
+	}
+
+	for _, incoming := range theirs.Endpoints {
+		key := EndpointKey(incoming)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		baseEndpoint, inBase := baseEndpoints[key]
+		if !inBase {
+			merged.Endpoints = append(merged.Endpoints, incoming)
+			continue
+		}
+
+		// Removed locally: keep it removed unless it changed upstream
+		if changes := compareFields(EndpointFields(baseEndpoint), EndpointFields(incoming)); len(changes) == 0 {
+			continue
+		}
+		restore, err := m.endpointConflict(key, "removed", "changed", Theirs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if restore {
+			merged.Endpoints = append(merged.Endpoints, incoming)
+		}
+	}
+
+	if merged.Webhooks, err = m.webhooks(base.Webhooks, ours.Webhooks, theirs.Webhooks); err != nil {
+		return nil, nil, err
+	}
+
+	return merged, m.conflicts, nil
+}
+
+type merger struct {
+	resolve   Resolver
+	conflicts []Conflict
+}
+
+// scalar merges a single value three ways
+func (m *merger) scalar(endpoint, field, base, ours, theirs string) (string, error) {
+	switch {
+	case ours == theirs:
+		return ours, nil
+	case ours == base:
+		return theirs, nil
+	case theirs == base:
+		return ours, nil
+	}
+
+	conflict := Conflict{Endpoint: endpoint, Field: field, Base: base, Ours: ours, Theirs: theirs}
+	if err := m.resolve(&conflict); err != nil {
+		return "", err
+	}
+	m.conflicts = append(m.conflicts, conflict)
+	if conflict.Resolution == Theirs {
+		return theirs, nil
+	}
+	return ours, nil
+}
+
+// section merges a top-level section, such as the servers, as a whole and
+// reports whether theirs wins. A section missing from theirs is kept.
+func (m *merger) section(field string, base, ours, theirs interface{}) (bool, error) {
+	baseFields, ourFields, theirFields := Flatten(base), Flatten(ours), Flatten(theirs)
+	switch {
+	case len(theirFields) == 0, len(compareFields(ourFields, theirFields)) == 0:
+		return false, nil
+	case len(compareFields(baseFields, ourFields)) == 0:
+		return true, nil
+	case len(compareFields(baseFields, theirFields)) == 0:
+		return false, nil
+	}
+
+	conflict := Conflict{Field: field, Ours: "changed", Theirs: "changed"}
+	if err := m.resolve(&conflict); err != nil {
+		return false, err
+	}
+	m.conflicts = append(m.conflicts, conflict)
+	return conflict.Resolution == Theirs, nil
+}
+
+// endpointConflict resolves an endpoint or webhook removed or changed on
+// both sides, and reports whether the given side won
+func (m *merger) endpointConflict(endpoint, ours, theirs, side string) (bool, error) {
+	conflict := Conflict{Endpoint: endpoint, Ours: ours, Theirs: theirs}
+	if err := m.resolve(&conflict); err != nil {
+		return false, err
+	}
+	m.conflicts = append(m.conflicts, conflict)
+	return conflict.Resolution == side, nil
+}
+
+// endpoint merges an endpoint present on both sides field by field
+func (m *merger) endpoint(key string, ours, theirs models.Endpoint, base map[string]string) (models.Endpoint, error) {
+	merged := models.Endpoint{Path: ours.Path, Method: ours.Method}
+
+	var err error
+	if merged.Description, err = m.scalar(key, "description", base["description"], ours.Description, theirs.Description); err != nil {
+		return merged, err
+	}
+	auth, err := m.scalar(key, "auth", base["auth"], strconv.FormatBool(ours.Auth), strconv.FormatBool(theirs.Auth))
+	if err != nil {
+		return merged, err
+	}
+	merged.Auth = auth == "true"
+	if merged.RPC, err = m.scalar(key, "rpc", base["rpc"], ours.RPC, theirs.RPC); err != nil {
+		return merged, err
+	}
+
+	// Request
+	ourRequest, theirRequest := ours.Request, theirs.Request
+	if ourRequest == nil {
+		ourRequest = &models.EndpointRequest{}
+	}
+	if theirRequest == nil {
+		theirRequest = &models.EndpointRequest{}
+	}
+	request := &models.EndpointRequest{}
+	if request.Params, err = m.fields(key, "request.params", base, ourRequest.Params, theirRequest.Params); err != nil {
+		return merged, err
+	}
+	if request.Query, err = m.fields(key, "request.query", base, ourRequest.Query, theirRequest.Query); err != nil {
+		return merged, err
+	}
+	if request.Body, err = m.fields(key, "request.body", base, ourRequest.Body, theirRequest.Body); err != nil {
+		return merged, err
+	}
+	if request.Params != nil || request.Query != nil || request.Body != nil {
+		merged.Request = request
+	}
+
+	// Response
+	ourResponse, theirResponse := ours.Response, theirs.Response
+	if ourResponse == nil {
+		ourResponse = &models.EndpointResponse{}
+	}
+	if theirResponse == nil {
+		theirResponse = &models.EndpointResponse{}
+	}
+	response := &models.EndpointResponse{}
+	status, err := m.scalar(key, "response.status", base["response.status"], statusText(ourResponse.Status), statusText(theirResponse.Status))
+	if err != nil {
+		return merged, err
+	}
+	response.Status, _ = strconv.Atoi(status)
+	if response.Headers, err = m.fields(key, "response.headers", base, ourResponse.Headers, theirResponse.Headers); err != nil {
+		return merged, err
+	}
+	if response.Body, err = m.fields(key, "response.body", base, ourResponse.Body, theirResponse.Body); err != nil {
+		return merged, err
+	}
+	if ours.Response != nil || theirs.Response != nil {
+		merged.Response = response
+	}
+
+	merged.Errors, err = m.errors(key, base, ours.Errors, theirs.Errors)
+	return merged, err
+}
+
+// fields merges a map of field definitions, such as a request body, field
+// by field
+func (m *merger) fields(key, prefix string, base, ours, theirs map[string]string) (map[string]string, error) {
+	var merged map[string]string
+	for _, name := range unionKeys(ours, theirs) {
+		value, err := m.scalar(key, prefix+"."+name, base[prefix+"."+name], ours[name], theirs[name])
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		if merged == nil {
+			merged = make(map[string]string)
+		}
+		merged[name] = value
+	}
+	return merged, nil
+}
+
+// errors merges error responses by status and code, keeping the local order
+// and appending new incoming errors
+func (m *merger) errors(key string, base map[string]string, ours, theirs []models.ErrorResponse) ([]models.ErrorResponse, error) {
+	theirMessages := make(map[string]string)
+	for _, errorResponse := range theirs {
+		theirMessages[ErrorField(errorResponse)] = errorResponse.Message
+	}
+
+	var merged []models.ErrorResponse
+	seen := make(map[string]bool)
+	for _, side := range [][]models.ErrorResponse{ours, theirs} {
+		for _, errorResponse := range side {
+			field := ErrorField(errorResponse)
+			if seen[field] {
+				continue
+			}
+			seen[field] = true
+
+			ourMessage, inOurs := "", false
+			for _, local := range ours {
+				if ErrorField(local) == field {
+					ourMessage, inOurs = local.Message, true
+				}
+			}
+			theirMessage, inTheirs := theirMessages[field]
+			baseMessage, inBase := base[field]
+
+			// Presence merges like a value: an error added or removed on one
+			// side only keeps that change, unless the other side reworded it
+			present, err := m.scalar(key, field, presence(inBase), presence(inOurs), presence(inTheirs))
+			if err != nil {
+				return nil, err
+			}
+			kept := ourMessage
+			if !inOurs {
+				kept = theirMessage
+			}
+			if inBase && inOurs != inTheirs && kept != baseMessage {
+				present, err = m.removal(key, field, baseMessage, ourMessage, theirMessage, inOurs)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if present == "" {
+				continue
+			}
+
+			message := ourMessage
+			if inOurs && inTheirs {
+				if message, err = m.scalar(key, field, baseMessage, ourMessage, theirMessage); err != nil {
+					return nil, err
+				}
+			} else if !inOurs {
+				message = theirMessage
+			}
+			merged = append(merged, models.ErrorResponse{Status: errorResponse.Status, Code: errorResponse.Code, Message: message})
+		}
+	}
+
+	return merged, nil
+}
+
+// removal resolves a field removed on one side and changed on the other,
+// returning presence of the field after resolution
+func (m *merger) removal(key, field, base, ours, theirs string, inOurs bool) (string, error) {
+	conflict := Conflict{Endpoint: key, Field: field, Base: base, Ours: ours, Theirs: theirs}
+	if inOurs {
+		conflict.Theirs = "removed"
+	} else {
+		conflict.Ours = "removed"
+	}
+	if err := m.resolve(&conflict); err != nil {
+		return "", err
+	}
+	m.conflicts = append(m.conflicts, conflict)
+	return presence((conflict.Resolution == Ours) == inOurs), nil
+}
+
+// webhooks merges webhooks by name as a whole: a webhook changed on one side
+// only takes that change, one changed on both sides or removed on one side
+// is a conflict unless the other side left it unchanged since base
+func (m *merger) webhooks(base, ours, theirs []models.Webhook) ([]models.Webhook, error) {
+	byName := func(webhooks []models.Webhook) map[string]models.Webhook {
+		named := make(map[string]models.Webhook, len(webhooks))
+		for _, webhook := range webhooks {
+			named[webhook.Name] = webhook
+		}
+		return named
+	}
+	baseWebhooks, ourWebhooks, theirWebhooks := byName(base), byName(ours), byName(theirs)
+	changed := func(before, after models.Webhook) bool {
+		return len(compareFields(Flatten(before), Flatten(after))) > 0
+	}
+
+	var merged []models.Webhook
+	for _, local := range ours {
+		key := "webhook " + local.Name
+		baseWebhook, inBase := baseWebhooks[local.Name]
+		incoming, inTheirs := theirWebhooks[local.Name]
+
+		switch {
+		case inTheirs:
+			oursChanged := !inBase || changed(baseWebhook, local)
+			theirsChanged := !inBase || changed(baseWebhook, incoming)
+			if !changed(local, incoming) || !theirsChanged {
+				merged = append(merged, local)
+				continue
+			}
+			if !oursChanged {
+				merged = append(merged, incoming)
+				continue
+			}
+			takeTheirs, err := m.endpointConflict(key, "changed", "changed", Theirs)
+			if err != nil {
+				return nil, err
+			}
+			if takeTheirs {
+				merged = append(merged, incoming)
+			} else {
+				merged = append(merged, local)
+			}
+
+		case inBase:
+			// Removed upstream: theirs drops it, ours keeps it
+			ourSide := "kept"
+			if changed(baseWebhook, local) {
+				ourSide = "changed"
+			}
+			keep, err := m.endpointConflict(key, ourSide, "removed", Ours)
+			if err != nil {
+				return nil, err
+			}
+			if keep {
+				merged = append(merged, local)
+			}
+
+		default:
+			merged = append(merged, local)
+		}
+	}
+
+	for _, incoming := range theirs {
+		if _, inOurs := ourWebhooks[incoming.Name]; inOurs {
+			continue
+		}
+		baseWebhook, inBase := baseWebhooks[incoming.Name]
+		if !inBase {
+			merged = append(merged, incoming)
+			continue
+		}
+
+		// Removed locally: keep it removed unless it changed upstream
+		if !changed(baseWebhook, incoming) {
+			continue
+		}
+		restore, err := m.endpointConflict("webhook "+incoming.Name, "removed", "changed", Theirs)
+		if err != nil {
+			return nil, err
+		}
+		if restore {
+			merged = append(merged, incoming)
+		}
+	}
+
+	return merged, nil
+}
+
+func presence(present bool) string {
+	if present {
+		return "present"
+	}
+	return ""
+}
+
+func statusText(status int) string {
+	if status == 0 {
+		return ""
+	}
+	return strconv.Itoa(status)
+}
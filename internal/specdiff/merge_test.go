@@ -0,0 +1,485 @@
+package specdiff
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// mergedField reads and writes one merged field of a specification; an
+// empty value removes the field
+type mergedField struct {
+	name string
+	get  func(api *models.API) string
+	set  func(api *models.API, value string)
+}
+
+func endpointField(name string, get func(e *models.Endpoint) string, set func(e *models.Endpoint, value string)) mergedField {
+	return mergedField{
+		name: name,
+		get: func(api *models.API) string {
+			if len(api.Endpoints) == 0 {
+				return "(no endpoint)"
+			}
+			return get(&api.Endpoints[0])
+		},
+		set: func(api *models.API, value string) { set(&api.Endpoints[0], value) },
+	}
+}
+
+func request(e *models.Endpoint) *models.EndpointRequest {
+	if e.Request == nil {
+		e.Request = &models.EndpointRequest{}
+	}
+	return e.Request
+}
+
+func response(e *models.Endpoint) *models.EndpointResponse {
+	if e.Response == nil {
+		e.Response = &models.EndpointResponse{}
+	}
+	return e.Response
+}
+
+// setEntry sets or, for an empty value, removes a map entry
+func setEntry(fields *map[string]string, name, value string) {
+	if value == "" {
+		delete(*fields, name)
+		return
+	}
+	if *fields == nil {
+		*fields = make(map[string]string)
+	}
+	(*fields)[name] = value
+}
+
+var mergedFields = []mergedField{
+	{
+		name: "base_url",
+		get:  func(api *models.API) string { return api.BaseURL },
+		set:  func(api *models.API, value string) { api.BaseURL = value },
+	},
+	{
+		name: "auth_type",
+		get:  func(api *models.API) string { return api.AuthType },
+		set:  func(api *models.API, value string) { api.AuthType = value },
+	},
+	endpointField("description",
+		func(e *models.Endpoint) string { return e.Description },
+		func(e *models.Endpoint, value string) { e.Description = value }),
+	endpointField("rpc",
+		func(e *models.Endpoint) string { return e.RPC },
+		func(e *models.Endpoint, value string) { e.RPC = value }),
+	endpointField("request.params",
+		func(e *models.Endpoint) string { return request(e).Params["id"] },
+		func(e *models.Endpoint, value string) { setEntry(&request(e).Params, "id", value) }),
+	endpointField("request.query",
+		func(e *models.Endpoint) string { return request(e).Query["page"] },
+		func(e *models.Endpoint, value string) { setEntry(&request(e).Query, "page", value) }),
+	endpointField("request.body",
+		func(e *models.Endpoint) string { return request(e).Body["email"] },
+		func(e *models.Endpoint, value string) { setEntry(&request(e).Body, "email", value) }),
+	endpointField("response.status",
+		func(e *models.Endpoint) string { return statusText(response(e).Status) },
+		func(e *models.Endpoint, value string) { response(e).Status, _ = strconv.Atoi(value) }),
+	endpointField("response.headers",
+		func(e *models.Endpoint) string { return response(e).Headers["X-Total"] },
+		func(e *models.Endpoint, value string) { setEntry(&response(e).Headers, "X-Total", value) }),
+	endpointField("response.body",
+		func(e *models.Endpoint) string { return response(e).Body["id"] },
+		func(e *models.Endpoint, value string) { setEntry(&response(e).Body, "id", value) }),
+	endpointField("errors",
+		func(e *models.Endpoint) string {
+			for _, errorResponse := range e.Errors {
+				if errorResponse.Status == 404 && errorResponse.Code == "NOT_FOUND" {
+					return errorResponse.Message
+				}
+			}
+			return ""
+		},
+		func(e *models.Endpoint, value string) {
+			var kept []models.ErrorResponse
+			for _, errorResponse := range e.Errors {
+				if errorResponse.Status != 404 {
+					kept = append(kept, errorResponse)
+				}
+			}
+			if value != "" {
+				kept = append(kept, models.ErrorResponse{Status: 404, Code: "NOT_FOUND", Message: value})
+			}
+			e.Errors = kept
+		}),
+}
+
+// fieldValues are the base value and two different changes of a field
+var fieldValues = map[string][3]string{
+	"base_url":        {"/api/v1", "/api/v2", "/api/v3"},
+	"auth_type":       {"bearer", "apikey", "basic"},
+	"response.status": {"200", "201", "202"},
+}
+
+func values(field string) (string, string, string) {
+	if v, ok := fieldValues[field]; ok {
+		return v[0], v[1], v[2]
+	}
+	return "base " + field, "ours " + field, "theirs " + field
+}
+
+func apiWithEndpoint() *models.API {
+	return &models.API{
+		BaseURL:  "/api",
+		AuthType: "none",
+		Endpoints: []models.Endpoint{{
+			Method: "GET",
+			Path:   "/users/{id}",
+			Errors: []models.ErrorResponse{{Status: 500, Code: "INTERNAL", Message: "Server error"}},
+		}},
+	}
+}
+
+func TestMergeFields(t *testing.T) {
+	cases := []struct {
+		name                string
+		base, ours, theirs  int // index into the field values, -1 for none
+		strategy            string
+		want                int
+		conflicts           int
+		skipTopLevelRemoval bool
+	}{
+		{name: "unchanged", base: 0, ours: 0, theirs: 0, want: 0},
+		{name: "changed locally", base: 0, ours: 1, theirs: 0, want: 1},
+		{name: "changed upstream", base: 0, ours: 0, theirs: 2, want: 2},
+		{name: "same change on both sides", base: 0, ours: 1, theirs: 1, want: 1},
+		{name: "added locally", base: -1, ours: 1, theirs: -1, want: 1},
+		{name: "added upstream", base: -1, ours: -1, theirs: 2, want: 2},
+		{name: "removed locally", base: 0, ours: -1, theirs: 0, want: -1},
+		{name: "removed upstream", base: 0, ours: 0, theirs: -1, want: -1},
+		{name: "conflict resolved as theirs", base: 0, ours: 1, theirs: 2, strategy: Theirs, want: 2, conflicts: 1},
+		{name: "conflict resolved as ours", base: 0, ours: 1, theirs: 2, strategy: Ours, want: 1, conflicts: 1},
+		{name: "added differently on both sides", base: -1, ours: 1, theirs: 2, strategy: Ours, want: 1, conflicts: 1},
+		{name: "removed upstream, changed locally", base: 0, ours: 1, theirs: -1, strategy: Ours, want: 1, conflicts: 1},
+		{name: "removed locally, changed upstream", base: 0, ours: -1, theirs: 2, strategy: Theirs, want: 2, conflicts: 1},
+	}
+
+	for _, field := range mergedFields {
+		for _, tc := range cases {
+			t.Run(field.name+"/"+tc.name, func(t *testing.T) {
+				v0, v1, v2 := values(field.name)
+				value := func(idx int) string {
+					switch idx {
+					case 0:
+						return v0
+					case 1:
+						return v1
+					case 2:
+						return v2
+					}
+					return ""
+				}
+
+				base, ours, theirs := apiWithEndpoint(), apiWithEndpoint(), apiWithEndpoint()
+				field.set(base, value(tc.base))
+				field.set(ours, value(tc.ours))
+				field.set(theirs, value(tc.theirs))
+
+				strategy := tc.strategy
+				if strategy == "" {
+					strategy = Theirs
+				}
+				merged, conflicts, err := Merge(base, ours, theirs, Strategy(strategy))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := field.get(merged); got != value(tc.want) {
+					t.Errorf("merged %s = %q, want %q", field.name, got, value(tc.want))
+				}
+				if len(conflicts) != tc.conflicts {
+					t.Errorf("conflicts = %v, want %d", conflicts, tc.conflicts)
+				}
+				// Unrelated fields are untouched
+				if got := merged.Endpoints[0].Errors; field.name != "errors" && (len(got) != 1 || got[0].Code != "INTERNAL") {
+					t.Errorf("errors = %+v, want the 500 error only", got)
+				}
+			})
+		}
+	}
+}
+
+func TestMergeWithoutBase(t *testing.T) {
+	cases := []struct {
+		name         string
+		ours, theirs int // index into the field values, -1 for none
+		strategy     string
+		want         int
+		conflicts    int
+	}{
+		{name: "same on both sides", ours: 1, theirs: 1, want: 1},
+		{name: "local only", ours: 1, theirs: -1, want: 1},
+		{name: "imported only", ours: -1, theirs: 2, want: 2},
+		{name: "different, resolved as ours", ours: 1, theirs: 2, strategy: Ours, want: 1, conflicts: 1},
+		{name: "different, resolved as theirs", ours: 1, theirs: 2, strategy: Theirs, want: 2, conflicts: 1},
+	}
+
+	for _, field := range mergedFields {
+		for _, tc := range cases {
+			t.Run(field.name+"/"+tc.name, func(t *testing.T) {
+				_, v1, v2 := values(field.name)
+				value := func(idx int) string {
+					switch idx {
+					case 1:
+						return v1
+					case 2:
+						return v2
+					}
+					return ""
+				}
+
+				ours, theirs := apiWithEndpoint(), apiWithEndpoint()
+				field.set(ours, value(tc.ours))
+				field.set(theirs, value(tc.theirs))
+
+				strategy := tc.strategy
+				if strategy == "" {
+					strategy = Theirs
+				}
+				merged, conflicts, err := Merge(nil, ours, theirs, Strategy(strategy))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := field.get(merged); got != value(tc.want) {
+					t.Errorf("merged %s = %q, want %q", field.name, got, value(tc.want))
+				}
+				if len(conflicts) != tc.conflicts {
+					t.Errorf("conflicts = %v, want %d", conflicts, tc.conflicts)
+				}
+			})
+		}
+	}
+}
+
+func TestMergeSections(t *testing.T) {
+	local := []models.Server{{Name: "staging", URL: "https://staging.example.com"}}
+	upstream := []models.Server{{Name: "production", URL: "https://api.example.com"}}
+	both := []models.Server{local[0], upstream[0]}
+
+	tests := []struct {
+		name               string
+		base, ours, theirs []models.Server
+		strategy           string
+		want               []models.Server
+		conflicts          int
+	}{
+		{"added upstream", nil, nil, upstream, Theirs, upstream, 0},
+		{"missing from the import", local, local, nil, Theirs, local, 0},
+		{"changed upstream", local, local, upstream, Ours, upstream, 0},
+		{"changed locally", upstream, both, upstream, Theirs, both, 0},
+		{"changed on both sides as ours", upstream, local, both, Ours, local, 1},
+		{"changed on both sides as theirs", upstream, local, both, Theirs, both, 1},
+		{"different without base as ours", nil, local, upstream, Ours, local, 1},
+		{"different without base as theirs", nil, local, upstream, Theirs, upstream, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var base *models.API
+			if tt.base != nil {
+				base = &models.API{Servers: tt.base}
+			}
+			merged, conflicts, err := Merge(base, &models.API{Servers: tt.ours}, &models.API{Servers: tt.theirs}, Strategy(tt.strategy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(merged.Servers, tt.want) {
+				t.Errorf("servers = %+v, want %+v", merged.Servers, tt.want)
+			}
+			if len(conflicts) != tt.conflicts {
+				t.Errorf("conflicts = %v, want %d", conflicts, tt.conflicts)
+			}
+		})
+	}
+
+	// The GraphQL schema and channels merge the same way
+	ours := &models.API{
+		GraphQL:  &models.GraphQL{Operations: []models.GraphQLOperation{{Type: "query", Name: "me", Output: "User"}}},
+		Channels: []models.Channel{{Name: "orders", Role: "consumer", Message: "OrderPlaced"}},
+	}
+	theirs := &models.API{
+		GraphQL:  &models.GraphQL{Operations: []models.GraphQLOperation{{Type: "query", Name: "viewer", Output: "User"}}},
+		Channels: []models.Channel{{Name: "orders", Role: "producer", Message: "OrderPlaced"}},
+	}
+	merged, conflicts, err := Merge(nil, ours, theirs, Strategy(Ours))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.GraphQL != ours.GraphQL || !reflect.DeepEqual(merged.Channels, ours.Channels) || len(conflicts) != 2 {
+		t.Errorf("merged = %+v with conflicts %v, want the local schema and channels with 2 conflicts", merged, conflicts)
+	}
+}
+
+func TestMergeAuth(t *testing.T) {
+	tests := []struct {
+		name               string
+		base, ours, theirs bool
+		want               bool
+	}{
+		{"enabled locally", false, true, false, true},
+		{"disabled locally", true, false, true, false},
+		{"enabled upstream", false, false, true, true},
+		{"disabled upstream", true, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, ours, theirs := apiWithEndpoint(), apiWithEndpoint(), apiWithEndpoint()
+			base.Endpoints[0].Auth = tt.base
+			ours.Endpoints[0].Auth = tt.ours
+			theirs.Endpoints[0].Auth = tt.theirs
+
+			merged, conflicts, err := Merge(base, ours, theirs, Strategy(Theirs))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if merged.Endpoints[0].Auth != tt.want || len(conflicts) != 0 {
+				t.Errorf("auth = %v with conflicts %v, want %v", merged.Endpoints[0].Auth, conflicts, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeEndpoints(t *testing.T) {
+	users := models.Endpoint{Method: "POST", Path: "/users", Description: "Create a user"}
+	changedUsers := users
+	changedUsers.Description = "Register a user"
+	orders := models.Endpoint{Method: "GET", Path: "/orders/{id}", Description: "Get an order"}
+
+	tests := []struct {
+		name               string
+		base, ours, theirs []models.Endpoint
+		strategy           string
+		want               []string
+		conflicts          int
+	}{
+		{
+			name: "added upstream",
+			ours: []models.Endpoint{users}, theirs: []models.Endpoint{users, orders},
+			want: []string{"POST /users", "GET /orders/{id}"},
+		},
+		{
+			name: "added locally",
+			base: []models.Endpoint{users}, ours: []models.Endpoint{users, orders}, theirs: []models.Endpoint{users},
+			want: []string{"POST /users", "GET /orders/{id}"},
+		},
+		{
+			name: "missing from an import without base",
+			ours: []models.Endpoint{users}, theirs: []models.Endpoint{orders},
+			want: []string{"POST /users", "GET /orders/{id}"},
+		},
+		{
+			name: "removed upstream is a conflict",
+			base: []models.Endpoint{users, orders}, ours: []models.Endpoint{users, orders}, theirs: []models.Endpoint{orders},
+			strategy: Theirs,
+			want:     []string{"GET /orders/{id}"}, conflicts: 1,
+		},
+		{
+			name: "removed upstream, kept as ours",
+			base: []models.Endpoint{users, orders}, ours: []models.Endpoint{users, orders}, theirs: []models.Endpoint{orders},
+			strategy: Ours,
+			want:     []string{"POST /users", "GET /orders/{id}"}, conflicts: 1,
+		},
+		{
+			name: "removed upstream, changed locally",
+			base: []models.Endpoint{users}, ours: []models.Endpoint{changedUsers}, theirs: nil,
+			strategy: Ours,
+			want:     []string{"POST /users"}, conflicts: 1,
+		},
+		{
+			name: "removed locally stays removed",
+			base: []models.Endpoint{users, orders}, ours: []models.Endpoint{orders}, theirs: []models.Endpoint{users, orders},
+			want: []string{"GET /orders/{id}"},
+		},
+		{
+			name: "removed locally, changed upstream",
+			base: []models.Endpoint{users}, ours: nil, theirs: []models.Endpoint{changedUsers},
+			strategy: Theirs,
+			want:     []string{"POST /users"}, conflicts: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var base *models.API
+			if tt.base != nil {
+				base = &models.API{Endpoints: tt.base}
+			}
+			strategy := tt.strategy
+			if strategy == "" {
+				strategy = Theirs
+			}
+			merged, conflicts, err := Merge(base, &models.API{Endpoints: tt.ours}, &models.API{Endpoints: tt.theirs}, Strategy(strategy))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, endpoint := range merged.Endpoints {
+				got = append(got, EndpointKey(endpoint))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("endpoints = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("endpoints = %v, want %v", got, tt.want)
+				}
+			}
+			if len(conflicts) != tt.conflicts {
+				t.Errorf("conflicts = %v, want %d", conflicts, tt.conflicts)
+			}
+		})
+	}
+}
+
+func TestMergeWebhooks(t *testing.T) {
+	paid := models.Webhook{Name: "order.paid", Payload: map[string]string{"id": "uuid"}}
+	paidLocal := models.Webhook{Name: "order.paid", Payload: map[string]string{"id": "uuid"}, Description: "Local note"}
+	paidUpstream := models.Webhook{Name: "order.paid", Payload: map[string]string{"id": "uuid", "amount": "integer"}}
+	shipped := models.Webhook{Name: "order.shipped"}
+
+	tests := []struct {
+		name               string
+		base, ours, theirs []models.Webhook
+		strategy           string
+		want               []models.Webhook
+		conflicts          int
+	}{
+		{"added upstream", nil, []models.Webhook{paid}, []models.Webhook{paid, shipped}, Theirs, []models.Webhook{paid, shipped}, 0},
+		{"changed upstream", []models.Webhook{paid}, []models.Webhook{paid}, []models.Webhook{paidUpstream}, Theirs, []models.Webhook{paidUpstream}, 0},
+		{"changed locally", []models.Webhook{paid}, []models.Webhook{paidLocal}, []models.Webhook{paid}, Theirs, []models.Webhook{paidLocal}, 0},
+		{"changed on both sides as theirs", []models.Webhook{paid}, []models.Webhook{paidLocal}, []models.Webhook{paidUpstream}, Theirs, []models.Webhook{paidUpstream}, 1},
+		{"changed on both sides as ours", []models.Webhook{paid}, []models.Webhook{paidLocal}, []models.Webhook{paidUpstream}, Ours, []models.Webhook{paidLocal}, 1},
+		{"removed upstream", []models.Webhook{paid, shipped}, []models.Webhook{paid, shipped}, []models.Webhook{paid}, Theirs, []models.Webhook{paid}, 1},
+		{"removed upstream, kept as ours", []models.Webhook{paid, shipped}, []models.Webhook{paid, shipped}, []models.Webhook{paid}, Ours, []models.Webhook{paid, shipped}, 1},
+		{"removed locally stays removed", []models.Webhook{paid, shipped}, []models.Webhook{paid}, []models.Webhook{paid, shipped}, Theirs, []models.Webhook{paid}, 0},
+		{"missing from an import without base", nil, []models.Webhook{shipped}, []models.Webhook{paid}, Theirs, []models.Webhook{shipped, paid}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var base *models.API
+			if tt.base != nil {
+				base = &models.API{Webhooks: tt.base}
+			}
+			merged, conflicts, err := Merge(base, &models.API{Webhooks: tt.ours}, &models.API{Webhooks: tt.theirs}, Strategy(tt.strategy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := Compare(&models.API{Webhooks: tt.want}, merged); !diff.Empty() {
+				t.Errorf("webhooks differ from the expected ones: %+v", diff.Items)
+			}
+			if len(merged.Webhooks) != len(tt.want) {
+				t.Errorf("webhooks = %+v, want %+v", merged.Webhooks, tt.want)
+			}
+			if len(conflicts) != tt.conflicts {
+				t.Errorf("conflicts = %v, want %d", conflicts, tt.conflicts)
+			}
+		})
+	}
+}
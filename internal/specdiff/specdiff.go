@@ -0,0 +1,294 @@
+// Package specdiff compares two API specifications: endpoints added,
+// removed or changed, with the changes of each endpoint down to single
+// fields, and the changes of the servers, GraphQL schema, channels and
+// webhooks. It also merges specifications three ways, field by field.
+package specdiff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Change kinds
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Diff holds the differences between two specifications
+type Diff struct {
+	// Settings are changes of the top-level settings, such as base_url
+	Settings []Change
+
+	Added   []models.Endpoint
+	Removed []models.Endpoint
+	Changed []EndpointDiff
+
+	// Items are changes of servers, GraphQL operations and types, channels
+	// and webhooks
+	Items []ItemDiff
+}
+
+// Change is a difference in a single field. Before is empty for added
+// fields and After for removed ones.
+type Change struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// EndpointDiff lists the field changes of an endpoint present on both sides
+type EndpointDiff struct {
+	Method  string
+	Path    string
+	Changes []Change
+}
+
+// ItemDiff is an added, removed or changed item of a section other than
+// endpoints
+type ItemDiff struct {
+	Section string // servers, graphql operations, graphql types, channels or webhooks
+	Name    string
+	Kind    string
+	Changes []Change // field changes of changed items
+}
+
+// Kind returns whether the field was added, removed or changed
+func (c Change) Kind() string {
+	switch {
+	case c.Before == "":
+		return Added
+	case c.After == "":
+		return Removed
+	default:
+		return Changed
+	}
+}
+
+func (c Change) String() string {
+	switch c.Kind() {
+	case Added:
+		return fmt.Sprintf("%s: %s", c.Field, c.After)
+	case Removed:
+		return fmt.Sprintf("%s: %s", c.Field, c.Before)
+	default:
+		return fmt.Sprintf("%s: %s → %s", c.Field, c.Before, c.After)
+	}
+}
+
+// Empty reports whether the specifications are the same
+func (d *Diff) Empty() bool {
+	return len(d.Settings) == 0 && len(d.Added) == 0 && len(d.Removed) == 0 &&
+		len(d.Changed) == 0 && len(d.Items) == 0
+}
+
+// Compare returns the changes turning before into after. A nil
+// specification is empty.
+func Compare(before, after *models.API) *Diff {
+	if before == nil {
+		before = &models.API{}
+	}
+	if after == nil {
+		after = &models.API{}
+	}
+
+	diff := &Diff{}
+	diff.Settings = compareFields(
+		map[string]string{"base_url": before.BaseURL, "auth_type": before.AuthType},
+		map[string]string{"base_url": after.BaseURL, "auth_type": after.AuthType},
+	)
+
+	beforeEndpoints := endpointsByKey(before.Endpoints)
+	afterEndpoints := endpointsByKey(after.Endpoints)
+	for _, key := range unionKeys(beforeEndpoints, afterEndpoints) {
+		old, inBefore := beforeEndpoints[key]
+		updated, inAfter := afterEndpoints[key]
+		switch {
+		case !inBefore:
+			diff.Added = append(diff.Added, updated)
+		case !inAfter:
+			diff.Removed = append(diff.Removed, old)
+		default:
+			if changes := compareFields(EndpointFields(old), EndpointFields(updated)); len(changes) > 0 {
+				diff.Changed = append(diff.Changed, EndpointDiff{Method: updated.Method, Path: updated.Path, Changes: changes})
+			}
+		}
+	}
+
+	for _, section := range sections {
+		beforeItems := section.items(before)
+		afterItems := section.items(after)
+		for _, name := range unionKeys(beforeItems, afterItems) {
+			old, inBefore := beforeItems[name]
+			updated, inAfter := afterItems[name]
+			switch {
+			case !inBefore:
+				diff.Items = append(diff.Items, ItemDiff{Section: section.name, Name: name, Kind: Added})
+			case !inAfter:
+				diff.Items = append(diff.Items, ItemDiff{Section: section.name, Name: name, Kind: Removed})
+			default:
+				if changes := compareFields(Flatten(old), Flatten(updated)); len(changes) > 0 {
+					diff.Items = append(diff.Items, ItemDiff{Section: section.name, Name: name, Kind: Changed, Changes: changes})
+				}
+			}
+		}
+	}
+
+	return diff
+}
+
+// EndpointKey identifies an endpoint by method and path
+func EndpointKey(endpoint models.Endpoint) string {
+	return strings.ToUpper(endpoint.Method) + " " + endpoint.Path
+}
+
+// EndpointFields flattens an endpoint into its fields, such as
+// "request.body.email" or "errors[404 NOT_FOUND]". Errors are keyed by
+// status and code rather than position, so reordering them is no change.
+func EndpointFields(endpoint models.Endpoint) map[string]string {
+	errors := endpoint.Errors
+	endpoint.Errors = nil
+	endpoint.Method = ""
+	endpoint.Path = ""
+
+	fields := Flatten(endpoint)
+	// A zero status is unset
+	if fields["response.status"] == "0" {
+		delete(fields, "response.status")
+	}
+	for _, errorResponse := range errors {
+		fields[ErrorField(errorResponse)] = errorResponse.Message
+	}
+	return fields
+}
+
+// ErrorField is the field name of an error response in EndpointFields
+func ErrorField(errorResponse models.ErrorResponse) string {
+	return fmt.Sprintf("errors[%d %s]", errorResponse.Status, errorResponse.Code)
+}
+
+// Flatten returns the scalar values of a model keyed by their dotted YAML
+// path, such as "response.body.id". Empty values are left out, so that an
+// empty field and a missing one compare equal.
+func Flatten(value interface{}) map[string]string {
+	fields := make(map[string]string)
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return fields
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return fields
+	}
+	flatten("", generic, fields)
+	return fields
+}
+
+func flatten(prefix string, value interface{}, fields map[string]string) {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			flatten(name, nested, fields)
+		}
+	case []interface{}:
+		for idx, nested := range typed {
+			flatten(fmt.Sprintf("%s[%d]", prefix, idx), nested, fields)
+		}
+	case nil:
+	default:
+		if text := fmt.Sprint(typed); text != "" {
+			fields[prefix] = text
+		}
+	}
+}
+
+// compareFields returns the changes between two sets of fields, sorted by
+// field name
+func compareFields(before, after map[string]string) []Change {
+	var changes []Change
+	for _, field := range unionKeys(before, after) {
+		if before[field] != after[field] {
+			changes = append(changes, Change{Field: field, Before: before[field], After: after[field]})
+		}
+	}
+	return changes
+}
+
+func endpointsByKey(endpoints []models.Endpoint) map[string]models.Endpoint {
+	byKey := make(map[string]models.Endpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		byKey[EndpointKey(endpoint)] = endpoint
+	}
+	return byKey
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// section extracts the items of a section of the specification by name
+type section struct {
+	name  string
+	items func(api *models.API) map[string]interface{}
+}
+
+var sections = []section{
+	{"servers", func(api *models.API) map[string]interface{} {
+		items := make(map[string]interface{})
+		for _, server := range api.Servers {
+			items[server.Name] = server
+		}
+		return items
+	}},
+	{"graphql operations", func(api *models.API) map[string]interface{} {
+		items := make(map[string]interface{})
+		if api.GraphQL != nil {
+			for _, operation := range api.GraphQL.Operations {
+				items[operation.Type+" "+operation.Name] = operation
+			}
+		}
+		return items
+	}},
+	{"graphql types", func(api *models.API) map[string]interface{} {
+		items := make(map[string]interface{})
+		if api.GraphQL != nil {
+			for _, graphqlType := range api.GraphQL.Types {
+				items[graphqlType.Name] = graphqlType
+			}
+		}
+		return items
+	}},
+	{"channels", func(api *models.API) map[string]interface{} {
+		items := make(map[string]interface{})
+		for _, channel := range api.Channels {
+			items[strings.TrimSpace(channel.Role+" "+channel.Name+" "+channel.Message)] = channel
+		}
+		return items
+	}},
+	{"webhooks", func(api *models.API) map[string]interface{} {
+		items := make(map[string]interface{})
+		for _, webhook := range api.Webhooks {
+			items[webhook.Name] = webhook
+		}
+		return items
+	}},
+}
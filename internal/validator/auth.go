@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// CheckAuth compares the auth requirement declared for an endpoint with the
+// auth middleware, decorators or dependencies detected for its route
+func CheckAuth(endpoint models.Endpoint, route Route) []Issue {
+	issue := Issue{Kind: "auth", File: route.File, Line: route.Line}
+	switch {
+	case endpoint.Auth && !route.Auth:
+		issue.Rule = RuleAuthMissing
+		issue.Severity = SeverityError
+		issue.Message = "endpoint requires auth but no auth middleware, decorator or dependency was found"
+	case !endpoint.Auth && route.Auth:
+		issue.Rule = RuleAuthUnexpected
+		issue.Severity = SeverityWarning
+		issue.Message = "route is behind auth but api.yaml declares auth: false"
+	default:
+		return nil
+	}
+	return []Issue{issue}
+}
+
+// authFragments are lower-cased identifier fragments that indicate
+// authentication middleware, guards, decorators or dependencies
+var authFragments = []string{
+	"authenticat", "authoriz", "oauth", "jwt", "bearer", "passport",
+	"login_required", "loginrequired", "logged_in", "loggedin",
+	"current_user", "currentuser", "require_user", "requireuser",
+	"require_login", "requirelogin", "token_required", "tokenrequired",
+	"verify_token", "verifytoken", "protect", "guard", "secured",
+	"rolesallowed", "permission",
+}
+
+var wordBoundary = regexp.MustCompile(`[^A-Za-z0-9]+|([a-z0-9])([A-Z])`)
+
+// isAuthIdentifier reports whether an identifier names authentication
+// middleware or a dependency providing the authenticated user. "auth" must
+// be a whole word so that names like "listAuthors" do not match.
+func isAuthIdentifier(name string) bool {
+	lower := strings.ToLower(name)
+	for _, fragment := range authFragments {
+		if strings.Contains(lower, fragment) {
+			return true
+		}
+	}
+
+	for _, word := range strings.Fields(wordBoundary.ReplaceAllString(name, "$1 $2")) {
+		if strings.EqualFold(word, "auth") {
+			return true
+		}
+	}
+	return false
+}
+
+var stringLiteral = regexp.MustCompile(`"[^"]*"|'[^']*'|` + "`[^`]*`")
+
+// mentionsAuth reports whether any identifier in the code, outside of string
+// literals, names authentication
+func mentionsAuth(code string) bool {
+	for _, ident := range identifierPattern.FindAllString(stringLiteral.ReplaceAllString(code, ""), -1) {
+		if isAuthIdentifier(ident) {
+			return true
+		}
+	}
+	return false
+}
+
+var handlerName = regexp.MustCompile(`^(?:[\w$]+\.)*[\w$]+$`)
+
+// middlewareAuth reports whether the arguments of a route registration
+// (everything after the path) include auth middleware. A trailing named
+// handler is ignored so that handlers such as getCurrentUser do not count.
+func middlewareAuth(args string) bool {
+	parts := strings.Split(args, ",")
+	last := strings.TrimRight(strings.TrimSpace(parts[len(parts)-1]), " );")
+	if handlerName.MatchString(last) {
+		parts = parts[:len(parts)-1]
+	}
+	return mentionsAuth(strings.Join(parts, ","))
+}
+
+// annotationBlock returns the contiguous decorator/annotation lines around
+// the line at idx, which usually carry per-handler auth decorators
+func annotationBlock(lines []string, idx int) []string {
+	isAnnotation := func(line string) bool {
+		return strings.HasPrefix(strings.TrimSpace(line), "@")
+	}
+
+	start, end := idx, idx+1
+	for start > 0 && isAnnotation(lines[start-1]) {
+		start--
+	}
+	for end < len(lines) && isAnnotation(lines[end]) {
+		end++
+	}
+	return lines[start:end]
+}
+
+// pythonParameters returns the parameter list of the function definition
+// following a decorator block. The function name is left out so that
+// handlers such as authenticate_user or get_current_user_profile do not
+// count as protected; only parameter annotations and defaults such as
+// Depends(get_current_user) do.
+func pythonParameters(lines []string, idx int) string {
+	for i := idx; i < len(lines) && i < idx+maxContextLines; i++ {
+		def := pyFunctionDef.FindStringIndex(lines[i])
+		if def == nil {
+			continue
+		}
+		signature := []string{lines[i][def[1]:]}
+		for end := i; end < len(lines) && end < i+maxContextLines; end++ {
+			if end > i {
+				signature = append(signature, lines[end])
+			}
+			if strings.HasSuffix(strings.TrimSpace(stripLineComment(lines[end], "#")), ":") {
+				break
+			}
+		}
+
+		// Drop the return annotation after the closing parenthesis
+		parameters := strings.Join(signature, "\n")
+		if close := strings.LastIndex(parameters, ")"); close >= 0 {
+			parameters = parameters[:close]
+		}
+		return parameters
+	}
+	return ""
+}
+
+var pyFunctionDef = regexp.MustCompile(`^\s*(?:async\s+)?def\s+\w+\s*\(?`)
@@ -0,0 +1,141 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+func TestIsAuthIdentifier(t *testing.T) {
+	tests := map[string]bool{
+		"authMiddleware":    true,
+		"requireAuth":       true,
+		"auth":              true,
+		"get_current_user":  true,
+		"login_required":    true,
+		"JwtAuthGuard":      true,
+		"listAuthors":       false,
+		"author_id":         false,
+		"createOrder":       false,
+		"AuthorsController": false,
+	}
+	for name, want := range tests {
+		if got := isAuthIdentifier(name); got != want {
+			t.Errorf("isAuthIdentifier(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestMiddlewareAuth(t *testing.T) {
+	tests := map[string]bool{
+		`requireAuth, getOrder)`:           true,
+		`passport.authenticate('jwt'), h)`: true,
+		`getCurrentUser)`:                  false,
+		`handlers.authenticateUser)`:       false,
+		`async (req, res) => {`:            false,
+		`"/auth/login", login)`:            false,
+	}
+	for args, want := range tests {
+		if got := middlewareAuth(args); got != want {
+			t.Errorf("middlewareAuth(%q) = %v, want %v", args, got, want)
+		}
+	}
+}
+
+func TestPythonRouteAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{
+			name: "handler named after auth",
+			content: `@router.post("/login")
+async def authenticate_user(credentials: Credentials):
+    pass
+`,
+		},
+		{
+			name: "handler returning the current user profile",
+			content: `@router.get("/me")
+def get_current_user_profile() -> Profile:
+    pass
+`,
+		},
+		{
+			name: "Depends parameter",
+			content: `@router.get("/me")
+def me(user: User = Depends(get_current_user)):
+    pass
+`,
+			want: true,
+		},
+		{
+			name: "Depends parameter on a later line",
+			content: `@router.get("/orders")
+async def list_orders(
+    page: int = 1,
+    user: Annotated[User, Depends(get_current_user)] = None,
+):
+    pass
+`,
+			want: true,
+		},
+		{
+			name: "decorator",
+			content: `@app.route("/admin")
+@login_required
+def admin_dashboard():
+    pass
+`,
+			want: true,
+		},
+		{
+			name: "route decorator between other decorators",
+			content: `@cache
+@app.get("/public")
+@log_calls
+def public():
+    pass
+
+
+def authenticate_user(user: User = Depends(get_current_user)):
+    pass
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routes := ExtractRoutes("app.py", []byte(tt.content))
+			if len(routes) != 1 {
+				t.Fatalf("routes = %+v, want one", routes)
+			}
+			if routes[0].Auth != tt.want {
+				t.Errorf("auth = %v, want %v", routes[0].Auth, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAuth(t *testing.T) {
+	tests := []struct {
+		declared, detected bool
+		want               string
+	}{
+		{true, true, ""},
+		{false, false, ""},
+		{true, false, RuleAuthMissing},
+		{false, true, RuleAuthUnexpected},
+	}
+	for _, tt := range tests {
+		issues := CheckAuth(models.Endpoint{Auth: tt.declared}, Route{Auth: tt.detected, File: "app.py", Line: 3})
+		got := ""
+		if len(issues) > 0 {
+			got = issues[0].Rule
+		}
+		if got != tt.want {
+			t.Errorf("declared %v, detected %v: rule %q, want %q", tt.declared, tt.detected, got, tt.want)
+		}
+	}
+}
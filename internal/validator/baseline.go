@@ -0,0 +1,204 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BaselineEntry identifies an accepted finding. Entries deliberately omit
+// messages and line numbers so that unrelated edits do not invalidate them.
+type BaselineEntry struct {
+	Rule     string `yaml:"rule"`
+	Endpoint string `yaml:"endpoint"`
+	Field    string `yaml:"field,omitempty"`
+}
+
+// Baseline is the set of known findings that validate does not fail on
+type Baseline struct {
+	Findings []BaselineEntry `yaml:"findings"`
+}
+
+const baselineHeader = "# Accepted validation findings. validate only fails on findings not listed here.\n# Regenerate with: architect validate --update-baseline\n"
+
+// LoadBaseline reads a baseline file. A missing file is an empty baseline.
+func LoadBaseline(filename string) (*Baseline, error) {
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return &Baseline{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var baseline Baseline
+	if err := yaml.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", filename, err)
+	}
+	return &baseline, nil
+}
+
+// WriteBaseline writes the given entries as a sorted, de-duplicated baseline
+func WriteBaseline(filename string, entries []BaselineEntry) error {
+	seen := make(map[BaselineEntry]bool)
+	baseline := Baseline{Findings: []BaselineEntry{}}
+	for _, entry := range entries {
+		if !seen[entry] {
+			seen[entry] = true
+			baseline.Findings = append(baseline.Findings, entry)
+		}
+	}
+	sort.Slice(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.Field < b.Field
+	})
+
+	data, err := yaml.Marshal(baseline)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append([]byte(baselineHeader), data...), 0644)
+}
+
+// Location is a file position that may carry an inline suppression comment
+type Location struct {
+	File string
+	Line int
+}
+
+// FindingFilter drops findings that are suppressed by an inline
+// architect:ignore comment or accepted in the baseline, and keeps track of
+// what it has seen so the baseline can be refreshed
+type FindingFilter struct {
+	Accepted   int
+	Suppressed int
+
+	baseline map[BaselineEntry]bool
+	matched  map[BaselineEntry]bool
+	seen     []BaselineEntry
+	files    map[string][]string
+}
+
+// NewFindingFilter creates a filter for the given baseline
+func NewFindingFilter(baseline *Baseline) *FindingFilter {
+	filter := &FindingFilter{
+		baseline: make(map[BaselineEntry]bool),
+		matched:  make(map[BaselineEntry]bool),
+		files:    make(map[string][]string),
+	}
+	for _, entry := range baseline.Findings {
+		filter.baseline[entry] = true
+	}
+	return filter
+}
+
+// Finding statuses returned by Check
+const (
+	FindingReported   = "reported"
+	FindingBaselined  = "baselined"
+	FindingSuppressed = "suppressed"
+)
+
+// Check reports whether a finding is reported, accepted in the baseline or
+// suppressed by an inline comment. Any of the locations may carry an inline
+// suppression comment.
+func (f *FindingFilter) Check(entry BaselineEntry, locations ...Location) string {
+	for _, location := range locations {
+		if f.ignoredAt(entry.Rule, location) {
+			f.Suppressed++
+			return FindingSuppressed
+		}
+	}
+
+	f.seen = append(f.seen, entry)
+	if f.baseline[entry] {
+		f.matched[entry] = true
+		f.Accepted++
+		return FindingBaselined
+	}
+	return FindingReported
+}
+
+// Seen returns every finding that was not suppressed inline, including
+// findings accepted in the baseline
+func (f *FindingFilter) Seen() []BaselineEntry {
+	return f.seen
+}
+
+// Stale returns baseline entries that no longer match any finding
+func (f *FindingFilter) Stale() []BaselineEntry {
+	var stale []BaselineEntry
+	for entry := range f.baseline {
+		if !f.matched[entry] {
+			stale = append(stale, entry)
+		}
+	}
+	return stale
+}
+
+// ignoreComment matches "# architect:ignore" and "// architect:ignore",
+// optionally followed by the rule IDs to ignore. Words that are not rule IDs
+// (such as a reason) are ignored.
+var ignoreComment = regexp.MustCompile(`(?:#|//)\s*architect:ignore\b([\w\-, ]*)`)
+
+// ignoredAt reports whether the line at location, or a comment-only line
+// directly above it, suppresses the rule
+func (f *FindingFilter) ignoredAt(rule string, location Location) bool {
+	if location.File == "" || location.Line <= 0 {
+		return false
+	}
+
+	lines, ok := f.files[location.File]
+	if !ok {
+		if data, err := os.ReadFile(location.File); err == nil {
+			lines = splitLines(data)
+		}
+		f.files[location.File] = lines
+	}
+	if location.Line > len(lines) {
+		return false
+	}
+
+	if ignoresRule(lines[location.Line-1], rule) {
+		return true
+	}
+	if location.Line > 1 {
+		previous := strings.TrimSpace(lines[location.Line-2])
+		if strings.HasPrefix(previous, "#") || strings.HasPrefix(previous, "//") {
+			return ignoresRule(previous, rule)
+		}
+	}
+	return false
+}
+
+func ignoresRule(line, rule string) bool {
+	match := ignoreComment.FindStringSubmatch(line)
+	if match == nil {
+		return false
+	}
+	var rules []string
+	for _, word := range strings.FieldsFunc(match[1], func(r rune) bool { return r == ',' || r == ' ' }) {
+		if _, ok := RuleDescriptions[word]; ok {
+			rules = append(rules, word)
+		}
+	}
+	if len(rules) == 0 {
+		return true
+	}
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
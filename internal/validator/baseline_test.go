@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindingFilterCheck(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "routes.py")
+	content := "@app.get('/a')  # architect:ignore auth-missing\n@app.get('/b')\n"
+	if err := os.WriteFile(source, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	accepted := BaselineEntry{Rule: RuleAuthMissing, Endpoint: "GET /b"}
+	filter := NewFindingFilter(&Baseline{Findings: []BaselineEntry{accepted}})
+
+	tests := []struct {
+		name     string
+		entry    BaselineEntry
+		location Location
+		want     string
+	}{
+		{"suppressed inline", BaselineEntry{Rule: RuleAuthMissing, Endpoint: "GET /a"}, Location{File: source, Line: 1}, FindingSuppressed},
+		{"accepted in baseline", accepted, Location{File: source, Line: 2}, FindingBaselined},
+		{"new finding", BaselineEntry{Rule: RuleEndpointNotImplemented, Endpoint: "GET /c"}, Location{}, FindingReported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filter.Check(tt.entry, tt.location); got != tt.want {
+				t.Errorf("Check = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if filter.Accepted != 1 || filter.Suppressed != 1 {
+		t.Errorf("accepted %d, suppressed %d; want 1 and 1", filter.Accepted, filter.Suppressed)
+	}
+	if len(filter.Stale()) != 0 {
+		t.Errorf("unexpected stale entries: %v", filter.Stale())
+	}
+}
+
+func TestIgnoresRule(t *testing.T) {
+	tests := []struct {
+		line string
+		rule string
+		want bool
+	}{
+		{"r.Get(\"/a\", h) // architect:ignore", RuleAuthMissing, true},
+		{"@app.get('/a')  # architect:ignore auth-missing", RuleAuthMissing, true},
+		{"@app.get('/a')  # architect:ignore auth-missing", RuleRouteUndocumented, false},
+		{"// architect:ignore auth-missing, route-undocumented", RuleRouteUndocumented, true},
+		{"// architect:ignore internal health check", RuleRouteUndocumented, true},
+		{"// architect:ignored", RuleAuthMissing, false},
+		{"r.Get(\"/a\", h)", RuleAuthMissing, false},
+	}
+	for _, tt := range tests {
+		if got := ignoresRule(tt.line, tt.rule); got != tt.want {
+			t.Errorf("ignoresRule(%q, %s) = %v, want %v", tt.line, tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestFindingFilterCommentAbove(t *testing.T) {
+	dir := t.TempDir()
+	source := filepath.Join(dir, "routes.go")
+	content := "// architect:ignore route-undocumented\nr.Get(\"/a\", h)\nr.Get(\"/b\", h)\n"
+	if err := os.WriteFile(source, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	filter := NewFindingFilter(&Baseline{})
+	if got := filter.Check(BaselineEntry{Rule: RuleRouteUndocumented, Endpoint: "GET /a"}, Location{File: source, Line: 2}); got != FindingSuppressed {
+		t.Errorf("route below the comment: %q, want suppressed", got)
+	}
+	if got := filter.Check(BaselineEntry{Rule: RuleRouteUndocumented, Endpoint: "GET /b"}, Location{File: source, Line: 3}); got != FindingReported {
+		t.Errorf("route two lines below the comment: %q, want reported", got)
+	}
+}
+
+func TestBaselineRoundTrip(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "baseline.yaml")
+
+	empty, err := LoadBaseline(filename)
+	if err != nil || len(empty.Findings) != 0 {
+		t.Fatalf("missing baseline = %+v, %v; want an empty baseline", empty, err)
+	}
+
+	entries := []BaselineEntry{
+		{Rule: RuleSchemaMissingField, Endpoint: "POST /users", Field: "email"},
+		{Rule: RuleAuthMissing, Endpoint: "GET /b"},
+		{Rule: RuleAuthMissing, Endpoint: "GET /b"},
+		{Rule: RuleRouteUndocumented, Endpoint: "GET /a"},
+	}
+	if err := WriteBaseline(filename, entries); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadBaseline(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []BaselineEntry{entries[3], entries[1], entries[0]}
+	if len(loaded.Findings) != len(want) {
+		t.Fatalf("findings = %+v, want %+v", loaded.Findings, want)
+	}
+	for idx := range want {
+		if loaded.Findings[idx] != want[idx] {
+			t.Errorf("finding %d = %+v, want %+v", idx, loaded.Findings[idx], want[idx])
+		}
+	}
+
+	filter := NewFindingFilter(loaded)
+	filter.Check(entries[1])
+	if stale := filter.Stale(); len(stale) != 2 {
+		t.Errorf("stale = %+v, want the two unmatched entries", stale)
+	}
+}
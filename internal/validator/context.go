@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+// maxContextLines bounds how far after a route or function definition we
+// look for referenced types
+const maxContextLines = 60
+
+var (
+	identifierPattern = regexp.MustCompile(`[A-Za-z_$][\w$]*`)
+	routeHandlerArg   = regexp.MustCompile(`[,(]\s*(?:[\w$]+\.)*([\w$]+)\s*\)+\s*(?:\.Methods\([^)]*\))?\s*;?\s*$`)
+
+	functionDefinitions = map[string]*regexp.Regexp{
+		".go": regexp.MustCompile(`^func\s+(?:\([^)]*\)\s*)?(\w+)\s*\(`),
+		".py": regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)\s*\(`),
+		".js": regexp.MustCompile(`^\s*(?:export\s+)?(?:(?:async\s+)?function\s+(\w+)|(?:const|let|var)\s+(\w+)\s*=\s*(?:async\s*)?(?:function|\(|\w+\s*=>)|(?:exports|module\.exports)\.(\w+)\s*=|(?:public\s+|private\s+|static\s+)*(?:async\s+)?(\w+)\s*\([^)]*\)\s*(?::\s*[^{]+)?\{)`),
+	}
+)
+
+func functionDefinition(ext string) *regexp.Regexp {
+	switch ext {
+	case ".ts", ".mjs", ".cjs":
+		ext = ".js"
+	}
+	return functionDefinitions[ext]
+}
+
+// identifiers returns the distinct identifiers used in the given lines
+func identifiers(lines []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, line := range lines {
+		for _, ident := range identifierPattern.FindAllString(line, -1) {
+			if !seen[ident] {
+				seen[ident] = true
+				result = append(result, ident)
+			}
+		}
+	}
+	return result
+}
+
+// attachRouteContext records the handler name and the identifiers referenced
+// between each route definition and the next one in the same file
+func attachRouteContext(routes []Route, lines []string) {
+	starts := make([]int, 0, len(routes))
+	for _, route := range routes {
+		starts = append(starts, route.Line)
+	}
+	sort.Ints(starts)
+
+	for idx := range routes {
+		route := &routes[idx]
+		if route.Line < 1 || route.Line > len(lines) {
+			continue
+		}
+
+		end := route.Line + maxContextLines
+		for _, start := range starts {
+			if start > route.Line {
+				if start-1 < end {
+					end = start - 1
+				}
+				break
+			}
+		}
+		if end > len(lines) {
+			end = len(lines)
+		}
+		if end < route.Line {
+			end = route.Line
+		}
+
+		route.References = identifiers(lines[route.Line-1 : end])
+		if m := routeHandlerArg.FindStringSubmatch(lines[route.Line-1]); m != nil {
+			route.Handler = m[1]
+		}
+	}
+}
+
+// extractFunctionReferences maps each function defined in the file to the
+// identifiers referenced in its body, so routes registered with a named
+// handler can be linked to the DTOs that handler uses
+func extractFunctionReferences(file string, lines []string) map[string][]string {
+	pattern := functionDefinition(filepath.Ext(file))
+	if pattern == nil {
+		return nil
+	}
+
+	type definition struct {
+		name string
+		line int
+	}
+	var definitions []definition
+	for idx, line := range lines {
+		m := pattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		for _, name := range m[1:] {
+			if name != "" && !isKeyword(name) {
+				definitions = append(definitions, definition{name: name, line: idx})
+				break
+			}
+		}
+	}
+
+	refs := make(map[string][]string)
+	for idx, def := range definitions {
+		end := def.line + maxContextLines
+		if idx+1 < len(definitions) && definitions[idx+1].line < end {
+			end = definitions[idx+1].line
+		}
+		if end > len(lines) {
+			end = len(lines)
+		}
+		refs[def.name] = identifiers(lines[def.line:end])
+	}
+	return refs
+}
+
+func isKeyword(name string) bool {
+	switch name {
+	case "if", "for", "while", "switch", "catch", "function", "return", "constructor":
+		return true
+	}
+	return false
+}
+
+// mergeReferences appends function references to a map shared across files
+func mergeReferences(dst map[string][]string, src map[string][]string) {
+	for name, refs := range src {
+		dst[name] = append(dst[name], refs...)
+	}
+}
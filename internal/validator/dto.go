@@ -0,0 +1,550 @@
+package validator
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Model is a request/response data type discovered in source code, such as a
+// Go struct, a Pydantic model, a TypeScript interface or a zod schema
+type Model struct {
+	Name   string
+	File   string
+	Line   int
+	Fields map[string]ModelField
+	bases  []string
+}
+
+// ModelField is a single field of a Model, with its type mapped onto the
+// api.yaml type vocabulary (string, integer, number, boolean, uuid, datetime,
+// array, object or any), or TypeUnknown for named types such as enums and
+// type aliases that could not be resolved
+type ModelField struct {
+	Type     string
+	Required bool
+	File     string
+	Line     int
+
+	// named is the type name of a TypeUnknown field
+	named string
+}
+
+// TypeUnknown is the type of DTO fields whose named type could not be
+// resolved; their type is not compared with api.yaml
+const TypeUnknown = "unknown"
+
+// ExtractModels returns all DTO models defined in the given file content
+func ExtractModels(file string, content []byte) []*Model {
+	switch filepath.Ext(file) {
+	case ".go":
+		return extractGoModels(file, splitLines(content))
+	case ".py":
+		return extractPydanticModels(file, splitLines(content))
+	case ".ts", ".js", ".mjs", ".cjs":
+		return extractTypeScriptModels(file, splitLines(content))
+	default:
+		return nil
+	}
+}
+
+// Go structs with json tags
+var (
+	goStructStart = regexp.MustCompile(`^\s*type\s+(\w+)\s+struct\s*\{\s*$`)
+	goStructField = regexp.MustCompile("^\\s*(\\w+)\\s+([^\\s`]+)\\s*(?:`([^`]*)`)?")
+	goJSONTag     = regexp.MustCompile(`json:"([^"]*)"`)
+	goRequiredTag = regexp.MustCompile(`(?:binding|validate):"[^"]*\brequired\b`)
+)
+
+func extractGoModels(file string, lines []string) []*Model {
+	var models []*Model
+
+	for idx := 0; idx < len(lines); idx++ {
+		m := goStructStart.FindStringSubmatch(lines[idx])
+		if m == nil {
+			continue
+		}
+
+		model := &Model{Name: m[1], File: file, Line: idx + 1, Fields: make(map[string]ModelField)}
+		depth := 1
+		for idx++; idx < len(lines) && depth > 0; idx++ {
+			line := stripLineComment(lines[idx], "//")
+			if depth == 1 {
+				if f := goStructField.FindStringSubmatch(line); f != nil && f[1] != "}" {
+					name, field, ok := goField(f[1], f[2], f[3])
+					if ok {
+						field.Line = idx + 1
+						model.Fields[name] = field
+					}
+				}
+			}
+			depth += strings.Count(line, "{") - strings.Count(line, "}")
+		}
+		idx--
+
+		if len(model.Fields) > 0 {
+			models = append(models, model)
+		}
+	}
+
+	return models
+}
+
+func goField(name, goType, tag string) (string, ModelField, bool) {
+	if name[0] < 'A' || name[0] > 'Z' {
+		return "", ModelField{}, false
+	}
+
+	jsonName := name
+	omitEmpty := false
+	if m := goJSONTag.FindStringSubmatch(tag); m != nil {
+		parts := strings.Split(m[1], ",")
+		if parts[0] == "-" {
+			return "", ModelField{}, false
+		}
+		if parts[0] != "" {
+			jsonName = parts[0]
+		}
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" || opt == "omitzero" {
+				omitEmpty = true
+			}
+		}
+	}
+
+	pointer := strings.HasPrefix(goType, "*")
+	required := goRequiredTag.MatchString(tag) || (!pointer && !omitEmpty)
+
+	field := ModelField{Type: goTypeToSpec(goType), Required: required}
+	if field.Type == TypeUnknown {
+		named := strings.TrimLeft(goType, "*")
+		field.named = named[strings.LastIndex(named, ".")+1:]
+	}
+	return jsonName, field, true
+}
+
+func goTypeToSpec(goType string) string {
+	goType = strings.TrimLeft(goType, "*")
+	switch {
+	case strings.HasPrefix(goType, "[]byte"):
+		return "string"
+	case strings.HasPrefix(goType, "[]"):
+		return "array"
+	case strings.HasPrefix(goType, "map["), goType == "struct{}", goType == "struct":
+		return "object"
+	case goType == "interface{}", goType == "any", goType == "json.RawMessage":
+		return "any"
+	}
+
+	switch goType {
+	case "string":
+		return "string"
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return "integer"
+	case "float32", "float64", "json.Number":
+		return "number"
+	case "time.Time":
+		return "datetime"
+	case "uuid.UUID":
+		return "uuid"
+	default:
+		return TypeUnknown
+	}
+}
+
+// Python Pydantic models (and dataclasses)
+var (
+	pyClassStart = regexp.MustCompile(`^class\s+(\w+)\s*(?:\(([^)]*)\))?\s*:`)
+	pyClassField = regexp.MustCompile(`^\s+(\w+)\s*:\s*([^=]+?)\s*(?:=\s*(.*))?$`)
+)
+
+func extractPydanticModels(file string, lines []string) []*Model {
+	var models []*Model
+
+	for idx := 0; idx < len(lines); idx++ {
+		m := pyClassStart.FindStringSubmatch(lines[idx])
+		if m == nil {
+			continue
+		}
+
+		model := &Model{Name: m[1], File: file, Line: idx + 1, Fields: make(map[string]ModelField)}
+		for _, base := range strings.Split(m[2], ",") {
+			if base = strings.TrimSpace(base); base != "" {
+				model.bases = append(model.bases, base[strings.LastIndex(base, ".")+1:])
+			}
+		}
+
+		for idx++; idx < len(lines); idx++ {
+			line := lines[idx]
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+				break
+			}
+			f := pyClassField.FindStringSubmatch(stripLineComment(line, "#"))
+			if f == nil || strings.HasPrefix(f[1], "_") || f[1] == "model_config" {
+				continue
+			}
+			specType, optional := pythonTypeToSpec(f[2])
+			field := ModelField{Type: specType, Required: !optional && !pythonDefaulted(f[3]), Line: idx + 1}
+			if specType == TypeUnknown {
+				field.named = pythonTypeName(f[2])
+			}
+			model.Fields[f[1]] = field
+		}
+		idx--
+
+		if len(model.Fields) > 0 || len(model.bases) > 0 {
+			models = append(models, model)
+		}
+	}
+
+	return models
+}
+
+func pythonTypeToSpec(pyType string) (string, bool) {
+	pyType = strings.TrimSpace(pyType)
+	optional := false
+
+	if inner, ok := unwrapGeneric(pyType, "Optional"); ok {
+		pyType, optional = inner, true
+	}
+	if strings.Contains(pyType, "|") {
+		var kept []string
+		for _, part := range strings.Split(pyType, "|") {
+			if part = strings.TrimSpace(part); part == "None" {
+				optional = true
+			} else {
+				kept = append(kept, part)
+			}
+		}
+		pyType = strings.Join(kept, "|")
+	}
+	if inner, ok := unwrapGeneric(pyType, "Annotated"); ok {
+		pyType = strings.TrimSpace(strings.SplitN(inner, ",", 2)[0])
+	}
+
+	base := pythonTypeName(pyType)
+	if base == "Literal" {
+		if inner, ok := unwrapGeneric(pyType, "Literal"); ok && (strings.HasPrefix(inner, `"`) || strings.HasPrefix(inner, "'")) {
+			return "string", optional
+		}
+	}
+
+	switch base {
+	case "str", "EmailStr", "HttpUrl", "AnyUrl", "constr", "SecretStr":
+		return "string", optional
+	case "int", "conint", "PositiveInt":
+		return "integer", optional
+	case "float", "Decimal", "confloat":
+		return "number", optional
+	case "bool":
+		return "boolean", optional
+	case "UUID", "UUID4", "UUID1":
+		return "uuid", optional
+	case "datetime", "date", "AwareDatetime":
+		return "datetime", optional
+	case "list", "List", "Set", "set", "Sequence", "tuple", "Tuple", "conlist":
+		return "array", optional
+	case "dict", "Dict", "Mapping", "Json":
+		return "object", optional
+	case "Any":
+		return "any", optional
+	default:
+		return TypeUnknown, optional
+	}
+}
+
+// pythonTypeName returns the unqualified name of a type annotation without
+// its generic arguments
+func pythonTypeName(pyType string) string {
+	name := strings.TrimSpace(pyType)
+	if idx := strings.Index(name, "["); idx >= 0 {
+		name = name[:idx]
+	}
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// pythonDefaulted reports whether a field assignment gives the field a
+// default: a plain value, or Field() with default=, default_factory= or a
+// first positional argument other than ...
+func pythonDefaulted(value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.HasPrefix(value, "...") {
+		return false
+	}
+	args, ok := strings.CutPrefix(value, "Field(")
+	if !ok {
+		args, ok = strings.CutPrefix(value, "pydantic.Field(")
+	}
+	if !ok {
+		return true
+	}
+
+	for idx, arg := range splitArguments(strings.TrimSuffix(args, ")")) {
+		name, _, keyword := strings.Cut(arg, "=")
+		name = strings.TrimSpace(name)
+		switch {
+		case keyword && (name == "default" || name == "default_factory"):
+			return true
+		case idx == 0 && !pyKeywordArg.MatchString(arg) && arg != "" && arg != "...":
+			return true
+		}
+	}
+	return false
+}
+
+var pyKeywordArg = regexp.MustCompile(`^\w+\s*=[^=]`)
+
+// splitArguments splits a call's arguments on top-level commas
+func splitArguments(args string) []string {
+	var parts []string
+	depth, start := 0, 0
+	var quote rune
+	for idx, r := range args {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(args[start:idx]))
+			start = idx + 1
+		}
+	}
+	if last := strings.TrimSpace(args[start:]); last != "" {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+func unwrapGeneric(t, name string) (string, bool) {
+	for _, prefix := range []string{name + "[", "typing." + name + "["} {
+		if strings.HasPrefix(t, prefix) && strings.HasSuffix(t, "]") {
+			return strings.TrimSpace(t[len(prefix) : len(t)-1]), true
+		}
+	}
+	return t, false
+}
+
+// TypeScript interfaces, object type aliases and zod schemas
+var (
+	tsInterfaceStart = regexp.MustCompile(`^\s*(?:export\s+)?(?:interface\s+(\w+)(?:<[^>]*>)?(?:\s+extends\s+([\w\s,<>]+?))?|type\s+(\w+)\s*=)\s*\{\s*$`)
+	tsField          = regexp.MustCompile(`^\s*(?:readonly\s+)?["']?([\w$]+)["']?(\??)\s*:\s*((?:[^;,<]|<[^>]*>)+?)\s*[;,]?\s*$`)
+	zodObjectStart   = regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let|var)\s+(\w+)\s*=\s*z\.object\(\s*\{\s*$`)
+	zodField         = regexp.MustCompile(`^\s*["']?([\w$]+)["']?\s*:\s*(z\..*?)\s*,?\s*$`)
+	zodInferAlias    = regexp.MustCompile(`type\s+(\w+)\s*=\s*z\.(?:infer|input|output)<\s*typeof\s+(\w+)\s*>`)
+	tsTypeName       = regexp.MustCompile(`^[A-Za-z_$][\w$]*(?:\.[A-Za-z_$][\w$]*)*$`)
+)
+
+func extractTypeScriptModels(file string, lines []string) []*Model {
+	var models []*Model
+	zodSchemas := make(map[string]*Model)
+
+	for idx := 0; idx < len(lines); idx++ {
+		line := lines[idx]
+
+		if m := zodInferAlias.FindStringSubmatch(line); m != nil {
+			if schema, ok := zodSchemas[m[2]]; ok {
+				alias := *schema
+				alias.Name, alias.Line = m[1], idx+1
+				models = append(models, &alias)
+			}
+			continue
+		}
+
+		var model *Model
+		parseField := func(string) (string, ModelField, bool) { return "", ModelField{}, false }
+
+		if m := tsInterfaceStart.FindStringSubmatch(line); m != nil {
+			name := m[1]
+			if name == "" {
+				name = m[3]
+			}
+			model = &Model{Name: name, File: file, Line: idx + 1, Fields: make(map[string]ModelField)}
+			for _, base := range strings.Split(m[2], ",") {
+				if base = strings.TrimSpace(base); base != "" {
+					model.bases = append(model.bases, base)
+				}
+			}
+			parseField = tsModelField
+		} else if m := zodObjectStart.FindStringSubmatch(line); m != nil {
+			model = &Model{Name: m[1], File: file, Line: idx + 1, Fields: make(map[string]ModelField)}
+			zodSchemas[m[1]] = model
+			parseField = zodModelField
+		} else {
+			continue
+		}
+
+		depth := 1
+		for idx++; idx < len(lines) && depth > 0; idx++ {
+			body := stripLineComment(lines[idx], "//")
+			if depth == 1 {
+				if name, field, ok := parseField(body); ok {
+					field.Line = idx + 1
+					model.Fields[name] = field
+				}
+			}
+			depth += strings.Count(body, "{") - strings.Count(body, "}")
+		}
+		idx--
+
+		models = append(models, model)
+	}
+
+	return models
+}
+
+func tsModelField(line string) (string, ModelField, bool) {
+	m := tsField.FindStringSubmatch(line)
+	if m == nil || strings.Contains(m[3], "(") && strings.Contains(m[3], "=>") {
+		return "", ModelField{}, false
+	}
+
+	optional := m[2] == "?"
+	var kept []string
+	for _, part := range strings.Split(m[3], "|") {
+		part = strings.TrimSpace(part)
+		if part == "null" || part == "undefined" {
+			optional = true
+			continue
+		}
+		kept = append(kept, part)
+	}
+	tsType := strings.Join(kept, "|")
+
+	specType, named := "object", ""
+	switch {
+	case strings.HasSuffix(tsType, "[]"), strings.HasPrefix(tsType, "Array<"):
+		specType = "array"
+	case strings.HasPrefix(tsType, "Record<"), strings.HasPrefix(tsType, "Map<"):
+		specType = "object"
+	case tsType == "string", strings.HasPrefix(tsType, "'"), strings.HasPrefix(tsType, `"`):
+		specType = "string"
+	case tsType == "number", tsType == "bigint":
+		specType = "number"
+	case tsType == "boolean":
+		specType = "boolean"
+	case tsType == "Date":
+		specType = "datetime"
+	case tsType == "any", tsType == "unknown":
+		specType = "any"
+	case tsTypeName.MatchString(tsType) && tsType != "object" && tsType != "Object":
+		// Interfaces, enums and type aliases such as string unions
+		specType, named = TypeUnknown, tsType[strings.LastIndex(tsType, ".")+1:]
+	}
+
+	return m[1], ModelField{Type: specType, Required: !optional, named: named}, true
+}
+
+func zodModelField(line string) (string, ModelField, bool) {
+	m := zodField.FindStringSubmatch(line)
+	if m == nil {
+		return "", ModelField{}, false
+	}
+	chain := m[2]
+
+	specType := "object"
+	switch {
+	case strings.HasPrefix(chain, "z.string().uuid"):
+		specType = "uuid"
+	case strings.HasPrefix(chain, "z.string().datetime"), strings.HasPrefix(chain, "z.date"), strings.HasPrefix(chain, "z.coerce.date"):
+		specType = "datetime"
+	case strings.HasPrefix(chain, "z.string"), strings.HasPrefix(chain, "z.enum"), strings.HasPrefix(chain, "z.literal(\""),
+		strings.HasPrefix(chain, "z.literal('"):
+		specType = "string"
+	case strings.HasPrefix(chain, "z.number().int"), strings.HasPrefix(chain, "z.coerce.number().int"):
+		specType = "integer"
+	case strings.HasPrefix(chain, "z.number"), strings.HasPrefix(chain, "z.coerce.number"), strings.HasPrefix(chain, "z.bigint"):
+		specType = "number"
+	case strings.HasPrefix(chain, "z.boolean"), strings.HasPrefix(chain, "z.coerce.boolean"):
+		specType = "boolean"
+	case strings.HasPrefix(chain, "z.array"):
+		specType = "array"
+	case strings.HasPrefix(chain, "z.any"), strings.HasPrefix(chain, "z.unknown"):
+		specType = "any"
+	}
+
+	optional := strings.Contains(chain, ".optional()") || strings.Contains(chain, ".nullish()") ||
+		strings.Contains(chain, ".nullable()") || strings.Contains(chain, ".default(")
+
+	return m[1], ModelField{Type: specType, Required: !optional}, true
+}
+
+// resolveInheritance copies fields from base models (Pydantic subclasses,
+// TypeScript interfaces using extends) into the models that inherit them
+func resolveInheritance(models map[string]*Model) {
+	resolved := make(map[string]bool)
+	var resolve func(model *Model, visiting map[string]bool)
+	resolve = func(model *Model, visiting map[string]bool) {
+		if resolved[model.Name] || visiting[model.Name] {
+			return
+		}
+		visiting[model.Name] = true
+		for _, baseName := range model.bases {
+			base, ok := models[baseName]
+			if !ok {
+				continue
+			}
+			resolve(base, visiting)
+			for name, field := range base.Fields {
+				if _, exists := model.Fields[name]; !exists {
+					model.Fields[name] = field
+				}
+			}
+		}
+		resolved[model.Name] = true
+	}
+
+	for _, model := range models {
+		resolve(model, make(map[string]bool))
+	}
+}
+
+// resolveNamedTypes resolves fields typed with another model: a model with
+// fields is an object, a Python enum deriving from str or int is a string or
+// an integer. Other named types stay TypeUnknown.
+func resolveNamedTypes(models map[string]*Model) {
+	for _, model := range models {
+		for name, field := range model.Fields {
+			if field.Type != TypeUnknown {
+				continue
+			}
+			target, ok := models[field.named]
+			if !ok {
+				continue
+			}
+			switch {
+			case hasBase(target, "str", "StrEnum"):
+				field.Type = "string"
+			case hasBase(target, "int", "IntEnum"):
+				field.Type = "integer"
+			case len(target.Fields) > 0:
+				field.Type = "object"
+			default:
+				continue
+			}
+			model.Fields[name] = field
+		}
+	}
+}
+
+func hasBase(model *Model, names ...string) bool {
+	for _, base := range model.bases {
+		for _, name := range names {
+			if base == name {
+				return true
+			}
+		}
+	}
+	return false
+}
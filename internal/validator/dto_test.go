@@ -0,0 +1,243 @@
+package validator
+
+import (
+	"testing"
+)
+
+// modelFields extracts the models of a file and returns the fields of one
+func modelFields(t *testing.T, file, content, name string) map[string]ModelField {
+	t.Helper()
+	extracted := ExtractModels(file, []byte(content))
+	models := make(map[string]*Model)
+	for _, model := range extracted {
+		models[model.Name] = model
+	}
+	resolveInheritance(models)
+	resolveNamedTypes(models)
+
+	model, ok := models[name]
+	if !ok {
+		t.Fatalf("model %s not found in %d models", name, len(extracted))
+	}
+	return model.Fields
+}
+
+type fieldCase struct {
+	field    string
+	typ      string
+	required bool
+}
+
+func checkFields(t *testing.T, fields map[string]ModelField, cases []fieldCase) {
+	t.Helper()
+	for _, tc := range cases {
+		field, ok := fields[tc.field]
+		if !ok {
+			t.Errorf("field %s not found", tc.field)
+			continue
+		}
+		if field.Type != tc.typ || field.Required != tc.required {
+			t.Errorf("field %s = %s required %v, want %s required %v", tc.field, field.Type, field.Required, tc.typ, tc.required)
+		}
+	}
+}
+
+func TestGoModelTypes(t *testing.T) {
+	content := "package dto\n\n" +
+		"type Status string\n\n" +
+		"type Address struct {\n\tCity string `json:\"city\"`\n}\n\n" +
+		"type User struct {\n" +
+		"\tID        uuid.UUID         `json:\"id\"`\n" +
+		"\tName      string            `json:\"name\"`\n" +
+		"\tAge       int64             `json:\"age,omitempty\"`\n" +
+		"\tScore     float64           `json:\"score\"`\n" +
+		"\tActive    *bool             `json:\"active\" binding:\"required\"`\n" +
+		"\tTags      []string          `json:\"tags\"`\n" +
+		"\tAvatar    []byte            `json:\"avatar\"`\n" +
+		"\tMeta      map[string]string `json:\"meta\"`\n" +
+		"\tExtra     json.RawMessage   `json:\"extra\"`\n" +
+		"\tCreatedAt time.Time         `json:\"created_at\"`\n" +
+		"\tStatus    Status            `json:\"status\"`\n" +
+		"\tAddress   *Address          `json:\"address\"`\n" +
+		"\tPassword  string            `json:\"-\"`\n" +
+		"\tinternal  string\n" +
+		"}\n"
+
+	fields := modelFields(t, "dto.go", content, "User")
+	checkFields(t, fields, []fieldCase{
+		{"id", "uuid", true},
+		{"name", "string", true},
+		{"age", "integer", false},
+		{"score", "number", true},
+		{"active", "boolean", true},
+		{"tags", "array", true},
+		{"avatar", "string", true},
+		{"meta", "object", true},
+		{"extra", "any", true},
+		{"created_at", "datetime", true},
+		{"status", TypeUnknown, true},
+		{"address", "object", false},
+	})
+	for _, skipped := range []string{"Password", "-", "internal"} {
+		if _, ok := fields[skipped]; ok {
+			t.Errorf("field %s should be skipped", skipped)
+		}
+	}
+}
+
+func TestPydanticModelTypes(t *testing.T) {
+	content := `from enum import Enum
+from pydantic import BaseModel, Field
+
+
+class Role(str, Enum):
+    ADMIN = "admin"
+
+
+class Color(Enum):
+    RED = 1
+
+
+class Address(BaseModel):
+    city: str
+
+
+class Base(BaseModel):
+    id: UUID
+
+
+class User(Base):
+    name: str
+    email: EmailStr = Field(..., description="Login")
+    nickname: str = Field(description="Shown to others")
+    bio: str = Field(default="")
+    tags: list[str] = Field(default_factory=list)
+    score: float = Field(0.5, ge=0)
+    age: Optional[int]
+    website: HttpUrl | None = None
+    created_at: datetime
+    role: Role
+    color: Color
+    address: Address
+    kind: Literal["person", "bot"]
+    attributes: dict[str, Any] = {}
+    payload: Any
+    _secret: str
+`
+
+	checkFields(t, modelFields(t, "models.py", content, "User"), []fieldCase{
+		{"id", "uuid", true},
+		{"name", "string", true},
+		{"email", "string", true},
+		{"nickname", "string", true},
+		{"bio", "string", false},
+		{"tags", "array", false},
+		{"score", "number", false},
+		{"age", "integer", false},
+		{"website", "string", false},
+		{"created_at", "datetime", true},
+		{"role", "string", true},
+		{"color", TypeUnknown, true},
+		{"address", "object", true},
+		{"kind", "string", true},
+		{"attributes", "object", false},
+		{"payload", "any", true},
+	})
+}
+
+func TestPythonDefaulted(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"", false},
+		{"None", true},
+		{"...", false},
+		{"Field(...)", false},
+		{"Field(..., description='x')", false},
+		{"Field(description='a, b')", false},
+		{"Field(min_length=1, max_length=5)", false},
+		{"Field(None)", true},
+		{"Field('guest', description='x')", true},
+		{"Field(description='x', default=None)", true},
+		{"Field(default_factory=list)", true},
+		{"pydantic.Field(default=1)", true},
+	}
+	for _, tt := range tests {
+		if got := pythonDefaulted(tt.value); got != tt.want {
+			t.Errorf("pythonDefaulted(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTypeScriptModelTypes(t *testing.T) {
+	content := `export type Status = 'active' | 'disabled';
+
+export interface Address {
+  city: string;
+}
+
+interface Entity {
+  id: string;
+}
+
+export interface User extends Entity {
+  name: string;
+  age?: number;
+  verified: boolean;
+  tags: string[];
+  roles: Array<string>;
+  createdAt: Date;
+  nickname: string | null;
+  kind: 'person' | 'bot';
+  status: Status;
+  address: Address;
+  meta: Record<string, string>;
+  inline: { a: string };
+  data: unknown;
+  onChange: (value: string) => void;
+}
+
+export const CreateUser = z.object({
+  id: z.string().uuid(),
+  email: z.string().email(),
+  age: z.number().int().optional(),
+  score: z.number(),
+  active: z.boolean().default(true),
+  tags: z.array(z.string()),
+  role: z.enum(['admin', 'user']),
+  birthday: z.coerce.date(),
+  extra: z.any(),
+});
+
+export type CreateUserInput = z.infer<typeof CreateUser>;
+`
+
+	checkFields(t, modelFields(t, "user.ts", content, "User"), []fieldCase{
+		{"id", "string", true},
+		{"name", "string", true},
+		{"age", "number", false},
+		{"verified", "boolean", true},
+		{"tags", "array", true},
+		{"roles", "array", true},
+		{"createdAt", "datetime", true},
+		{"nickname", "string", false},
+		{"kind", "string", true},
+		{"status", TypeUnknown, true},
+		{"address", "object", true},
+		{"meta", "object", true},
+		{"data", "any", true},
+	})
+
+	checkFields(t, modelFields(t, "user.ts", content, "CreateUserInput"), []fieldCase{
+		{"id", "uuid", true},
+		{"email", "string", true},
+		{"age", "integer", false},
+		{"score", "number", true},
+		{"active", "boolean", false},
+		{"tags", "array", true},
+		{"role", "string", true},
+		{"birthday", "datetime", true},
+		{"extra", "any", true},
+	})
+}
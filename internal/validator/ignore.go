@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"os"
+	"path"
+	"strings"
+)
+
+// ignoreRule is a single .gitignore pattern
+type ignoreRule struct {
+	base     string   // directory of the .gitignore, slash separated and relative to the root
+	segments []string // pattern split on "/"
+	negate   bool
+	dirOnly  bool
+}
+
+// ignoreMatcher implements the subset of .gitignore semantics needed to skip
+// files while scanning: comments, negation, anchored and unanchored patterns,
+// directory-only patterns and "**" wildcards. Later rules take precedence.
+type ignoreMatcher struct {
+	rules []ignoreRule
+}
+
+// load adds the rules of the .gitignore file in dir, if there is one. dir is
+// slash separated and relative to the scan root, with "" for the root.
+func (m *ignoreMatcher) load(root, dir string) error {
+	data, err := os.ReadFile(path.Join(root, dir, ".gitignore"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m.add(dir, line)
+	}
+	return nil
+}
+
+// add parses a single gitignore pattern relative to base
+func (m *ignoreMatcher) add(base, pattern string) {
+	rule := ignoreRule{base: base}
+	if strings.HasPrefix(pattern, "!") {
+		rule.negate = true
+		pattern = pattern[1:]
+	}
+	pattern = strings.TrimPrefix(pattern, `\`)
+	if strings.HasSuffix(pattern, "/") {
+		rule.dirOnly = true
+		pattern = strings.TrimRight(pattern, "/")
+	}
+	if pattern == "" {
+		return
+	}
+
+	// Patterns without a slash match at any depth below the .gitignore
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	rule.segments = strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	m.rules = append(m.rules, rule)
+}
+
+// ignored reports whether the slash separated path relative to the root is
+// ignored
+func (m *ignoreMatcher) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range m.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		target := rel
+		if rule.base != "" {
+			if !strings.HasPrefix(rel, rule.base+"/") {
+				continue
+			}
+			target = strings.TrimPrefix(rel, rule.base+"/")
+		}
+
+		if matchSegments(rule.segments, strings.Split(target, "/")) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matchGlob matches a slash separated path against a glob pattern where "**"
+// matches any number of directories
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(strings.Trim(pattern, "/"), "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestIgnoreMatcher(t *testing.T) {
+	var m ignoreMatcher
+	for _, pattern := range []string{"node_modules/", "*.gen.go", "/build", "!keep.gen.go", "docs/**/drafts"} {
+		m.add("", pattern)
+	}
+	m.add("services/api", "tmp/")
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"node_modules", true, true},
+		{"web/node_modules", true, true},
+		{"node_modules", false, false},
+		{"models.gen.go", false, true},
+		{"internal/models.gen.go", false, true},
+		{"keep.gen.go", false, false},
+		{"build", true, true},
+		{"cmd/build", true, false},
+		{"docs/drafts", true, true},
+		{"docs/a/b/drafts", true, true},
+		{"services/api/tmp", true, true},
+		{"services/web/tmp", true, false},
+		{"main.go", false, false},
+	}
+	for _, tt := range tests {
+		if got := m.ignored(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("ignored(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"vendor/**", "vendor/github.com/x/y.go", true},
+		{"**/*_test.go", "internal/a/b_test.go", true},
+		{"**/*_test.go", "b_test.go", true},
+		{"stubs/**", "stubsextra/a.go", false},
+		{"*.py", "app/main.py", false},
+		{"app/*.py", "app/main.py", true},
+	}
+	for _, tt := range tests {
+		if got := matchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+var (
+	// Framework-specific parameter syntaxes: {id}, {id:int}, :id, <id>, <int:id>
+	bracedParam  = regexp.MustCompile(`\{([^}:]+)(?::[^}]*)?\}`)
+	colonParam   = regexp.MustCompile(`(^|/):(\w+)\??`)
+	angleParam   = regexp.MustCompile(`<(?:\w+:)?(\w+)>`)
+	anyParamRule = regexp.MustCompile(`\{[^}]*\}`)
+)
+
+// NormalizePath converts framework-specific path parameter syntax to the
+// {param} style used in api.yaml and removes trailing slashes
+func NormalizePath(path string) string {
+	path = strings.TrimSpace(path)
+	if idx := strings.IndexAny(path, "?#"); idx >= 0 {
+		path = path[:idx]
+	}
+	path = bracedParam.ReplaceAllString(path, "{$1}")
+	path = colonParam.ReplaceAllString(path, "$1{$2}")
+	path = angleParam.ReplaceAllString(path, "{$1}")
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	return path
+}
+
+// pathKey returns a normalized path with parameter names erased so that
+// /users/{id} and /users/:userId compare equal
+func pathKey(path string) string {
+	return anyParamRule.ReplaceAllString(NormalizePath(path), "{}")
+}
+
+// joinPaths joins a router prefix and a route path
+func joinPaths(prefix, path string) string {
+	prefix = strings.Trim(prefix, "/")
+	path = strings.Trim(path, "/")
+	switch {
+	case prefix == "" && path == "":
+		return "/"
+	case prefix == "":
+		return NormalizePath(path)
+	case path == "":
+		return NormalizePath(prefix)
+	default:
+		return NormalizePath(prefix + "/" + path)
+	}
+}
+
+// specPathKeys returns the keys a spec endpoint may appear under in code,
+// both with and without the API base URL
+func specPathKeys(baseURL, path string) []string {
+	keys := []string{pathKey(path)}
+	base := strings.Trim(baseURL, "/")
+	if base == "" {
+		return keys
+	}
+
+	trimmed := strings.Trim(path, "/")
+	if strings.HasPrefix(trimmed+"/", base+"/") {
+		keys = append(keys, pathKey(strings.TrimPrefix(trimmed, base)))
+	} else {
+		keys = append(keys, pathKey(base+"/"+trimmed))
+	}
+	return keys
+}
+
+// keysMatch reports whether a code route key matches a spec key. Routers are
+// often mounted under a prefix in another file, so a code route also matches
+// when it is a segment-aligned suffix of the spec path containing at least
+// one static segment.
+func keysMatch(codeKey, specKey string) bool {
+	if codeKey == specKey {
+		return true
+	}
+	if !strings.HasSuffix(specKey, codeKey) || !strings.HasPrefix(codeKey, "/") {
+		return false
+	}
+	for _, segment := range strings.Split(strings.Trim(codeKey, "/"), "/") {
+		if segment != "{}" && segment != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// stripBasePath removes the path of the base URL from the front of a path
+func stripBasePath(path, baseURL string) string {
+	if parsed, err := url.Parse(baseURL); err == nil && parsed.Host != "" {
+		baseURL = parsed.Path
+	}
+	base := strings.Trim(baseURL, "/")
+	trimmed := strings.Trim(path, "/")
+	if base == "" || !strings.HasPrefix(trimmed+"/", base+"/") {
+		return path
+	}
+	return NormalizePath(strings.TrimPrefix(trimmed, base))
+}
+
+func methodsMatch(routeMethod, specMethod string) bool {
+	return routeMethod == MethodAny || strings.EqualFold(routeMethod, specMethod)
+}
+
+// routeMatchesEndpoint reports whether a code route implements the endpoint
+func routeMatchesEndpoint(route Route, endpoint models.Endpoint, baseURL string) bool {
+	if !methodsMatch(route.Method, endpoint.Method) {
+		return false
+	}
+	codeKey := pathKey(route.Path)
+	for _, specKey := range specPathKeys(baseURL, endpoint.Path) {
+		if keysMatch(codeKey, specKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindRoute returns the first code route implementing the given endpoint
+func FindRoute(endpoint models.Endpoint, routes []Route, baseURL string) (Route, bool) {
+	for _, route := range routes {
+		if routeMatchesEndpoint(route, endpoint, baseURL) {
+			return route, true
+		}
+	}
+	return Route{}, false
+}
+
+// UndocumentedRoutes returns code routes that do not correspond to any
+// endpoint in the specification
+func UndocumentedRoutes(api *models.API, routes []Route) []Route {
+	var undocumented []Route
+	for _, route := range routes {
+		documented := false
+		for _, endpoint := range api.Endpoints {
+			if routeMatchesEndpoint(route, endpoint, api.BaseURL) {
+				documented = true
+				break
+			}
+		}
+		if !documented {
+			undocumented = append(undocumented, route)
+		}
+	}
+	return undocumented
+}
+
+// DraftEndpoint builds a placeholder spec endpoint for an undocumented route.
+// Paths in api.yaml are relative to the base URL, so a route mounted under
+// the base path has it stripped.
+func DraftEndpoint(route Route, baseURL string) models.Endpoint {
+	method := route.Method
+	if method == MethodAny {
+		method = "GET"
+	}
+
+	endpoint := models.Endpoint{
+		Path:        stripBasePath(NormalizePath(route.Path), baseURL),
+		Method:      method,
+		Description: "TODO: document endpoint (discovered in " + route.File + ")",
+	}
+
+	params := make(map[string]string)
+	for _, m := range bracedParam.FindAllStringSubmatch(endpoint.Path, -1) {
+		params[m[1]] = "string, required"
+	}
+	if len(params) > 0 {
+		endpoint.Request = &models.EndpointRequest{Params: params}
+	}
+
+	return endpoint
+}
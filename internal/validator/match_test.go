@@ -0,0 +1,41 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestDraftEndpointStripsBasePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		route   Route
+		baseURL string
+		path    string
+		method  string
+	}{
+		{"mounted under base path", Route{Method: "GET", Path: "/api/v1/widgets/:widgetId"}, "/api/v1", "/widgets/{widgetId}", "GET"},
+		{"base URL with host", Route{Method: "POST", Path: "/api/v1/widgets"}, "https://example.com/api/v1/", "/widgets", "POST"},
+		{"not under base path", Route{Method: "GET", Path: "/health"}, "/api/v1", "/health", "GET"},
+		{"prefix of a segment only", Route{Method: "GET", Path: "/api/v10/widgets"}, "/api/v1", "/api/v10/widgets", "GET"},
+		{"base path itself", Route{Method: "GET", Path: "/api/v1"}, "/api/v1", "/", "GET"},
+		{"no base URL", Route{Method: MethodAny, Path: "/widgets/<int:id>"}, "", "/widgets/{id}", "GET"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := DraftEndpoint(tt.route, tt.baseURL)
+			if endpoint.Path != tt.path {
+				t.Errorf("path = %q, want %q", endpoint.Path, tt.path)
+			}
+			if endpoint.Method != tt.method {
+				t.Errorf("method = %q, want %q", endpoint.Method, tt.method)
+			}
+		})
+	}
+}
+
+func TestDraftEndpointParams(t *testing.T) {
+	endpoint := DraftEndpoint(Route{Method: "GET", Path: "/api/v1/widgets/:widgetId"}, "/api/v1")
+	if endpoint.Request == nil || endpoint.Request.Params["widgetId"] != "string, required" {
+		t.Fatalf("expected widgetId path param, got %+v", endpoint.Request)
+	}
+}
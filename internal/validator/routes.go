@@ -0,0 +1,586 @@
+package validator
+
+import (
+	"bufio"
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MethodAny is used for routes registered without an explicit HTTP method
+// (e.g. Django's path() or net/http's HandleFunc without a method pattern)
+const MethodAny = "ANY"
+
+// Route represents a single route definition discovered in source code
+type Route struct {
+	Method string
+	Path   string
+	File   string
+	Line   int
+
+	// Handler is the name of the handler function when the route is
+	// registered with a named handler rather than an inline function
+	Handler string
+
+	// References are the identifiers used around the route definition
+	References []string
+
+	// Auth reports whether authentication middleware, a decorator, guard or
+	// dependency was detected for the route
+	Auth bool
+}
+
+// routeExtractor extracts routes from a single file's content
+type routeExtractor func(file string, content []byte) []Route
+
+// extractors maps supported source file extensions to their route extractor
+var extractors = map[string]routeExtractor{
+	".py":   extractPythonRoutes,
+	".js":   extractJavaScriptRoutes,
+	".mjs":  extractJavaScriptRoutes,
+	".cjs":  extractJavaScriptRoutes,
+	".ts":   extractJavaScriptRoutes,
+	".go":   extractGoRoutes,
+	".java": extractSpringRoutes,
+	".kt":   extractSpringRoutes,
+	".rb":   extractRubyRoutes,
+}
+
+// IsSupportedFile reports whether routes can be extracted from the given file
+func IsSupportedFile(filename string) bool {
+	_, ok := extractors[filepath.Ext(filename)]
+	return ok
+}
+
+// ExtractRoutes returns all routes defined in the given file content
+func ExtractRoutes(file string, content []byte) []Route {
+	extract, ok := extractors[filepath.Ext(file)]
+	if !ok {
+		return nil
+	}
+	routes := extract(file, content)
+	if len(routes) > 0 {
+		attachRouteContext(routes, splitLines(content))
+	}
+	return routes
+}
+
+var httpMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// Python: FastAPI, Flask and Django
+var (
+	pyMethodDecorator = regexp.MustCompile(`@(\w+)\.(get|post|put|patch|delete|head|options)\(\s*[rf]?["']([^"']*)["']`)
+	pyRouteDecorator  = regexp.MustCompile(`@(\w+)\.(?:route|api_route)\(\s*[rf]?["']([^"']*)["'](.*)`)
+	pyMethodsArg      = regexp.MustCompile(`methods\s*=\s*[\[(]([^\])]*)[\])]`)
+	pyRouterPrefix    = regexp.MustCompile(`^\s*(\w+)\s*=\s*(?:\w+\.)?(?:APIRouter|Blueprint)\((.*)`)
+	pyPrefixArg       = regexp.MustCompile(`(?:url_)?prefix\s*=\s*["']([^"']*)["']`)
+	djangoPath        = regexp.MustCompile(`\bpath\(\s*r?["']([^"']*)["']`)
+)
+
+func extractPythonRoutes(file string, content []byte) []Route {
+	var routes []Route
+	prefixes := make(map[string]string)
+	lines := splitLines(content)
+
+	// Routers created with dependencies=[Depends(auth)] protect all their routes
+	authRouters := make(map[string]bool)
+
+	// Auth is declared by decorators around the route decorator or by
+	// Depends()/Security() parameters of the handler
+	handlerAuth := func(lineNum int) bool {
+		block := annotationBlock(lines, lineNum-1)
+		parameters := pythonParameters(lines, lineNum-1)
+		return mentionsAuth(strings.Join(block, "\n")) || mentionsAuth(parameters)
+	}
+
+	forEachLine(content, func(lineNum int, line string) {
+		if m := pyRouterPrefix.FindStringSubmatch(line); m != nil {
+			if p := pyPrefixArg.FindStringSubmatch(m[2]); p != nil {
+				prefixes[m[1]] = p[1]
+			}
+			authRouters[m[1]] = mentionsAuth(m[2])
+			return
+		}
+
+		if m := pyMethodDecorator.FindStringSubmatch(line); m != nil {
+			routes = append(routes, Route{
+				Method: strings.ToUpper(m[2]),
+				Path:   joinPaths(prefixes[m[1]], m[3]),
+				File:   file,
+				Line:   lineNum,
+				Auth:   authRouters[m[1]] || handlerAuth(lineNum),
+			})
+			return
+		}
+
+		if m := pyRouteDecorator.FindStringSubmatch(line); m != nil {
+			methods := []string{"GET"}
+			if mm := pyMethodsArg.FindStringSubmatch(m[3]); mm != nil {
+				methods = parseMethodList(mm[1])
+			}
+			auth := authRouters[m[1]] || handlerAuth(lineNum)
+			for _, method := range methods {
+				routes = append(routes, Route{
+					Method: method,
+					Path:   joinPaths(prefixes[m[1]], m[2]),
+					File:   file,
+					Line:   lineNum,
+					Auth:   auth,
+				})
+			}
+			return
+		}
+
+		if m := djangoPath.FindStringSubmatch(line); m != nil {
+			routes = append(routes, Route{
+				Method: MethodAny,
+				Path:   joinPaths("", m[1]),
+				File:   file,
+				Line:   lineNum,
+				Auth:   mentionsAuth(line),
+			})
+		}
+	})
+
+	return routes
+}
+
+// JavaScript/TypeScript: Express, Fastify, Koa, Hono and NestJS
+var (
+	jsMethodCall    = regexp.MustCompile(`\b([\w$]+)\.(get|post|put|patch|delete|head|options|all)\(\s*["'` + "`" + `](/[^"'` + "`" + `]*)["'` + "`" + `]`)
+	jsRouteCall     = regexp.MustCompile(`\b([\w$]+)\.route\(\s*["'` + "`" + `](/[^"'` + "`" + `]*)["'` + "`" + `]\s*\)`)
+	jsChainedMethod = regexp.MustCompile(`\.(get|post|put|patch|delete|head|options|all)\(`)
+	nestController  = regexp.MustCompile(`@Controller\(\s*(?:["']([^"']*)["'])?`)
+	nestMethod      = regexp.MustCompile(`@(Get|Post|Put|Patch|Delete|Head|Options|All)\(\s*(?:["']([^"']*)["'])?\s*\)`)
+	nestGuards      = regexp.MustCompile(`@UseGuards\(`)
+	jsUse           = regexp.MustCompile(`\b([\w$]+)\.use\(\s*([^"'` + "`" + `\s].*)`)
+)
+
+// jsClientReceivers are receivers whose .get()/.post() calls are HTTP client
+// requests rather than route registrations
+var jsClientReceivers = map[string]bool{
+	"axios": true, "http": true, "https": true, "client": true, "fetch": true,
+	"request": true, "superagent": true, "ky": true, "got": true, "$http": true,
+	"cy": true,
+}
+
+func extractJavaScriptRoutes(file string, content []byte) []Route {
+	var routes []Route
+	lines := splitLines(content)
+	controllerPrefix := ""
+	chainPath := ""
+
+	// Receivers with app.use(auth) protect the routes registered after it;
+	// @UseGuards on a controller protects all of its handlers
+	authReceivers := make(map[string]bool)
+	controllerAuth := false
+	chainAuth := false
+
+	forEachLine(content, func(lineNum int, line string) {
+		if nestGuards.MatchString(line) && annotatesClass(lines[lineNum:]) {
+			controllerAuth = mentionsAuth(line)
+			return
+		}
+
+		if m := nestController.FindStringSubmatch(line); m != nil {
+			controllerPrefix = m[1]
+			return
+		}
+
+		if m := nestMethod.FindStringSubmatch(line); m != nil {
+			routes = append(routes, Route{
+				Method: jsMethod(m[1]),
+				Path:   joinPaths(controllerPrefix, m[2]),
+				File:   file,
+				Line:   lineNum,
+				Auth:   controllerAuth || mentionsAuth(strings.Join(annotationBlock(lines, lineNum-1), "\n")),
+			})
+			return
+		}
+
+		if m := jsUse.FindStringSubmatch(line); m != nil && !jsClientReceivers[m[1]] {
+			if mentionsAuth(m[2]) {
+				authReceivers[m[1]] = true
+			}
+			return
+		}
+
+		// router.route('/path').get(...).post(...)
+		if m := jsRouteCall.FindStringSubmatchIndex(line); m != nil {
+			chainPath = line[m[4]:m[5]]
+			chainAuth = authReceivers[line[m[2]:m[3]]]
+			chain := line[m[1]:]
+			calls := jsChainedMethod.FindAllStringSubmatchIndex(chain, -1)
+			for i, cm := range calls {
+				// Only the arguments of this method call, up to the next one
+				args := chain[cm[1]:]
+				if i+1 < len(calls) {
+					args = chain[cm[1]:calls[i+1][0]]
+				}
+				routes = append(routes, Route{
+					Method: jsMethod(chain[cm[2]:cm[3]]),
+					Path:   joinPaths("", chainPath),
+					File:   file,
+					Line:   lineNum,
+					Auth:   chainAuth || middlewareAuth(args),
+				})
+			}
+			return
+		}
+		if chainPath != "" {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, ".") {
+				if cm := jsChainedMethod.FindStringSubmatch(trimmed); cm != nil && strings.HasPrefix(trimmed, cm[0]) {
+					routes = append(routes, Route{
+						Method: jsMethod(cm[1]),
+						Path:   joinPaths("", chainPath),
+						File:   file,
+						Line:   lineNum,
+						Auth:   chainAuth || middlewareAuth(trimmed[len(cm[0]):]),
+					})
+				}
+				return
+			}
+			if trimmed != "" && !strings.HasPrefix(trimmed, "//") {
+				chainPath = ""
+			}
+		}
+
+		for _, m := range jsMethodCall.FindAllStringSubmatchIndex(line, -1) {
+			receiver := line[m[2]:m[3]]
+			if jsClientReceivers[receiver] {
+				continue
+			}
+			routes = append(routes, Route{
+				Method: jsMethod(line[m[4]:m[5]]),
+				Path:   joinPaths("", line[m[6]:m[7]]),
+				File:   file,
+				Line:   lineNum,
+				Auth:   authReceivers[receiver] || middlewareAuth(line[m[1]:]),
+			})
+		}
+	})
+
+	return routes
+}
+
+func jsMethod(method string) string {
+	method = strings.ToUpper(method)
+	if method == "ALL" {
+		return MethodAny
+	}
+	return method
+}
+
+// Go: chi, gin, echo, gorilla/mux and net/http
+var (
+	goChiMethod     = regexp.MustCompile(`\b(\w+)\.(?:With\((.*?)\)\.)?(Get|Post|Put|Patch|Delete|Head|Options)\(\s*"(/[^"]*)"`)
+	goUpperMethod   = regexp.MustCompile(`\b(\w+)\.(GET|POST|PUT|PATCH|DELETE|HEAD|OPTIONS|Any)\(\s*"(/[^"]*)"`)
+	goHandleFunc    = regexp.MustCompile(`\b(\w+)\.(?:HandleFunc|Handle)\(\s*"(?:([A-Z]+)\s+)?(/[^"]*)"`)
+	goMuxMethods    = regexp.MustCompile(`\.Methods\(([^)]*)\)`)
+	goGroupAssign   = regexp.MustCompile(`\b(\w+)\s*:?=\s*(\w+)\.Group\(\s*"(/[^"]*)"(.*)`)
+	goChiRouteGroup = regexp.MustCompile(`\b(\w+)\.Route\(\s*"(/[^"]*)"\s*,\s*func\s*\(\s*(\w+)`)
+	goChiGroup      = regexp.MustCompile(`\b(\w+)\.(?:With\((.*?)\)\.)?Group\(\s*func\s*\(\s*(\w+)`)
+	goUse           = regexp.MustCompile(`\b(\w+)\.Use\((.*)`)
+)
+
+func extractGoRoutes(file string, content []byte) []Route {
+	var routes []Route
+	prefixes := make(map[string]string)
+	authed := make(map[string]bool)
+
+	// chi's r.Route("/prefix", func(r chi.Router) { ... }) and r.Group(...)
+	// scope a prefix and middleware to a block; track the brace depth at
+	// which each scope was opened
+	type scope struct {
+		receiver     string
+		previous     string
+		previousAuth bool
+		depth        int
+	}
+	var scopes []scope
+	depth := 0
+	openScope := func(outer, inner, prefix string, auth bool) {
+		scopes = append(scopes, scope{receiver: inner, previous: prefixes[inner], previousAuth: authed[inner], depth: depth})
+		prefixes[inner] = joinPaths(prefixes[outer], prefix)
+		authed[inner] = authed[outer] || auth
+	}
+
+	forEachLine(content, func(lineNum int, line string) {
+		code := stripLineComment(line, "//")
+
+		if m := goGroupAssign.FindStringSubmatch(code); m != nil {
+			prefixes[m[1]] = joinPaths(prefixes[m[2]], m[3])
+			authed[m[1]] = authed[m[2]] || mentionsAuth(m[4])
+		}
+
+		if m := goChiRouteGroup.FindStringSubmatch(code); m != nil {
+			openScope(m[1], m[3], m[2], false)
+		} else if m := goChiGroup.FindStringSubmatch(code); m != nil {
+			openScope(m[1], m[3], "", mentionsAuth(m[2]))
+		} else if m := goUse.FindStringSubmatch(code); m != nil && mentionsAuth(m[2]) {
+			authed[m[1]] = true
+		}
+
+		for _, m := range goChiMethod.FindAllStringSubmatchIndex(code, -1) {
+			receiver := code[m[2]:m[3]]
+			auth := authed[receiver] || middlewareAuth(code[m[1]:])
+			if m[4] >= 0 {
+				auth = auth || mentionsAuth(code[m[4]:m[5]])
+			}
+			routes = append(routes, Route{
+				Method: strings.ToUpper(code[m[6]:m[7]]),
+				Path:   joinPaths(prefixes[receiver], code[m[8]:m[9]]),
+				File:   file,
+				Line:   lineNum,
+				Auth:   auth,
+			})
+		}
+
+		for _, m := range goUpperMethod.FindAllStringSubmatchIndex(code, -1) {
+			receiver := code[m[2]:m[3]]
+			method := code[m[4]:m[5]]
+			if method == "Any" {
+				method = MethodAny
+			}
+			routes = append(routes, Route{
+				Method: method,
+				Path:   joinPaths(prefixes[receiver], code[m[6]:m[7]]),
+				File:   file,
+				Line:   lineNum,
+				Auth:   authed[receiver] || middlewareAuth(code[m[1]:]),
+			})
+		}
+
+		for _, m := range goHandleFunc.FindAllStringSubmatchIndex(code, -1) {
+			receiver := code[m[2]:m[3]]
+			methods := []string{MethodAny}
+			if m[4] >= 0 {
+				methods = []string{code[m[4]:m[5]]}
+			} else if mm := goMuxMethods.FindStringSubmatch(code); mm != nil {
+				methods = parseMethodList(mm[1])
+			}
+			args := goMuxMethods.ReplaceAllString(code[m[1]:], "")
+			for _, method := range methods {
+				routes = append(routes, Route{
+					Method: method,
+					Path:   joinPaths(prefixes[receiver], code[m[6]:m[7]]),
+					File:   file,
+					Line:   lineNum,
+					Auth:   authed[receiver] || middlewareAuth(args),
+				})
+			}
+		}
+
+		depth += strings.Count(code, "{") - strings.Count(code, "}")
+		for len(scopes) > 0 && depth <= scopes[len(scopes)-1].depth {
+			s := scopes[len(scopes)-1]
+			prefixes[s.receiver] = s.previous
+			authed[s.receiver] = s.previousAuth
+			scopes = scopes[:len(scopes)-1]
+		}
+	})
+
+	return routes
+}
+
+// Java/Kotlin: Spring MVC and Spring WebFlux annotations
+var (
+	springMapping        = regexp.MustCompile(`@(Get|Post|Put|Patch|Delete)Mapping\b(?:\(\s*(?:(?:value|path)\s*=\s*)?\{?\s*"([^"]*)")?`)
+	springRequestMapping = regexp.MustCompile(`@RequestMapping\b(?:\(\s*(?:(?:value|path)\s*=\s*)?\{?\s*"([^"]*)")?(.*)`)
+	springRequestMethod  = regexp.MustCompile(`RequestMethod\.(\w+)`)
+)
+
+func extractSpringRoutes(file string, content []byte) []Route {
+	var routes []Route
+	lines := splitLines(content)
+	classPrefix := ""
+
+	// @PreAuthorize, @Secured or @RolesAllowed on the class protect every
+	// handler; on a method they protect that handler only
+	classAuth := false
+	handlerAuth := func(idx int) bool {
+		return classAuth || mentionsAuth(strings.Join(annotationBlock(lines, idx), "\n"))
+	}
+
+	for idx, line := range lines {
+		lineNum := idx + 1
+
+		if strings.HasPrefix(strings.TrimSpace(line), "@") && mentionsAuth(line) && annotatesClass(lines[idx+1:]) {
+			classAuth = true
+		}
+
+		if m := springMapping.FindStringSubmatch(line); m != nil {
+			routes = append(routes, Route{
+				Method: strings.ToUpper(m[1]),
+				Path:   joinPaths(classPrefix, m[2]),
+				File:   file,
+				Line:   lineNum,
+				Auth:   handlerAuth(idx),
+			})
+			continue
+		}
+
+		if m := springRequestMapping.FindStringSubmatch(line); m != nil {
+			if annotatesClass(lines[idx+1:]) {
+				classPrefix = m[1]
+				continue
+			}
+			methods := []string{MethodAny}
+			if mm := springRequestMethod.FindAllStringSubmatch(m[2], -1); mm != nil {
+				methods = nil
+				for _, method := range mm {
+					methods = append(methods, strings.ToUpper(method[1]))
+				}
+			}
+			for _, method := range methods {
+				routes = append(routes, Route{Method: method, Path: joinPaths(classPrefix, m[1]), File: file, Line: lineNum, Auth: handlerAuth(idx)})
+			}
+		}
+	}
+
+	return routes
+}
+
+// annotatesClass reports whether the annotation preceding the given lines is
+// attached to a class declaration rather than a method
+func annotatesClass(following []string) bool {
+	for _, line := range following {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "@") {
+			continue
+		}
+		return strings.Contains(" "+trimmed, " class ")
+	}
+	return false
+}
+
+// Ruby: Rails routes.rb and Sinatra
+var (
+	rubyVerbRoute = regexp.MustCompile(`^\s*(get|post|put|patch|delete)\s+\(?\s*["']([^"']+)["']`)
+	railsResource = regexp.MustCompile(`^\s*(resources|resource)\s+:(\w+)(.*)`)
+	railsOnly     = regexp.MustCompile(`only:\s*\[([^\]]*)\]`)
+	rubyBlockOpen = regexp.MustCompile(`\bdo(\s*\|[^|]*\|)?\s*$`)
+	rubyBlockEnd  = regexp.MustCompile(`^\s*end\b`)
+	railsAuth     = regexp.MustCompile(`^\s*authenticated?\b`)
+)
+
+func extractRubyRoutes(file string, content []byte) []Route {
+	var routes []Route
+
+	// Devise's `authenticate :user do ... end` protects the routes inside it
+	depth := 0
+	authDepth := -1
+
+	forEachLine(content, func(lineNum int, line string) {
+		code := stripLineComment(line, "#")
+		auth := authDepth >= 0
+
+		if m := rubyVerbRoute.FindStringSubmatch(code); m != nil {
+			routes = append(routes, Route{Method: strings.ToUpper(m[1]), Path: joinPaths("", m[2]), File: file, Line: lineNum, Auth: auth})
+		} else if m := railsResource.FindStringSubmatch(code); m != nil {
+			for _, route := range expandRailsResource(m[1] == "resource", m[2], m[3], file, lineNum) {
+				route.Auth = auth
+				routes = append(routes, route)
+			}
+		}
+
+		if rubyBlockOpen.MatchString(code) {
+			if railsAuth.MatchString(code) && authDepth < 0 {
+				authDepth = depth
+			}
+			depth++
+		} else if rubyBlockEnd.MatchString(code) && depth > 0 {
+			depth--
+			if depth == authDepth {
+				authDepth = -1
+			}
+		}
+	})
+
+	return routes
+}
+
+// expandRailsResource expands `resources :users` into its RESTful JSON routes
+func expandRailsResource(singular bool, name, options, file string, line int) []Route {
+	base := "/" + name
+	member := base + "/{id}"
+	if singular {
+		member = base
+	}
+
+	actions := []struct {
+		action string
+		method string
+		path   string
+	}{
+		{"index", "GET", base},
+		{"create", "POST", base},
+		{"show", "GET", member},
+		{"update", "PUT", member},
+		{"update", "PATCH", member},
+		{"destroy", "DELETE", member},
+	}
+
+	only := map[string]bool{}
+	if m := railsOnly.FindStringSubmatch(options); m != nil {
+		for _, action := range strings.Split(m[1], ",") {
+			only[strings.Trim(strings.TrimSpace(action), `:"'`)] = true
+		}
+	}
+
+	var routes []Route
+	for _, a := range actions {
+		if singular && a.action == "index" {
+			continue
+		}
+		if len(only) > 0 && !only[a.action] {
+			continue
+		}
+		routes = append(routes, Route{Method: a.method, Path: a.path, File: file, Line: line})
+	}
+	return routes
+}
+
+// parseMethodList parses a quoted method list such as `"GET", "POST"`
+func parseMethodList(list string) []string {
+	var methods []string
+	for _, part := range strings.Split(list, ",") {
+		method := strings.ToUpper(strings.Trim(strings.TrimSpace(part), `"'`))
+		for _, known := range httpMethods {
+			if method == known {
+				methods = append(methods, method)
+			}
+		}
+	}
+	if len(methods) == 0 {
+		return []string{MethodAny}
+	}
+	return methods
+}
+
+func forEachLine(content []byte, fn func(lineNum int, line string)) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		fn(lineNum, scanner.Text())
+	}
+}
+
+func splitLines(content []byte) []string {
+	var lines []string
+	forEachLine(content, func(_ int, line string) {
+		lines = append(lines, line)
+	})
+	return lines
+}
+
+func stripLineComment(line, marker string) string {
+	if idx := strings.Index(line, marker); idx >= 0 && !strings.Contains(line[:idx], `"`) {
+		return line[:idx]
+	}
+	return line
+}
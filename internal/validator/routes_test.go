@@ -0,0 +1,167 @@
+package validator
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// routeList renders routes as sorted "METHOD path" lines
+func routeList(routes []Route) []string {
+	var list []string
+	for _, route := range routes {
+		list = append(list, route.Method+" "+route.Path)
+	}
+	sort.Strings(list)
+	return list
+}
+
+func TestExtractRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []string
+	}{
+		{
+			name: "fastapi router prefix",
+			file: "app/users.py",
+			content: `router = APIRouter(prefix="/users")
+
+@router.get("/{user_id}")
+def get_user(user_id: int):
+    pass
+
+@router.post("")
+def create_user():
+    pass
+`,
+			want: []string{"GET /users/{user_id}", "POST /users"},
+		},
+		{
+			name: "flask route with methods",
+			file: "app.py",
+			content: `@app.route("/orders/<int:id>", methods=["GET", "DELETE"])
+def order(id):
+    pass
+`,
+			want: []string{"DELETE /orders/{id}", "GET /orders/{id}"},
+		},
+		{
+			name:    "django path",
+			file:    "urls.py",
+			content: `urlpatterns = [path("health/", views.health)]`,
+			want:    []string{"ANY /health"},
+		},
+		{
+			name: "express skips http clients",
+			file: "server.js",
+			content: `app.get('/users/:id', getUser)
+router.delete("/users/:id", removeUser)
+axios.get('/upstream')
+`,
+			want: []string{"DELETE /users/{id}", "GET /users/{id}"},
+		},
+		{
+			name: "nest controller",
+			file: "users.controller.ts",
+			content: `@Controller('users')
+export class UsersController {
+  @Get(':id')
+  findOne() {}
+
+  @Post()
+  create() {}
+}
+`,
+			want: []string{"GET /users/{id}", "POST /users"},
+		},
+		{
+			name: "chi route groups",
+			file: "routes.go",
+			content: `func routes(r chi.Router) {
+	r.Route("/users", func(r chi.Router) {
+		r.Get("/{id}", getUser)
+	})
+	r.Post("/login", login)
+}
+`,
+			want: []string{"GET /users/{id}", "POST /login"},
+		},
+		{
+			name: "gin groups and net/http patterns",
+			file: "main.go",
+			content: `func main() {
+	api := router.Group("/api")
+	api.GET("/orders", listOrders)
+	mux.HandleFunc("DELETE /orders/{id}", deleteOrder)
+}
+`,
+			want: []string{"DELETE /orders/{id}", "GET /api/orders"},
+		},
+		{
+			name: "spring class mapping",
+			file: "UserController.java",
+			content: `@RestController
+@RequestMapping("/users")
+public class UserController {
+    @GetMapping("/{id}")
+    public User get(@PathVariable Long id) { return null; }
+
+    @RequestMapping(value = "/{id}", method = RequestMethod.DELETE)
+    public void delete(@PathVariable Long id) {}
+}
+`,
+			want: []string{"DELETE /users/{id}", "GET /users/{id}"},
+		},
+		{
+			name: "rails resources",
+			file: "config/routes.rb",
+			content: `Rails.application.routes.draw do
+  resources :photos, only: [:index, :show]
+  get "/health", to: "health#show"
+end
+`,
+			want: []string{"GET /health", "GET /photos", "GET /photos/{id}"},
+		},
+		{
+			name:    "unsupported file",
+			file:    "README.md",
+			content: `app.get('/users', handler)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := routeList(ExtractRoutes(tt.file, []byte(tt.content)))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("routes = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUndocumentedRoutes(t *testing.T) {
+	api := &models.API{
+		BaseURL: "/api/v1",
+		Endpoints: []models.Endpoint{
+			{Method: "GET", Path: "/users/{id}"},
+			{Method: "POST", Path: "/users"},
+		},
+	}
+	routes := []Route{
+		{Method: "GET", Path: "/api/v1/users/:userId"},
+		{Method: "POST", Path: "/users"},
+		{Method: MethodAny, Path: "/api/v1/users"},
+		{Method: "DELETE", Path: "/api/v1/users/<int:id>"},
+		{Method: "GET", Path: "/health"},
+	}
+
+	got := routeList(UndocumentedRoutes(api, routes))
+	want := []string{"DELETE /api/v1/users/<int:id>", "GET /health"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("undocumented = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,250 @@
+package validator
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// DefaultExcludeDirs are directory names that are never scanned
+var DefaultExcludeDirs = []string{
+	"node_modules", "vendor", ".git", ".architect", "venv", ".venv", "__pycache__", "dist", "build",
+}
+
+// DefaultExcludes are globs for files that define routes only for tests
+var DefaultExcludes = []string{
+	"**/*_test.go", "**/*.test.*", "**/*.spec.*", "**/test_*.py", "**/*_test.py",
+}
+
+// GeneratedMarker is in the header of handler stubs generated by
+// `validate --fix`. Files carrying it are not scanned, wherever they were
+// moved to, since stubs implement endpoints in name only.
+const GeneratedMarker = "generated by `architect validate --fix`"
+
+// generatedHeaderSize is how much of a file is searched for GeneratedMarker
+const generatedHeaderSize = 512
+
+// ScanOptions controls which files Scan reads
+type ScanOptions struct {
+	// Root is the directory to scan recursively, "." when empty
+	Root string
+
+	// Include restricts scanning to files matching at least one glob. All
+	// supported files are scanned when empty.
+	Include []string
+
+	// Exclude skips files and directories matching any glob, in addition
+	// to DefaultExcludeDirs, DefaultExcludes and .gitignore rules
+	Exclude []string
+
+	// Workers is the number of files parsed concurrently, runtime.NumCPU()
+	// when zero
+	Workers int
+}
+
+// Index holds everything extracted from the scanned source files
+type Index struct {
+	Routes []Route
+	Models map[string]*Model
+
+	// functionRefs maps handler function names to the identifiers used in
+	// their bodies
+	functionRefs map[string][]string
+}
+
+// fileIndex is what a single file contributes to the index
+type fileIndex struct {
+	routes       []Route
+	models       []*Model
+	functionRefs map[string][]string
+	err          error
+}
+
+// Scan walks the source tree once, skipping ignored paths, and indexes the
+// routes and DTO models of every supported file using a pool of workers
+func Scan(opts ScanOptions) (*Index, error) {
+	files, err := sourceFiles(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]fileIndex, len(files))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = indexFile(files[i])
+			}
+		}()
+	}
+	for i := range files {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	// Merge in file order so the result does not depend on scheduling
+	index := &Index{
+		Models:       make(map[string]*Model),
+		functionRefs: make(map[string][]string),
+	}
+	for _, result := range results {
+		if result.err != nil {
+			return nil, result.err
+		}
+		index.merge(result)
+	}
+
+	index.finalize()
+	return index, nil
+}
+
+// sourceFiles lists the supported files below the root in lexical order
+func sourceFiles(opts ScanOptions) ([]string, error) {
+	root := opts.Root
+	if root == "" {
+		root = "."
+	}
+
+	skipDirs := make(map[string]bool)
+	for _, dir := range DefaultExcludeDirs {
+		skipDirs[dir] = true
+	}
+	excludes := append(append([]string{}, DefaultExcludes...), opts.Exclude...)
+
+	gitignore := &ignoreMatcher{}
+	var files []string
+	err := filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if entry.IsDir() {
+			if rel == "." {
+				return gitignore.load(root, "")
+			}
+			if skipDirs[entry.Name()] || gitignore.ignored(rel, true) || matchesAny(excludes, rel) {
+				return filepath.SkipDir
+			}
+			return gitignore.load(root, rel)
+		}
+
+		if !IsSupportedFile(entry.Name()) || gitignore.ignored(rel, false) || matchesAny(excludes, rel) {
+			return nil
+		}
+		if len(opts.Include) > 0 && !matchesAny(opts.Include, rel) {
+			return nil
+		}
+		files = append(files, file)
+		return nil
+	})
+	return files, err
+}
+
+func matchesAny(globs []string, rel string) bool {
+	for _, glob := range globs {
+		if matchGlob(glob, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// indexFile extracts routes, models and handler references from one file
+func indexFile(file string) fileIndex {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fileIndex{err: err}
+	}
+	if isGenerated(content) {
+		return fileIndex{}
+	}
+
+	models := ExtractModels(file, content)
+	for _, model := range models {
+		for name, field := range model.Fields {
+			field.File = model.File
+			model.Fields[name] = field
+		}
+	}
+
+	return fileIndex{
+		routes:       ExtractRoutes(file, content),
+		models:       models,
+		functionRefs: extractFunctionReferences(file, splitLines(content)),
+	}
+}
+
+// isGenerated reports whether a file starts with the stub header
+func isGenerated(content []byte) bool {
+	header := content
+	if len(header) > generatedHeaderSize {
+		header = header[:generatedHeaderSize]
+	}
+	return bytes.Contains(header, []byte(GeneratedMarker))
+}
+
+// merge adds the contents of a single file to the index. The first model
+// with a given name wins.
+func (idx *Index) merge(result fileIndex) {
+	idx.Routes = append(idx.Routes, result.routes...)
+	for _, model := range result.models {
+		if _, exists := idx.Models[model.Name]; !exists {
+			idx.Models[model.Name] = model
+		}
+	}
+	mergeReferences(idx.functionRefs, result.functionRefs)
+}
+
+// finalize sorts routes and resolves model inheritance and named field types
+// once all files are indexed
+func (idx *Index) finalize() {
+	sort.SliceStable(idx.Routes, func(a, b int) bool {
+		if idx.Routes[a].File != idx.Routes[b].File {
+			return idx.Routes[a].File < idx.Routes[b].File
+		}
+		return idx.Routes[a].Line < idx.Routes[b].Line
+	})
+	resolveInheritance(idx.Models)
+	resolveNamedTypes(idx.Models)
+}
+
+// ReferencedModels returns the DTO models referenced by a route, either
+// directly around its definition or inside its named handler function
+func (idx *Index) ReferencedModels(route Route) []*Model {
+	refs := route.References
+	if route.Handler != "" {
+		refs = append(append([]string{}, refs...), idx.functionRefs[route.Handler]...)
+	}
+
+	seen := make(map[string]bool)
+	var models []*Model
+	for _, ref := range refs {
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		if model, ok := idx.Models[ref]; ok {
+			models = append(models, model)
+		}
+	}
+	return models
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanSkipsStubsAndIgnoredFiles(t *testing.T) {
+	root := t.TempDir()
+	stubHeader := "# Handler stubs for the orders resource, " + GeneratedMarker + ".\n"
+	files := map[string]string{
+		"app/routes.py":             "@app.get('/orders')\ndef list_orders():\n    pass\n",
+		"stubs/orders.py":           "@router.post('/orders')\ndef create_order():\n    pass\n",
+		"app/moved_stub.py":         stubHeader + "@router.delete('/orders/{id}')\ndef delete_order():\n    pass\n",
+		"node_modules/lib/index.js": "app.get('/vendored', handler)\n",
+		"app/test_routes.py":        "@app.get('/only-in-tests')\ndef test_route():\n    pass\n",
+		"ignored/routes.py":         "@app.get('/gitignored')\ndef ignored():\n    pass\n",
+		".gitignore":                "ignored/\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		exclude []string
+		want    []string
+	}{
+		{"generated files skipped by their header", nil, []string{"GET /orders", "POST /orders"}},
+		{"stubs directory excluded", []string{"stubs"}, []string{"GET /orders"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			index, err := Scan(ScanOptions{Root: root, Exclude: tt.exclude, Workers: 2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, route := range index.Routes {
+				got = append(got, route.Method+" "+route.Path)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("routes = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("routes = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestIsGenerated(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"stub header", "// Handler stubs for the users resource, " + GeneratedMarker + ".\npackage handlers\n", true},
+		{"hand-written", "package handlers\n\nfunc ListUsers() {}\n", false},
+		{"marker past the header", string(make([]byte, generatedHeaderSize)) + GeneratedMarker, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isGenerated([]byte(tt.content)); got != tt.want {
+				t.Errorf("isGenerated = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
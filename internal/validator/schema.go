@@ -0,0 +1,180 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/faisalahmedsifat/architect/internal/models"
+)
+
+// Severity describes how serious a validation finding is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule identifiers reported by validate
+const (
+	RuleEndpointNotImplemented = "endpoint-not-implemented"
+	RuleRouteUndocumented      = "route-undocumented"
+	RuleSchemaMissingField     = "schema-missing-field"
+	RuleSchemaTypeMismatch     = "schema-type-mismatch"
+	RuleSchemaRequiredMismatch = "schema-required-mismatch"
+	RuleSchemaUndeclaredField  = "schema-undeclared-field"
+	RuleAuthMissing            = "auth-missing"
+	RuleAuthUnexpected         = "auth-unexpected"
+)
+
+// RuleDescriptions describes every rule reported by validate
+var RuleDescriptions = map[string]string{
+	RuleEndpointNotImplemented: "Endpoint declared in api.yaml has no matching route in code",
+	RuleRouteUndocumented:      "Route defined in code is not declared in api.yaml",
+	RuleSchemaMissingField:     "Declared body field is missing from the DTO",
+	RuleSchemaTypeMismatch:     "DTO field type does not match the declared type",
+	RuleSchemaRequiredMismatch: "DTO field required/optional does not match api.yaml",
+	RuleSchemaUndeclaredField:  "DTO field is not declared in api.yaml",
+	RuleAuthMissing:            "Endpoint requires auth in api.yaml but no auth middleware was found",
+	RuleAuthUnexpected:         "Route is behind auth middleware but api.yaml declares it public",
+}
+
+// Issue describes a mismatch between an endpoint declared in api.yaml and
+// the code implementing it, such as a request/response body that does not
+// match its DTO
+type Issue struct {
+	Rule     string
+	Severity Severity
+	Kind     string // "request", "response" or "auth"
+	Field    string
+	Message  string
+	Model    *Model
+	File     string
+	Line     int
+}
+
+// CheckSchemas compares the request and response bodies declared for an
+// endpoint with the DTO models referenced by the route implementing it
+func CheckSchemas(endpoint models.Endpoint, route Route, index *Index) []Issue {
+	candidates := index.ReferencedModels(route)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	var issues []Issue
+	if endpoint.Request != nil && len(endpoint.Request.Body) > 0 {
+		if model := bestMatchingModel(endpoint.Request.Body, candidates); model != nil {
+			issues = append(issues, compareFields("request", endpoint.Request.Body, model)...)
+		}
+	}
+	if endpoint.Response != nil && len(endpoint.Response.Body) > 0 {
+		if model := bestMatchingModel(endpoint.Response.Body, candidates); model != nil {
+			issues = append(issues, compareFields("response", endpoint.Response.Body, model)...)
+		}
+	}
+	return issues
+}
+
+// bestMatchingModel picks the candidate sharing the most field names with the
+// declared body. Models without any overlap are not considered linked.
+func bestMatchingModel(fields map[string]string, candidates []*Model) *Model {
+	var best *Model
+	bestScore := 0
+	for _, model := range candidates {
+		score := 0
+		for name := range fields {
+			if _, ok := model.Fields[name]; ok {
+				score++
+			}
+		}
+		if score > bestScore {
+			best, bestScore = model, score
+		}
+	}
+	return best
+}
+
+func compareFields(kind string, declared map[string]string, model *Model) []Issue {
+	var issues []Issue
+	add := func(rule string, severity Severity, field, format string, args ...interface{}) {
+		issue := Issue{
+			Rule:     rule,
+			Severity: severity,
+			Kind:     kind,
+			Field:    field,
+			Message:  fmt.Sprintf(format, args...),
+			Model:    model,
+			File:     model.File,
+			Line:     model.Line,
+		}
+		if f, ok := model.Fields[field]; ok && f.Line > 0 {
+			issue.File, issue.Line = f.File, f.Line
+		}
+		issues = append(issues, issue)
+	}
+
+	for _, name := range sortedKeys(declared) {
+		spec := models.ParseField(declared[name])
+		code, ok := model.Fields[name]
+		if !ok {
+			if spec.Required {
+				add(RuleSchemaMissingField, SeverityError, name, "%s field '%s' is missing from %s", kind, name, model.Name)
+			} else {
+				add(RuleSchemaMissingField, SeverityWarning, name, "%s field '%s' is missing from %s", kind, name, model.Name)
+			}
+			continue
+		}
+
+		if !typesCompatible(spec.Type, code.Type) {
+			add(RuleSchemaTypeMismatch, SeverityError, name, "%s field '%s' has type %s in %s, expected %s", kind, name, code.Type, model.Name, spec.Type)
+		}
+
+		if spec.Required && !code.Required {
+			add(RuleSchemaRequiredMismatch, SeverityWarning, name, "%s field '%s' is required in api.yaml but optional in %s", kind, name, model.Name)
+		} else if spec.Optional && code.Required {
+			add(RuleSchemaRequiredMismatch, SeverityWarning, name, "%s field '%s' is optional in api.yaml but required in %s", kind, name, model.Name)
+		}
+	}
+
+	var extra []string
+	for name := range model.Fields {
+		if _, ok := declared[name]; !ok {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		add(RuleSchemaUndeclaredField, SeverityWarning, name, "%s field '%s' in %s is not declared in api.yaml", kind, name, model.Name)
+	}
+
+	return issues
+}
+
+// typesCompatible reports whether a code type satisfies a declared api.yaml type
+func typesCompatible(specType, codeType string) bool {
+	if specType == codeType || codeType == "any" || codeType == TypeUnknown {
+		return true
+	}
+
+	switch specType {
+	case "string", "uuid", "datetime":
+		return codeType == "string" || codeType == "uuid" || codeType == "datetime"
+	case "integer", "number":
+		// TypeScript and zod only have a single number type
+		return codeType == "integer" || codeType == "number"
+	case "boolean", "array", "object":
+		return false
+	default:
+		// Unknown declared types cannot be checked
+		return true
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
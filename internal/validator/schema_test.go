@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"testing"
+)
+
+func TestTypesCompatible(t *testing.T) {
+	tests := []struct {
+		spec, code string
+		want       bool
+	}{
+		{"string", "string", true},
+		{"string", "uuid", true},
+		{"uuid", "string", true},
+		{"datetime", "string", true},
+		{"integer", "number", true},
+		{"number", "integer", true},
+		{"string", "integer", false},
+		{"boolean", "string", false},
+		{"array", "object", false},
+		{"object", "array", false},
+		{"string", "any", true},
+		{"string", TypeUnknown, true},
+		{"integer", TypeUnknown, true},
+		{"email", "integer", true},
+	}
+	for _, tt := range tests {
+		if got := typesCompatible(tt.spec, tt.code); got != tt.want {
+			t.Errorf("typesCompatible(%q, %q) = %v, want %v", tt.spec, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestCompareFields(t *testing.T) {
+	model := &Model{
+		Name: "CreateUser",
+		File: "dto.go",
+		Line: 3,
+		Fields: map[string]ModelField{
+			"email":  {Type: "string", Required: true, File: "dto.go", Line: 4},
+			"age":    {Type: "string", Required: true, File: "dto.go", Line: 5},
+			"status": {Type: TypeUnknown, Required: true, File: "dto.go", Line: 6},
+			"bio":    {Type: "string", Required: true, File: "dto.go", Line: 7},
+			"admin":  {Type: "boolean", Required: false, File: "dto.go", Line: 8},
+		},
+	}
+	declared := map[string]string{
+		"email":    "string, required",
+		"age":      "integer, required",
+		"status":   "string, required",
+		"bio":      "string, optional",
+		"password": "string, required",
+		"locale":   "string, optional",
+	}
+
+	want := map[string]string{
+		"age":      RuleSchemaTypeMismatch,
+		"bio":      RuleSchemaRequiredMismatch,
+		"password": RuleSchemaMissingField,
+		"locale":   RuleSchemaMissingField,
+		"admin":    RuleSchemaUndeclaredField,
+	}
+	severities := map[string]Severity{
+		"age":      SeverityError,
+		"bio":      SeverityWarning,
+		"password": SeverityError,
+		"locale":   SeverityWarning,
+		"admin":    SeverityWarning,
+	}
+
+	issues := compareFields("request", declared, model)
+	got := make(map[string]string)
+	for _, issue := range issues {
+		got[issue.Field] = issue.Rule
+		if issue.Severity != severities[issue.Field] {
+			t.Errorf("%s: severity %s, want %s", issue.Field, issue.Severity, severities[issue.Field])
+		}
+	}
+	if len(issues) != len(want) {
+		t.Errorf("issues = %+v, want rules %v", issues, want)
+	}
+	for field, rule := range want {
+		if got[field] != rule {
+			t.Errorf("%s: rule %q, want %q", field, got[field], rule)
+		}
+	}
+}